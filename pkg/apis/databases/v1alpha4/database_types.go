@@ -45,6 +45,30 @@ type DatabaseSpec struct {
 	DeploySeedData  bool              `json:"deploySeedData,omitempty"` // TODO remove this for envs in 0.13.0
 	SchemaHero      *SchemaHero       `json:"schemahero,omitempty"`
 	Template        *DatabaseTemplate `json:"template,omitempty"`
+
+	// MaintenanceWindows limits when approved migrations are executed against this database.
+	// When empty, migrations are executed as soon as they are approved
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// MigrationLedger records every migration that is executed against this database
+	// in a schemahero_migrations table in the database
+	MigrationLedger bool `json:"migrationLedger,omitempty"`
+}
+
+// MaintenanceWindow is a recurring range of time that migrations are allowed to execute in
+type MaintenanceWindow struct {
+	// Days is the list of weekdays (monday, tue, ...) that the window opens on. When empty, the window opens every day
+	Days []string `json:"days,omitempty"`
+
+	// Start is the time of day (HH:MM, 24 hour clock) that the window opens
+	Start string `json:"start"`
+
+	// End is the time of day (HH:MM, 24 hour clock) that the window closes. When End is not after Start,
+	// the window closes on the following day
+	End string `json:"end"`
+
+	// Timezone is the IANA name of the timezone Start and End are in. Defaults to UTC
+	Timezone string `json:"timezone,omitempty"`
 }
 
 type DatabaseTemplate struct {
@@ -55,8 +79,26 @@ type DatabaseTemplate struct {
 type DatabaseStatus struct {
 	IsConnected bool   `json:"isConnected"`
 	LastPing    string `json:"lastPing"`
+
+	// EngineVersion is the version of the database engine reported by the last successful connection
+	EngineVersion string `json:"engineVersion,omitempty"`
+
+	// LatencyMilliseconds is how long it took to connect to the database in the last connection check
+	LatencyMilliseconds int64 `json:"latencyMilliseconds,omitempty"`
+
+	// LastError is the error from the last connection check, and is empty when the check succeeded
+	LastError string `json:"lastError,omitempty"`
+
+	// Conditions are the latest observations of the database. The Connected condition is
+	// false when SchemaHero is unable to connect to the database
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// DatabaseConditionConnected is the condition type that reports if the database is reachable
+	DatabaseConditionConnected = "Connected"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
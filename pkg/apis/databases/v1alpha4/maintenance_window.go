@@ -0,0 +1,110 @@
+package v1alpha4
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"sun":       time.Sunday,
+	"monday":    time.Monday,
+	"mon":       time.Monday,
+	"tuesday":   time.Tuesday,
+	"tue":       time.Tuesday,
+	"wednesday": time.Wednesday,
+	"wed":       time.Wednesday,
+	"thursday":  time.Thursday,
+	"thu":       time.Thursday,
+	"friday":    time.Friday,
+	"fri":       time.Friday,
+	"saturday":  time.Saturday,
+	"sat":       time.Saturday,
+}
+
+// NextMaintenanceWindow returns the earliest time, at or after now, that migrations can be executed
+// against this database. If no maintenance windows are configured or now is inside of a window,
+// now is returned
+func (d Database) NextMaintenanceWindow(now time.Time) (time.Time, error) {
+	if len(d.Spec.MaintenanceWindows) == 0 {
+		return now, nil
+	}
+
+	var next time.Time
+	for _, window := range d.Spec.MaintenanceWindows {
+		opensAt, err := window.nextOpen(now)
+		if err != nil {
+			return time.Time{}, errors.Wrap(err, "failed to calculate next maintenance window")
+		}
+
+		if !opensAt.After(now) {
+			return now, nil
+		}
+
+		if next.IsZero() || opensAt.Before(next) {
+			next = opensAt
+		}
+	}
+
+	return next, nil
+}
+
+// nextOpen returns now if the window is currently open, or the time that the window will next open
+func (w MaintenanceWindow) nextOpen(now time.Time) (time.Time, error) {
+	location := time.UTC
+	if w.Timezone != "" {
+		l, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			return time.Time{}, errors.Wrapf(err, "failed to load timezone %q", w.Timezone)
+		}
+		location = l
+	}
+
+	start, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "failed to parse start time %q", w.Start)
+	}
+	end, err := time.Parse("15:04", w.End)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "failed to parse end time %q", w.End)
+	}
+
+	days := map[time.Weekday]bool{}
+	for _, day := range w.Days {
+		weekday, ok := weekdays[strings.ToLower(strings.TrimSpace(day))]
+		if !ok {
+			return time.Time{}, errors.Errorf("unknown day %q", day)
+		}
+		days[weekday] = true
+	}
+
+	duration := end.Sub(start)
+	if duration <= 0 {
+		duration += 24 * time.Hour
+	}
+
+	// start looking on the previous day because a window that opened yesterday might still be open
+	local := now.In(location)
+	for i := -1; i <= 7; i++ {
+		day := local.AddDate(0, 0, i)
+		opensAt := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, location)
+		if len(days) > 0 && !days[opensAt.Weekday()] {
+			continue
+		}
+
+		closesAt := opensAt.Add(duration)
+		if !now.Before(closesAt) {
+			continue
+		}
+
+		if now.Before(opensAt) {
+			return opensAt, nil
+		}
+
+		return now, nil
+	}
+
+	return time.Time{}, errors.New("maintenance window never opens")
+}
@@ -0,0 +1,133 @@
+package v1alpha4
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NextMaintenanceWindow(t *testing.T) {
+	// 2022-11-02 is a wednesday
+	now := time.Date(2022, 11, 2, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		windows []MaintenanceWindow
+		expect  time.Time
+	}{
+		{
+			name:    "no windows",
+			windows: nil,
+			expect:  now,
+		},
+		{
+			name: "inside of window",
+			windows: []MaintenanceWindow{
+				{
+					Start: "12:00",
+					End:   "13:00",
+				},
+			},
+			expect: now,
+		},
+		{
+			name: "later today",
+			windows: []MaintenanceWindow{
+				{
+					Start: "22:00",
+					End:   "23:00",
+				},
+			},
+			expect: time.Date(2022, 11, 2, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "already closed today",
+			windows: []MaintenanceWindow{
+				{
+					Start: "02:00",
+					End:   "04:00",
+				},
+			},
+			expect: time.Date(2022, 11, 3, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "overnight window opened yesterday",
+			windows: []MaintenanceWindow{
+				{
+					Start: "22:00",
+					End:   "13:00",
+				},
+			},
+			expect: now,
+		},
+		{
+			name: "weekend only",
+			windows: []MaintenanceWindow{
+				{
+					Days:  []string{"saturday", "Sun"},
+					Start: "00:00",
+					End:   "06:00",
+				},
+			},
+			expect: time.Date(2022, 11, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "earliest of multiple windows",
+			windows: []MaintenanceWindow{
+				{
+					Start: "20:00",
+					End:   "21:00",
+				},
+				{
+					Start: "14:00",
+					End:   "15:00",
+				},
+			},
+			expect: time.Date(2022, 11, 2, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "with timezone",
+			windows: []MaintenanceWindow{
+				{
+					Start:    "02:00",
+					End:      "04:00",
+					Timezone: "America/New_York",
+				},
+			},
+			expect: time.Date(2022, 11, 3, 6, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := Database{
+				Spec: DatabaseSpec{
+					MaintenanceWindows: test.windows,
+				},
+			}
+
+			actual, err := d.NextMaintenanceWindow(now)
+			require.NoError(t, err)
+			assert.True(t, test.expect.Equal(actual), "expected %s, got %s", test.expect, actual)
+		})
+	}
+}
+
+func Test_NextMaintenanceWindowInvalid(t *testing.T) {
+	d := Database{
+		Spec: DatabaseSpec{
+			MaintenanceWindows: []MaintenanceWindow{
+				{
+					Days:  []string{"someday"},
+					Start: "02:00",
+					End:   "04:00",
+				},
+			},
+		},
+	}
+
+	_, err := d.NextMaintenanceWindow(time.Now())
+	assert.Error(t, err)
+}
@@ -16,6 +16,14 @@ limitations under the License.
 
 package v1alpha4
 
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
 type PostgresConnection struct {
 	URI ValueOrValueFrom `json:"uri,omitempty"`
 
@@ -26,4 +34,32 @@ type PostgresConnection struct {
 	DBName        ValueOrValueFrom `json:"dbname,omitempty"`
 	SSLMode       ValueOrValueFrom `json:"sslmode,omitempty"`
 	CurrentSchema ValueOrValueFrom `json:"schema,omitempty"`
+
+	// Extensions are installed in the database, and updated to their version, through migrations that are
+	// approved the same way as table migrations. Extensions that are not listed are never dropped
+	Extensions []PostgresExtension `json:"extensions,omitempty"`
+}
+
+// PostgresExtension is an extension that must be installed in the database
+type PostgresExtension struct {
+	Name string `json:"name"`
+
+	// Version is the version of the extension to install or update to. The default version of the extension
+	// is installed, and an installed extension is never updated, when this is empty
+	Version string `json:"version,omitempty"`
+
+	// Schema is the schema to install the objects of the extension in. The current schema is used when this
+	// is empty
+	Schema string `json:"schema,omitempty"`
+}
+
+// GetExtensionsSHA returns the sha of the extensions, which identifies the migration that was planned for them
+func (c PostgresConnection) GetExtensionsSHA() (string, error) {
+	b, err := json.Marshal(c.Extensions)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal")
+	}
+
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("%x", sum), nil
 }
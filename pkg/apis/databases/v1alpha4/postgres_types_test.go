@@ -35,3 +35,25 @@ uri:
 	g.Expect(err).NotTo(gomega.HaveOccurred())
 	g.Expect(p.URI.Value).To(gomega.Equal("baz"))
 }
+
+func TestPostgresExtensionsSHA(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	p := PostgresConnection{
+		Extensions: []PostgresExtension{
+			{Name: "pgcrypto"},
+		},
+	}
+	sha, err := p.GetExtensionsSHA()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	p.URI.Value = "baz"
+	sameSHA, err := p.GetExtensionsSHA()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(sameSHA).To(gomega.Equal(sha))
+
+	p.Extensions[0].Version = "1.3"
+	updatedSHA, err := p.GetExtensionsSHA()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(updatedSHA).NotTo(gomega.Equal(sha))
+}
@@ -20,6 +20,10 @@ type CassandraColumn struct {
 	Name     string `json:"name" yaml:"name"`
 	Type     string `json:"type" yaml:"type"`
 	IsStatic *bool  `json:"isStatic,omitempty" yaml:"isStatic,omitempty"`
+
+	// RenamedFrom is the previous name of the column. When the table has a column with this name, and no column
+	// with the new name, the column is renamed instead of being dropped and added
+	RenamedFrom string `json:"renamedFrom,omitempty" yaml:"renamedFrom,omitempty"`
 }
 
 type CassandraClusteringOrder struct {
@@ -0,0 +1,21 @@
+package v1alpha4
+
+// Condition types that are set on the status of tables and views
+const (
+	// ConditionDatabaseFound is true when the database that the table or view is deployed to exists
+	ConditionDatabaseFound = "DatabaseFound"
+
+	// ConditionPlanned is true when a migration has been planned for the current spec, or planning
+	// found that no changes are needed
+	ConditionPlanned = "Planned"
+
+	// ConditionMigrationPending is true when the migration for the current spec is waiting to be
+	// approved or executed
+	ConditionMigrationPending = "MigrationPending"
+
+	// ConditionApplied is true when the database matches the current spec
+	ConditionApplied = "Applied"
+
+	// ConditionError is true when the last reconcile failed
+	ConditionError = "Error"
+)
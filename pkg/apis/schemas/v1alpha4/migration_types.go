@@ -30,6 +30,49 @@ const (
 	Invalid  Phase = "INVALID"
 )
 
+const (
+	// MigrationOwnerKindLabel is the kind (Table, View or Database) of the object that a migration was planned for
+	MigrationOwnerKindLabel = "schemas.schemahero.io/owner-kind"
+
+	// MigrationOwnerUIDLabel is the uid of the object that a migration was planned for
+	MigrationOwnerUIDLabel = "schemas.schemahero.io/owner-uid"
+
+	// MigrationGenerationLabel is the generation of the object that a migration was planned for
+	MigrationGenerationLabel = "schemas.schemahero.io/generation"
+
+	// MigrationSpecSHALabel is the sha of the spec that a migration was planned for. Label values are
+	// limited to 63 characters, so this is truncated. The full sha is in the MigrationSpecSHAAnnotation
+	MigrationSpecSHALabel = "schemas.schemahero.io/spec-sha"
+
+	// MigrationSpecSHAAnnotation is the full sha of the spec that a migration was planned for
+	MigrationSpecSHAAnnotation = "schemas.schemahero.io/spec-sha"
+
+	// AllowDestructiveChangesAnnotation is set to "true" on a table to allow migrations that can lose data,
+	// such as dropping the table or a column, to be executed
+	AllowDestructiveChangesAnnotation = "schemas.schemahero.io/allow-destructive-changes"
+)
+
+const (
+	// MigrationRiskSafe migrations don't remove data and don't hold long locks
+	MigrationRiskSafe = "safe"
+
+	// MigrationRiskLocking migrations can block reads or writes on the table while they run
+	MigrationRiskLocking = "locking"
+
+	// MigrationRiskDataLoss migrations can remove or change existing data. They are not executed unless
+	// the table allows destructive changes
+	MigrationRiskDataLoss = "data-loss"
+)
+
+// MigrationStatementRisk is a planned statement that is not safe to execute, and why
+type MigrationStatementRisk struct {
+	Statement string `json:"statement"`
+
+	// +kubebuilder:validation:Enum=safe;locking;data-loss
+	Risk   string `json:"risk"`
+	Reason string `json:"reason,omitempty"`
+}
+
 // MigrationSpec defines the desired state of Migration
 type MigrationSpec struct {
 	DatabaseName   string `json:"databaseName,omitempty"`
@@ -37,6 +80,13 @@ type MigrationSpec struct {
 	TableNamespace string `json:"tableNamespace"`
 	GeneratedDDL   string `json:"generatedDDL,omitempty"`
 	EditedDDL      string `json:"editedDDL,omitempty"`
+
+	// Risk is the most severe risk of the statements in the generated DDL
+	// +kubebuilder:validation:Enum=safe;locking;data-loss
+	Risk string `json:"risk,omitempty"`
+
+	// RiskyStatements are the statements in the generated DDL that are not safe to execute
+	RiskyStatements []MigrationStatementRisk `json:"riskyStatements,omitempty"`
 }
 
 // MigrationStatus defines the observed state of Migration
@@ -50,8 +100,24 @@ type MigrationStatus struct {
 	InvalidatedAt int64 `json:"invalidatedAt,omitempty"`
 
 	ApprovedAt int64 `json:"approvedAt,omitempty"`
+
+	// ApprovedBy is the user that approved the migration
+	ApprovedBy string `json:"approvedBy,omitempty"`
+
 	RejectedAt int64 `json:"rejectedAt,omitempty"`
 	ExecutedAt int64 `json:"executedAt,omitempty"`
+
+	// ExecuteAfter is the unix timestamp that an approved migration must not be executed before,
+	// set when a migration is approved to run at a specific time
+	ExecuteAfter int64 `json:"executeAfter,omitempty"`
+
+	// ScheduledAt is the unix timestamp of the earliest time that an approved migration will be executed,
+	// set when the migration is waiting for its ExecuteAfter time or a maintenance window on the database to open
+	ScheduledAt int64 `json:"scheduledAt,omitempty"`
+
+	// BlockedReason is set when an approved migration can't be executed because it contains destructive
+	// statements that the table doesn't allow
+	BlockedReason string `json:"blockedReason,omitempty"`
 }
 
 // +genclient
@@ -62,6 +128,7 @@ type MigrationStatus struct {
 // +kubebuilder:printcolumn:name="Table",type=string,JSONPath=`.spec.tableName`
 // +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.metadata.namespace`,priority=1
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Risk",type=string,JSONPath=`.spec.risk`
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +k8s:openapi-gen=true
 type Migration struct {
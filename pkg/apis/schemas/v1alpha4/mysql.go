@@ -24,6 +24,13 @@ type MysqlTableColumnAttributes struct {
 	AutoIncrement *bool `json:"autoIncrement,omitempty" yaml:"autoIncrement,omitempty"`
 }
 
+// MysqlTableColumnGenerated is the expression that a generated column is computed from. The column is
+// virtual, and computed when it's read, unless it's stored
+type MysqlTableColumnGenerated struct {
+	Expression string `json:"expression" yaml:"expression"`
+	Stored     bool   `json:"stored,omitempty" yaml:"stored,omitempty"`
+}
+
 type MysqlTableForeignKeyReferences struct {
 	Table   string   `json:"table"`
 	Columns []string `json:"columns"`
@@ -34,6 +41,10 @@ type MysqlTableForeignKey struct {
 	References MysqlTableForeignKeyReferences `json:"references" yaml:"references"`
 	OnDelete   string                         `json:"onDelete,omitempty" yaml:"onDelete,omitempty"`
 	Name       string                         `json:"name,omitempty" yaml:"name,omitempty"`
+	OnUpdate   string                         `json:"onUpdate,omitempty" yaml:"onUpdate,omitempty"`
+	// Match is full, partial or simple. It isn't compared with the existing foreign key, because innodb doesn't
+	// keep it
+	Match string `json:"match,omitempty" yaml:"match,omitempty"`
 }
 
 type MysqlTableIndex struct {
@@ -43,6 +54,33 @@ type MysqlTableIndex struct {
 	Type     string   `json:"type,omitempty" yaml:"type,omitempty"`
 }
 
+// MysqlTableCheck is a named check constraint with the expression that rows must satisfy
+type MysqlTableCheck struct {
+	Name       string `json:"name" yaml:"name"`
+	Expression string `json:"expression" yaml:"expression"`
+}
+
+// MysqlTablePartition is a partition of a partitioned table, with the bounds of the rows that it holds.
+// Bound values are SQL expressions, so strings are quoted
+type MysqlTablePartition struct {
+	Name string `json:"name" yaml:"name"`
+	// LessThan is the upper bound of a range partition, which isn't included, or maxvalue
+	LessThan string `json:"lessThan,omitempty" yaml:"lessThan,omitempty"`
+	// In is the values of the key in a list partition
+	In []string `json:"in,omitempty" yaml:"in,omitempty"`
+}
+
+// MysqlTablePartitioning splits the rows of the table into partitions by the key
+type MysqlTablePartitioning struct {
+	// Strategy is range, list, hash or key, or range columns or list columns
+	Strategy string `json:"strategy" yaml:"strategy"`
+	// Key is the expression, or the columns, that rows are partitioned by
+	Key string `json:"key" yaml:"key"`
+	// Count is the number of partitions of a hash or key partitioned table
+	Count      int                    `json:"count,omitempty" yaml:"count,omitempty"`
+	Partitions []*MysqlTablePartition `json:"partitions,omitempty" yaml:"partitions,omitempty"`
+}
+
 type MysqlTableColumn struct {
 	Name        string                       `json:"name" yaml:"name"`
 	Type        string                       `json:"type" yaml:"type"`
@@ -51,14 +89,44 @@ type MysqlTableColumn struct {
 	Default     *string                      `json:"default,omitempty" yaml:"default,omitempty"`
 	Charset     string                       `json:"charset,omitempty" yaml:"charset,omitempty"`
 	Collation   string                       `json:"collation,omitempty" yaml:"collation,omitempty"`
+	Generated   *MysqlTableColumnGenerated   `json:"generated,omitempty" yaml:"generated,omitempty"`
+	// Comment is the comment on the column. An empty comment removes it
+	Comment *string `json:"comment,omitempty" yaml:"comment,omitempty"`
+
+	// RenamedFrom is the previous name of the column. When the table has a column with this name, and no column
+	// with the new name, the column is renamed instead of being dropped and added
+	RenamedFrom string `json:"renamedFrom,omitempty" yaml:"renamedFrom,omitempty"`
 }
 
 type MysqlTableSchema struct {
 	PrimaryKey     []string                `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
 	ForeignKeys    []*MysqlTableForeignKey `json:"foreignKeys,omitempty" yaml:"foreignKeys,omitempty"`
 	Indexes        []*MysqlTableIndex      `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	Checks         []*MysqlTableCheck      `json:"checks,omitempty" yaml:"checks,omitempty"`
 	Columns        []*MysqlTableColumn     `json:"columns,omitempty" yaml:"columns,omitempty"`
 	IsDeleted      bool                    `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
 	DefaultCharset string                  `json:"defaultCharset,omitempty" yaml:"defaultCharset,omitempty"`
 	Collation      string                  `json:"collation,omitempty" yaml:"collation,omitempty"`
+	Partitioning   *MysqlTablePartitioning `json:"partitioning,omitempty" yaml:"partitioning,omitempty"`
+
+	// Engine, RowFormat and KeyBlockSize are changed on an existing table when they're set and don't match it.
+	// Changing them rebuilds the table, and changing the engine from innodb can lose data. Engine is innodb,
+	// myisam, aria, memory, archive, csv or blackhole
+	Engine       string `json:"engine,omitempty" yaml:"engine,omitempty"`
+	RowFormat    string `json:"rowFormat,omitempty" yaml:"rowFormat,omitempty"`
+	KeyBlockSize int    `json:"keyBlockSize,omitempty" yaml:"keyBlockSize,omitempty"`
+	// AutoIncrementStart is the first value of the auto increment column. The counter of an existing table is
+	// raised to it, and is never lowered
+	AutoIncrementStart int64 `json:"autoIncrementStart,omitempty" yaml:"autoIncrementStart,omitempty"`
+
+	// Comment is the comment on the table. The comment isn't changed when this isn't set, and is removed
+	// when it's empty
+	Comment *string `json:"comment,omitempty" yaml:"comment,omitempty"`
+
+	// OnlineAlter runs the changes to an existing table with algorithm=inplace, lock=none, so that writes to the
+	// table aren't blocked. Changes that mysql can't make in place are made by copying the rows to a new table in
+	// chunks, with triggers to copy the writes made in the meantime, and swapping the tables with a rename. The
+	// copy needs a primary key, and isn't done for tables with triggers or foreign keys. Changes to the primary key
+	// and the foreign keys aren't made online
+	OnlineAlter bool `json:"onlineAlter,omitempty" yaml:"onlineAlter,omitempty"`
 }
@@ -37,13 +37,40 @@ type PostgresqlTableForeignKey struct {
 	References PostgresqlTableForeignKeyReferences `json:"references" yaml:"references"`
 	OnDelete   string                              `json:"onDelete,omitempty" yaml:"onDelete,omitempty"`
 	Name       string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	OnUpdate   string                              `json:"onUpdate,omitempty" yaml:"onUpdate,omitempty"`
+	// Match is full, partial or simple
+	Match             string `json:"match,omitempty" yaml:"match,omitempty"`
+	Deferrable        bool   `json:"deferrable,omitempty" yaml:"deferrable,omitempty"`
+	InitiallyDeferred bool   `json:"initiallyDeferred,omitempty" yaml:"initiallyDeferred,omitempty"`
+	// NotValid adds the foreign key without checking the existing rows, and then validates it separately so
+	// that writes to the table aren't blocked while the rows are checked
+	NotValid bool `json:"notValid,omitempty" yaml:"notValid,omitempty"`
+}
+
+// PostgresqlTableIndexKey is a column or expression in an index, with its operator class and ordering
+type PostgresqlTableIndexKey struct {
+	Column     string `json:"column,omitempty" yaml:"column,omitempty"`
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+	OpClass    string `json:"opClass,omitempty" yaml:"opClass,omitempty"`
+	// Order is asc or desc
+	Order string `json:"order,omitempty" yaml:"order,omitempty"`
+	// Nulls is first or last
+	Nulls string `json:"nulls,omitempty" yaml:"nulls,omitempty"`
 }
 
 type PostgresqlTableIndex struct {
-	Columns  []string `json:"columns" yaml:"columns"`
-	Name     string   `json:"name,omitempty" yaml:"name,omitempty"`
-	IsUnique bool     `json:"isUnique,omitempty" yaml:"isUnique,omitempty"`
-	Type     string   `json:"type,omitempty" yaml:"type,omitempty"`
+	Columns []string `json:"columns,omitempty" yaml:"columns,omitempty"`
+	// Keys are used instead of columns when the index has expressions, operator classes or ordering
+	Keys     []PostgresqlTableIndexKey `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Name     string                    `json:"name,omitempty" yaml:"name,omitempty"`
+	IsUnique bool                      `json:"isUnique,omitempty" yaml:"isUnique,omitempty"`
+	// Type is the index method: btree, hash, gist, gin or brin
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Where is the predicate of a partial index
+	Where   string   `json:"where,omitempty" yaml:"where,omitempty"`
+	Include []string `json:"include,omitempty" yaml:"include,omitempty"`
+	// Concurrently builds the index without locking writes to the table
+	Concurrently bool `json:"concurrently,omitempty" yaml:"concurrently,omitempty"`
 }
 
 type PostgresqlTableColumnConstraints struct {
@@ -54,19 +81,146 @@ type PostgresqlTableColumnAttributes struct {
 	AutoIncrement *bool `json:"autoIncrement,omitempty" yaml:"autoIncrement,omitempty"`
 }
 
+// PostgresqlTableColumnGenerated is the expression that a generated column is computed from. Postgres only
+// supports generated columns that are stored, so they are always stored
+type PostgresqlTableColumnGenerated struct {
+	Expression string `json:"expression" yaml:"expression"`
+}
+
+// PostgresqlSequenceOptions are the options of a sequence. The options that aren't set are left to postgres,
+// and aren't changed on an existing sequence
+type PostgresqlSequenceOptions struct {
+	Start     *int64 `json:"start,omitempty" yaml:"start,omitempty"`
+	Increment *int64 `json:"increment,omitempty" yaml:"increment,omitempty"`
+	MinValue  *int64 `json:"minValue,omitempty" yaml:"minValue,omitempty"`
+	MaxValue  *int64 `json:"maxValue,omitempty" yaml:"maxValue,omitempty"`
+	Cycle     bool   `json:"cycle,omitempty" yaml:"cycle,omitempty"`
+}
+
+// PostgresqlTableColumnIdentity makes the column an identity column, with its values taken from a sequence
+type PostgresqlTableColumnIdentity struct {
+	// Generated is always or by default. Values can only be inserted into a column that is generated by
+	// default, and by default is used when this is empty
+	Generated string                     `json:"generated,omitempty" yaml:"generated,omitempty"`
+	Sequence  *PostgresqlSequenceOptions `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+}
+
+// PostgresqlSequence is a sequence in the schema of the table. A sequence that's owned by a column of the
+// table is dropped with the column
+type PostgresqlSequence struct {
+	Name    string                     `json:"name" yaml:"name"`
+	Options *PostgresqlSequenceOptions `json:"options,omitempty" yaml:"options,omitempty"`
+	OwnedBy string                     `json:"ownedBy,omitempty" yaml:"ownedBy,omitempty"`
+}
+
+// PostgresqlTableGrant is the privileges on the table that are granted to a role. Privileges that the role has
+// and that aren't in the list are revoked
+type PostgresqlTableGrant struct {
+	// Role is the name of the role, or public for all roles
+	Role string `json:"role" yaml:"role"`
+	// Privileges are select, insert, update, delete, truncate, references and trigger, or all of them
+	Privileges []string `json:"privileges" yaml:"privileges"`
+}
+
+// PostgresqlTableRowLevelSecurity limits the rows of the table that roles can access to the rows that its
+// policies allow
+type PostgresqlTableRowLevelSecurity struct {
+	Enable bool `json:"enable,omitempty" yaml:"enable,omitempty"`
+	// Force applies the policies to the owner of the table too
+	Force bool `json:"force,omitempty" yaml:"force,omitempty"`
+}
+
+// PostgresqlTablePolicy is a row level security policy on the table
+type PostgresqlTablePolicy struct {
+	Name string `json:"name" yaml:"name"`
+	// Command is all, select, insert, update or delete, and all is used when this is empty
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+	// Roles are the roles that the policy applies to, and it applies to public when this is empty
+	Roles []string `json:"roles,omitempty" yaml:"roles,omitempty"`
+	// Using is the expression that existing rows must satisfy to be visible
+	Using string `json:"using,omitempty" yaml:"using,omitempty"`
+	// WithCheck is the expression that inserted and updated rows must satisfy
+	WithCheck string `json:"withCheck,omitempty" yaml:"withCheck,omitempty"`
+}
+
+// PostgresqlTablePartition is a partition of a partitioned table, with the bounds of the rows that it holds.
+// Bound values are SQL literals, so strings are quoted
+type PostgresqlTablePartition struct {
+	Name string `json:"name" yaml:"name"`
+	// From and To bound the rows of a range partition, with a value for each column of the key. From is
+	// included and To isn't
+	From []string `json:"from,omitempty" yaml:"from,omitempty"`
+	To   []string `json:"to,omitempty" yaml:"to,omitempty"`
+	// In is the values of the key in a list partition
+	In []string `json:"in,omitempty" yaml:"in,omitempty"`
+	// Modulus and Remainder are the hash values of the rows in a hash partition
+	Modulus   int `json:"modulus,omitempty" yaml:"modulus,omitempty"`
+	Remainder int `json:"remainder,omitempty" yaml:"remainder,omitempty"`
+	// IsDefault partitions hold the rows that aren't in any other partition
+	IsDefault bool `json:"isDefault,omitempty" yaml:"isDefault,omitempty"`
+}
+
+// PostgresqlTablePartitioning splits the rows of the table into partitions by the key. The partitioning of
+// an existing table can't be changed
+type PostgresqlTablePartitioning struct {
+	// Strategy is range, list or hash
+	Strategy string `json:"strategy" yaml:"strategy"`
+	// Key is the columns or expressions that rows are partitioned by, such as "created_at"
+	Key        string                      `json:"key" yaml:"key"`
+	Partitions []*PostgresqlTablePartition `json:"partitions,omitempty" yaml:"partitions,omitempty"`
+	// DropRemovedPartitions drops the partitions that are removed from the spec. They are only detached from
+	// the table when this isn't set, and their rows are kept in a table of the same name
+	DropRemovedPartitions bool `json:"dropRemovedPartitions,omitempty" yaml:"dropRemovedPartitions,omitempty"`
+}
+
+// PostgresqlTableCheck is a named check constraint with the expression that rows must satisfy
+type PostgresqlTableCheck struct {
+	Name       string `json:"name" yaml:"name"`
+	Expression string `json:"expression" yaml:"expression"`
+	// NotValid adds the constraint without checking the existing rows, and then validates it separately so
+	// that writes to the table aren't blocked while the rows are checked
+	NotValid bool `json:"notValid,omitempty" yaml:"notValid,omitempty"`
+}
+
 type PostgresqlTableColumn struct {
 	Name        string                            `json:"name" yaml:"name"`
 	Type        string                            `json:"type" yaml:"type"`
 	Constraints *PostgresqlTableColumnConstraints `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Attributes  *PostgresqlTableColumnAttributes  `json:"attributes,omitempty" yaml:"attributes,omitempty"`
 	Default     *string                           `json:"default,omitempty" yaml:"default,omitempty"`
+	Generated   *PostgresqlTableColumnGenerated   `json:"generated,omitempty" yaml:"generated,omitempty"`
+	Identity    *PostgresqlTableColumnIdentity    `json:"identity,omitempty" yaml:"identity,omitempty"`
+	// Comment is the comment on the column. An empty comment removes it
+	Comment *string `json:"comment,omitempty" yaml:"comment,omitempty"`
+
+	// RenamedFrom is the previous name of the column. When the table has a column with this name, and no column
+	// with the new name, the column is renamed instead of being dropped and added
+	RenamedFrom string `json:"renamedFrom,omitempty" yaml:"renamedFrom,omitempty"`
 }
 
 type PostgresqlTableSchema struct {
-	PrimaryKey  []string                     `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
-	ForeignKeys []*PostgresqlTableForeignKey `json:"foreignKeys,omitempty" yaml:"foreignKeys,omitempty"`
-	Indexes     []*PostgresqlTableIndex      `json:"indexes,omitempty" yaml:"indexes,omitempty"`
-	Columns     []*PostgresqlTableColumn     `json:"columns,omitempty" yaml:"columns,omitempty"`
-	IsDeleted   bool                         `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
-	Triggers    []*PostgresqlTableTrigger    `json:"json:triggers,omitempty" yaml:"triggers,omitempty"`
+	// Schema is the postgres schema that the table is in. The current schema of the connection, usually public,
+	// is used when this is empty. The schema is created if it doesn't exist
+	Schema       string                       `json:"schema,omitempty" yaml:"schema,omitempty"`
+	PrimaryKey   []string                     `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
+	ForeignKeys  []*PostgresqlTableForeignKey `json:"foreignKeys,omitempty" yaml:"foreignKeys,omitempty"`
+	Indexes      []*PostgresqlTableIndex      `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	Checks       []*PostgresqlTableCheck      `json:"checks,omitempty" yaml:"checks,omitempty"`
+	Columns      []*PostgresqlTableColumn     `json:"columns,omitempty" yaml:"columns,omitempty"`
+	Sequences    []*PostgresqlSequence        `json:"sequences,omitempty" yaml:"sequences,omitempty"`
+	Partitioning *PostgresqlTablePartitioning `json:"partitioning,omitempty" yaml:"partitioning,omitempty"`
+	IsDeleted    bool                         `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
+	Triggers     []*PostgresqlTableTrigger    `json:"json:triggers,omitempty" yaml:"triggers,omitempty"`
+
+	// Comment is the comment on the table. The comment isn't changed when this isn't set, and is removed
+	// when it's empty
+	Comment *string `json:"comment,omitempty" yaml:"comment,omitempty"`
+
+	// Grants are the privileges of roles on the table. The privileges of roles that aren't in the list aren't
+	// changed
+	Grants           []*PostgresqlTableGrant          `json:"grants,omitempty" yaml:"grants,omitempty"`
+	RowLevelSecurity *PostgresqlTableRowLevelSecurity `json:"rowLevelSecurity,omitempty" yaml:"rowLevelSecurity,omitempty"`
+	// Policies are managed when the table has policies or row level security in the spec, and then the
+	// policies that aren't in the spec are dropped
+	Policies []*PostgresqlTablePolicy `json:"policies,omitempty" yaml:"policies,omitempty"`
 }
@@ -34,6 +34,10 @@ type RqliteTableForeignKey struct {
 	References RqliteTableForeignKeyReferences `json:"references" yaml:"references"`
 	OnDelete   string                          `json:"onDelete,omitempty" yaml:"onDelete,omitempty"`
 	Name       string                          `json:"name,omitempty" yaml:"name,omitempty"`
+	OnUpdate   string                          `json:"onUpdate,omitempty" yaml:"onUpdate,omitempty"`
+	// Match is full, partial or simple. It isn't compared with the existing foreign key, because sqlite doesn't
+	// keep it
+	Match string `json:"match,omitempty" yaml:"match,omitempty"`
 }
 
 type RqliteTableIndex struct {
@@ -43,18 +47,29 @@ type RqliteTableIndex struct {
 	Type     string   `json:"type,omitempty" yaml:"type,omitempty"`
 }
 
+// RqliteTableCheck is a named check constraint with the expression that rows must satisfy
+type RqliteTableCheck struct {
+	Name       string `json:"name" yaml:"name"`
+	Expression string `json:"expression" yaml:"expression"`
+}
+
 type RqliteTableColumn struct {
 	Name        string                        `json:"name" yaml:"name"`
 	Type        string                        `json:"type" yaml:"type"`
 	Constraints *RqliteTableColumnConstraints `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Attributes  *RqliteTableColumnAttributes  `json:"attributes,omitempty" yaml:"attributes,omitempty"`
 	Default     *string                       `json:"default,omitempty" yaml:"default,omitempty"`
+
+	// RenamedFrom is the previous name of the column. When the table has a column with this name, and no column
+	// with the new name, the column is renamed instead of being dropped and added
+	RenamedFrom string `json:"renamedFrom,omitempty" yaml:"renamedFrom,omitempty"`
 }
 
 type RqliteTableSchema struct {
 	PrimaryKey  []string                 `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
 	ForeignKeys []*RqliteTableForeignKey `json:"foreignKeys,omitempty" yaml:"foreignKeys,omitempty"`
 	Indexes     []*RqliteTableIndex      `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	Checks      []*RqliteTableCheck      `json:"checks,omitempty" yaml:"checks,omitempty"`
 	Columns     []*RqliteTableColumn     `json:"columns,omitempty" yaml:"columns,omitempty"`
 	IsDeleted   bool                     `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
 	Strict      bool                     `json:"strict,omitempty" yaml:"strict,omitempty"`
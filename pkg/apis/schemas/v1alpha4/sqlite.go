@@ -24,6 +24,13 @@ type SqliteTableColumnAttributes struct {
 	AutoIncrement *bool `json:"autoIncrement,omitempty" yaml:"autoIncrement,omitempty"`
 }
 
+// SqliteTableColumnGenerated is the expression that a generated column is computed from. The column is
+// virtual, and computed when it's read, unless it's stored
+type SqliteTableColumnGenerated struct {
+	Expression string `json:"expression" yaml:"expression"`
+	Stored     bool   `json:"stored,omitempty" yaml:"stored,omitempty"`
+}
+
 type SqliteTableForeignKeyReferences struct {
 	Table   string   `json:"table"`
 	Columns []string `json:"columns"`
@@ -34,6 +41,10 @@ type SqliteTableForeignKey struct {
 	References SqliteTableForeignKeyReferences `json:"references" yaml:"references"`
 	OnDelete   string                          `json:"onDelete,omitempty" yaml:"onDelete,omitempty"`
 	Name       string                          `json:"name,omitempty" yaml:"name,omitempty"`
+	OnUpdate   string                          `json:"onUpdate,omitempty" yaml:"onUpdate,omitempty"`
+	// Match is full, partial or simple. It isn't compared with the existing foreign key, because sqlite doesn't
+	// keep it
+	Match string `json:"match,omitempty" yaml:"match,omitempty"`
 }
 
 type SqliteTableIndex struct {
@@ -43,18 +54,30 @@ type SqliteTableIndex struct {
 	Type     string   `json:"type,omitempty" yaml:"type,omitempty"`
 }
 
+// SqliteTableCheck is a named check constraint with the expression that rows must satisfy
+type SqliteTableCheck struct {
+	Name       string `json:"name" yaml:"name"`
+	Expression string `json:"expression" yaml:"expression"`
+}
+
 type SqliteTableColumn struct {
 	Name        string                        `json:"name" yaml:"name"`
 	Type        string                        `json:"type" yaml:"type"`
 	Constraints *SqliteTableColumnConstraints `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Attributes  *SqliteTableColumnAttributes  `json:"attributes,omitempty" yaml:"attributes,omitempty"`
 	Default     *string                       `json:"default,omitempty" yaml:"default,omitempty"`
+	Generated   *SqliteTableColumnGenerated   `json:"generated,omitempty" yaml:"generated,omitempty"`
+
+	// RenamedFrom is the previous name of the column. When the table has a column with this name, and no column
+	// with the new name, the column is renamed instead of being dropped and added
+	RenamedFrom string `json:"renamedFrom,omitempty" yaml:"renamedFrom,omitempty"`
 }
 
 type SqliteTableSchema struct {
 	PrimaryKey  []string                 `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
 	ForeignKeys []*SqliteTableForeignKey `json:"foreignKeys,omitempty" yaml:"foreignKeys,omitempty"`
 	Indexes     []*SqliteTableIndex      `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	Checks      []*SqliteTableCheck      `json:"checks,omitempty" yaml:"checks,omitempty"`
 	Columns     []*SqliteTableColumn     `json:"columns,omitempty" yaml:"columns,omitempty"`
 	IsDeleted   bool                     `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
 	Strict      bool                     `json:"strict,omitempty" yaml:"strict,omitempty"`
@@ -41,6 +41,10 @@ type TableSpec struct {
 	Name     string   `json:"name" yaml:"name"`
 	Requires []string `json:"requires,omitempty" yaml:"requires,omitempty"`
 
+	// RenamedFrom is the previous name of the table in the database. When the database has a table with this
+	// name, and no table with the new name, the table is renamed instead of a new table being created
+	RenamedFrom string `json:"renamedFrom,omitempty" yaml:"renamedFrom,omitempty"`
+
 	Schema   *TableSchema `json:"schema,omitempty" yaml:"schema,omitempty"`
 	SeedData *SeedData    `json:"seedData,omitempty" yaml:"seedData,omitempty"`
 }
@@ -52,6 +56,16 @@ type TableStatus struct {
 	// we cannot use the resourceVersion or generation fields because updating them
 	// would cause the object to be modified again
 	LastPlannedTableSpecSHA string `json:"lastPlannedTableSpecSHA,omitempty" yaml:"lastPlannedTableSpecSHA,omitempty"`
+
+	// ObservedGeneration is the generation of the table that the conditions were last updated for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" yaml:"observedGeneration,omitempty"`
+
+	// CurrentMigration is the name of the migration that was planned for the current spec of the table.
+	// It's empty when the table didn't need any changes
+	CurrentMigration string `json:"currentMigration,omitempty" yaml:"currentMigration,omitempty"`
+
+	// Conditions are the latest observations of the table
+	Conditions []metav1.Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
 }
 
 // +genclient
@@ -61,8 +75,11 @@ type TableStatus struct {
 // +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.metadata.namespace`,priority=1
 // +kubebuilder:printcolumn:name="Table",type=string,JSONPath=`.spec.name`
 // +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.database`
+// +kubebuilder:printcolumn:name="Applied",type=string,JSONPath=`.status.conditions[?(@.type=="Applied")].status`
+// +kubebuilder:printcolumn:name="Pending",type=string,JSONPath=`.status.conditions[?(@.type=="MigrationPending")].status`
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
 type Table struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -53,6 +53,9 @@ type TimescaleDBTableSchema struct {
 	IsDeleted   bool                         `json:"isDeleted,omitempty" yaml:"isDeleted,omitempty"`
 	Triggers    []*PostgresqlTableTrigger    `json:"triggers,omitempty" yaml:"triggers,omitempty"`
 	Hypertable  *TimescaleDBHypertable       `json:"hypertable,omitempty" yaml:"hypertable,omitempty"`
+
+	// Comment is the comment on the table, which is set like the comment on a postgres table
+	Comment *string `json:"comment,omitempty" yaml:"comment,omitempty"`
 }
 
 type TimescaleDBViewSchema struct {
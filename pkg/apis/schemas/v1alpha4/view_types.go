@@ -54,6 +54,16 @@ type ViewStatus struct {
 	// we cannot use the resourceVersion or generation fields because updating them
 	// would cause the object to be modified again
 	LastPlannedViewSpecSHA string `json:"lastPlannedViewSpecSHA,omitempty" yaml:"lastPlannedViewSpecSHA,omitempty"`
+
+	// ObservedGeneration is the generation of the view that the conditions were last updated for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" yaml:"observedGeneration,omitempty"`
+
+	// CurrentMigration is the name of the migration that was planned for the current spec of the view.
+	// It's empty when the view didn't need any changes
+	CurrentMigration string `json:"currentMigration,omitempty" yaml:"currentMigration,omitempty"`
+
+	// Conditions are the latest observations of the view
+	Conditions []metav1.Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
 }
 
 // +genclient
@@ -63,8 +73,11 @@ type ViewStatus struct {
 // +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.metadata.namespace`,priority=1
 // +kubebuilder:printcolumn:name="View",type=string,JSONPath=`.spec.name`
 // +kubebuilder:printcolumn:name="Database",type=string,JSONPath=`.spec.database`
+// +kubebuilder:printcolumn:name="Applied",type=string,JSONPath=`.status.conditions[?(@.type=="Applied")].status`
+// +kubebuilder:printcolumn:name="Pending",type=string,JSONPath=`.status.conditions[?(@.type=="MigrationPending")].status`
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
 type View struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha4
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -349,7 +350,7 @@ func (in *Migration) DeepCopyInto(out *Migration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -406,6 +407,11 @@ func (in *MigrationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MigrationSpec) DeepCopyInto(out *MigrationSpec) {
 	*out = *in
+	if in.RiskyStatements != nil {
+		in, out := &in.RiskyStatements, &out.RiskyStatements
+		*out = make([]MigrationStatementRisk, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationSpec.
@@ -418,6 +424,21 @@ func (in *MigrationSpec) DeepCopy() *MigrationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationStatementRisk) DeepCopyInto(out *MigrationStatementRisk) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatementRisk.
+func (in *MigrationStatementRisk) DeepCopy() *MigrationStatementRisk {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationStatementRisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
 	*out = *in
@@ -433,6 +454,21 @@ func (in *MigrationStatus) DeepCopy() *MigrationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlTableCheck) DeepCopyInto(out *MysqlTableCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableCheck.
+func (in *MysqlTableCheck) DeepCopy() *MysqlTableCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlTableCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MysqlTableColumn) DeepCopyInto(out *MysqlTableColumn) {
 	*out = *in
@@ -451,6 +487,16 @@ func (in *MysqlTableColumn) DeepCopyInto(out *MysqlTableColumn) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Generated != nil {
+		in, out := &in.Generated, &out.Generated
+		*out = new(MysqlTableColumnGenerated)
+		**out = **in
+	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableColumn.
@@ -503,6 +549,21 @@ func (in *MysqlTableColumnConstraints) DeepCopy() *MysqlTableColumnConstraints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlTableColumnGenerated) DeepCopyInto(out *MysqlTableColumnGenerated) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableColumnGenerated.
+func (in *MysqlTableColumnGenerated) DeepCopy() *MysqlTableColumnGenerated {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlTableColumnGenerated)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MysqlTableForeignKey) DeepCopyInto(out *MysqlTableForeignKey) {
 	*out = *in
@@ -564,6 +625,52 @@ func (in *MysqlTableIndex) DeepCopy() *MysqlTableIndex {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlTablePartition) DeepCopyInto(out *MysqlTablePartition) {
+	*out = *in
+	if in.In != nil {
+		in, out := &in.In, &out.In
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTablePartition.
+func (in *MysqlTablePartition) DeepCopy() *MysqlTablePartition {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlTablePartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MysqlTablePartitioning) DeepCopyInto(out *MysqlTablePartitioning) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]*MysqlTablePartition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MysqlTablePartition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTablePartitioning.
+func (in *MysqlTablePartitioning) DeepCopy() *MysqlTablePartitioning {
+	if in == nil {
+		return nil
+	}
+	out := new(MysqlTablePartitioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MysqlTableSchema) DeepCopyInto(out *MysqlTableSchema) {
 	*out = *in
@@ -594,6 +701,17 @@ func (in *MysqlTableSchema) DeepCopyInto(out *MysqlTableSchema) {
 			}
 		}
 	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]*MysqlTableCheck, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MysqlTableCheck)
+				**out = **in
+			}
+		}
+	}
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
 		*out = make([]*MysqlTableColumn, len(*in))
@@ -605,6 +723,16 @@ func (in *MysqlTableSchema) DeepCopyInto(out *MysqlTableSchema) {
 			}
 		}
 	}
+	if in.Partitioning != nil {
+		in, out := &in.Partitioning, &out.Partitioning
+		*out = new(MysqlTablePartitioning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MysqlTableSchema.
@@ -632,6 +760,76 @@ func (in *NotImplementedViewSchema) DeepCopy() *NotImplementedViewSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlSequence) DeepCopyInto(out *PostgresqlSequence) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = new(PostgresqlSequenceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlSequence.
+func (in *PostgresqlSequence) DeepCopy() *PostgresqlSequence {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlSequence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlSequenceOptions) DeepCopyInto(out *PostgresqlSequenceOptions) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Increment != nil {
+		in, out := &in.Increment, &out.Increment
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinValue != nil {
+		in, out := &in.MinValue, &out.MinValue
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxValue != nil {
+		in, out := &in.MaxValue, &out.MaxValue
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlSequenceOptions.
+func (in *PostgresqlSequenceOptions) DeepCopy() *PostgresqlSequenceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlSequenceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableCheck) DeepCopyInto(out *PostgresqlTableCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableCheck.
+func (in *PostgresqlTableCheck) DeepCopy() *PostgresqlTableCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresqlTableColumn) DeepCopyInto(out *PostgresqlTableColumn) {
 	*out = *in
@@ -650,6 +848,21 @@ func (in *PostgresqlTableColumn) DeepCopyInto(out *PostgresqlTableColumn) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Generated != nil {
+		in, out := &in.Generated, &out.Generated
+		*out = new(PostgresqlTableColumnGenerated)
+		**out = **in
+	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(PostgresqlTableColumnIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableColumn.
@@ -702,6 +915,41 @@ func (in *PostgresqlTableColumnConstraints) DeepCopy() *PostgresqlTableColumnCon
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableColumnGenerated) DeepCopyInto(out *PostgresqlTableColumnGenerated) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableColumnGenerated.
+func (in *PostgresqlTableColumnGenerated) DeepCopy() *PostgresqlTableColumnGenerated {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableColumnGenerated)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableColumnIdentity) DeepCopyInto(out *PostgresqlTableColumnIdentity) {
+	*out = *in
+	if in.Sequence != nil {
+		in, out := &in.Sequence, &out.Sequence
+		*out = new(PostgresqlSequenceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableColumnIdentity.
+func (in *PostgresqlTableColumnIdentity) DeepCopy() *PostgresqlTableColumnIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableColumnIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresqlTableForeignKey) DeepCopyInto(out *PostgresqlTableForeignKey) {
 	*out = *in
@@ -743,6 +991,26 @@ func (in *PostgresqlTableForeignKeyReferences) DeepCopy() *PostgresqlTableForeig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableGrant) DeepCopyInto(out *PostgresqlTableGrant) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableGrant.
+func (in *PostgresqlTableGrant) DeepCopy() *PostgresqlTableGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresqlTableIndex) DeepCopyInto(out *PostgresqlTableIndex) {
 	*out = *in
@@ -751,6 +1019,16 @@ func (in *PostgresqlTableIndex) DeepCopyInto(out *PostgresqlTableIndex) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]PostgresqlTableIndexKey, len(*in))
+		copy(*out, *in)
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableIndex.
@@ -763,6 +1041,112 @@ func (in *PostgresqlTableIndex) DeepCopy() *PostgresqlTableIndex {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableIndexKey) DeepCopyInto(out *PostgresqlTableIndexKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableIndexKey.
+func (in *PostgresqlTableIndexKey) DeepCopy() *PostgresqlTableIndexKey {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableIndexKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTablePartition) DeepCopyInto(out *PostgresqlTablePartition) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.In != nil {
+		in, out := &in.In, &out.In
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTablePartition.
+func (in *PostgresqlTablePartition) DeepCopy() *PostgresqlTablePartition {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTablePartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTablePartitioning) DeepCopyInto(out *PostgresqlTablePartitioning) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]*PostgresqlTablePartition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTablePartition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTablePartitioning.
+func (in *PostgresqlTablePartitioning) DeepCopy() *PostgresqlTablePartitioning {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTablePartitioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTablePolicy) DeepCopyInto(out *PostgresqlTablePolicy) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTablePolicy.
+func (in *PostgresqlTablePolicy) DeepCopy() *PostgresqlTablePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTablePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresqlTableRowLevelSecurity) DeepCopyInto(out *PostgresqlTableRowLevelSecurity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableRowLevelSecurity.
+func (in *PostgresqlTableRowLevelSecurity) DeepCopy() *PostgresqlTableRowLevelSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresqlTableRowLevelSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresqlTableSchema) DeepCopyInto(out *PostgresqlTableSchema) {
 	*out = *in
@@ -793,6 +1177,17 @@ func (in *PostgresqlTableSchema) DeepCopyInto(out *PostgresqlTableSchema) {
 			}
 		}
 	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]*PostgresqlTableCheck, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTableCheck)
+				**out = **in
+			}
+		}
+	}
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
 		*out = make([]*PostgresqlTableColumn, len(*in))
@@ -804,6 +1199,22 @@ func (in *PostgresqlTableSchema) DeepCopyInto(out *PostgresqlTableSchema) {
 			}
 		}
 	}
+	if in.Sequences != nil {
+		in, out := &in.Sequences, &out.Sequences
+		*out = make([]*PostgresqlSequence, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlSequence)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Partitioning != nil {
+		in, out := &in.Partitioning, &out.Partitioning
+		*out = new(PostgresqlTablePartitioning)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]*PostgresqlTableTrigger, len(*in))
@@ -815,6 +1226,38 @@ func (in *PostgresqlTableSchema) DeepCopyInto(out *PostgresqlTableSchema) {
 			}
 		}
 	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]*PostgresqlTableGrant, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTableGrant)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RowLevelSecurity != nil {
+		in, out := &in.RowLevelSecurity, &out.RowLevelSecurity
+		*out = new(PostgresqlTableRowLevelSecurity)
+		**out = **in
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]*PostgresqlTablePolicy, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PostgresqlTablePolicy)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresqlTableSchema.
@@ -872,6 +1315,21 @@ func (in *PostgresqlTableTrigger) DeepCopy() *PostgresqlTableTrigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RqliteTableCheck) DeepCopyInto(out *RqliteTableCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RqliteTableCheck.
+func (in *RqliteTableCheck) DeepCopy() *RqliteTableCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RqliteTableCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RqliteTableColumn) DeepCopyInto(out *RqliteTableColumn) {
 	*out = *in
@@ -1033,6 +1491,17 @@ func (in *RqliteTableSchema) DeepCopyInto(out *RqliteTableSchema) {
 			}
 		}
 	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]*RqliteTableCheck, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RqliteTableCheck)
+				**out = **in
+			}
+		}
+	}
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
 		*out = make([]*RqliteTableColumn, len(*in))
@@ -1125,6 +1594,21 @@ func (in *SeedDataValue) DeepCopy() *SeedDataValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SqliteTableCheck) DeepCopyInto(out *SqliteTableCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SqliteTableCheck.
+func (in *SqliteTableCheck) DeepCopy() *SqliteTableCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(SqliteTableCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SqliteTableColumn) DeepCopyInto(out *SqliteTableColumn) {
 	*out = *in
@@ -1143,6 +1627,11 @@ func (in *SqliteTableColumn) DeepCopyInto(out *SqliteTableColumn) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Generated != nil {
+		in, out := &in.Generated, &out.Generated
+		*out = new(SqliteTableColumnGenerated)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SqliteTableColumn.
@@ -1195,6 +1684,21 @@ func (in *SqliteTableColumnConstraints) DeepCopy() *SqliteTableColumnConstraints
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SqliteTableColumnGenerated) DeepCopyInto(out *SqliteTableColumnGenerated) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SqliteTableColumnGenerated.
+func (in *SqliteTableColumnGenerated) DeepCopy() *SqliteTableColumnGenerated {
+	if in == nil {
+		return nil
+	}
+	out := new(SqliteTableColumnGenerated)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SqliteTableForeignKey) DeepCopyInto(out *SqliteTableForeignKey) {
 	*out = *in
@@ -1286,6 +1790,17 @@ func (in *SqliteTableSchema) DeepCopyInto(out *SqliteTableSchema) {
 			}
 		}
 	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]*SqliteTableCheck, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SqliteTableCheck)
+				**out = **in
+			}
+		}
+	}
 	if in.Columns != nil {
 		in, out := &in.Columns, &out.Columns
 		*out = make([]*SqliteTableColumn, len(*in))
@@ -1315,7 +1830,7 @@ func (in *Table) DeepCopyInto(out *Table) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Table.
@@ -1451,6 +1966,13 @@ func (in *TableSpec) DeepCopy() *TableSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TableStatus) DeepCopyInto(out *TableStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableStatus.
@@ -1650,6 +2172,11 @@ func (in *TimescaleDBTableSchema) DeepCopyInto(out *TimescaleDBTableSchema) {
 		*out = new(TimescaleDBHypertable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimescaleDBTableSchema.
@@ -1693,7 +2220,7 @@ func (in *View) DeepCopyInto(out *View) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new View.
@@ -1824,6 +2351,13 @@ func (in *ViewSpec) DeepCopy() *ViewSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ViewStatus) DeepCopyInto(out *ViewStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ViewStatus.
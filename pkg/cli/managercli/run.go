@@ -2,6 +2,7 @@ package managercli
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/schemahero/schemahero/pkg/apis"
@@ -51,6 +52,8 @@ func RunCmd() *cobra.Command {
 			// Create a new Cmd to provide shared dependencies and start components
 			options := manager.Options{
 				MetricsBindAddress: v.GetString("metrics-addr"),
+				Port:               v.GetInt("webhook-port"),
+				CertDir:            v.GetString("webhook-cert-dir"),
 			}
 
 			if v.GetString("namespace") != "" {
@@ -100,9 +103,11 @@ func RunCmd() *cobra.Command {
 				}
 			}
 
-			if err := webhook.AddToManager(mgr); err != nil {
-				logger.Error(err)
-				os.Exit(1)
+			if v.GetBool("enable-webhooks") {
+				if err := webhook.AddToManager(mgr); err != nil {
+					logger.Error(err)
+					os.Exit(1)
+				}
 			}
 
 			// Start the Cmd
@@ -116,6 +121,9 @@ func RunCmd() *cobra.Command {
 	}
 
 	cmd.Flags().String("metrics-addr", ":8088", "The address the metric endpoint binds to.")
+	cmd.Flags().Bool("enable-webhooks", false, "when set, the validating webhooks for tables, views and databases will be served and registered")
+	cmd.Flags().Int("webhook-port", 9876, "the port the webhook server binds to")
+	cmd.Flags().String("webhook-cert-dir", filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"), "the directory the webhook server certificate is written to")
 
 	cmd.Flags().Bool("enable-database-controller", false, "when set, the database controller will be active")
 	cmd.Flags().StringSlice("database-name", []string{}, "when present (and not set to *), the controller will reconcile tables and migrations for the specified database")
@@ -5,9 +5,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/pkg/errors"
 	"github.com/schemahero/schemahero/pkg/database"
+	"github.com/schemahero/schemahero/pkg/database/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -65,32 +67,36 @@ func ApplyCmd() *cobra.Command {
 				Keyspace:  v.GetString("keyspace"),
 			}
 
+			if v.GetBool("record-ledger") {
+				migrationID := v.GetString("migration-id")
+				if migrationID == "" {
+					migrationID = filepath.Base(v.GetString("ddl"))
+				}
+
+				db.LedgerEntry = &types.LedgerEntry{
+					MigrationID: migrationID,
+					TableName:   v.GetString("table-name"),
+					SpecSHA:     v.GetString("spec-sha"),
+				}
+			}
+
+			// the sql files in a ddl directory are applied, in the lexical order of their paths, as one migration
 			commands := []string{}
 			if fi.Mode().IsDir() {
-				err := filepath.Walk(v.GetString("ddl"), func(path string, info os.FileInfo, err error) error {
-					if err != nil {
-						return err
-					}
-					if info.IsDir() {
-						return nil
-					}
+				ddlFiles, err := listDDLFiles(v.GetString("ddl"))
+				if err != nil {
+					return errors.Wrap(err, "failed to list ddl directory")
+				}
 
-					ddl, err := ioutil.ReadFile(filepath.Clean(path))
+				for _, ddlFile := range ddlFiles {
+					ddl, err := ioutil.ReadFile(ddlFile)
 					if err != nil {
 						return errors.Wrap(err, "failed to read file in directory")
 					}
 
 					statements := db.GetStatementsFromDDL(string(ddl))
 					commands = append(commands, statements...)
-
-					return nil
-				})
-
-				if err != nil {
-					return errors.Wrap(err, "failed to walk ddl directory")
 				}
-
-				return nil
 			} else {
 				ddl, err := ioutil.ReadFile(v.GetString("ddl"))
 				if err != nil {
@@ -118,7 +124,34 @@ func ApplyCmd() *cobra.Command {
 	cmd.Flags().StringSlice("host", []string{}, "hostname to use when connecting")
 	cmd.Flags().String("keyspace", "", "the keyspace to use for databases that support keyspaces")
 
-	cmd.Flags().String("ddl", "", "filename or directory name containing the rendered DDL commands to execute")
+	cmd.Flags().String("ddl", "", "filename or directory name containing the rendered DDL commands to execute. The .sql files in a directory are executed, in lexical order")
+
+	cmd.Flags().Bool("record-ledger", false, "when set, will record the applied commands in the schemahero_migrations table in the database")
+	cmd.Flags().String("migration-id", "", "the migration id to record in the ledger, defaults to the name of the ddl file or directory")
+	cmd.Flags().String("table-name", "", "the name of the table or view to record in the ledger")
+	cmd.Flags().String("spec-sha", "", "the sha of the spec that the ddl was planned from, to record in the ledger")
 
 	return cmd
 }
+
+// listDDLFiles returns the paths of the .sql files in the directory and its subdirectories, in lexical order
+func listDDLFiles(dir string) ([]string, error) {
+	ddlFiles := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".sql" {
+			return nil
+		}
+
+		ddlFiles = append(ddlFiles, filepath.Clean(path))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(ddlFiles)
+	return ddlFiles, nil
+}
@@ -0,0 +1,26 @@
+package schemaherokubectlcli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listDDLFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "b"), 0755))
+	for _, name := range []string{"c.sql", "a.sql", "README.md", filepath.Join("b", "d.sql"), filepath.Join("b", "notes.txt")} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("select 1;"), 0644))
+	}
+
+	ddlFiles, err := listDDLFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.sql"),
+		filepath.Join(dir, "b", "d.sql"),
+		filepath.Join(dir, "c.sql"),
+	}, ddlFiles)
+}
@@ -32,6 +32,15 @@ func ApproveMigrationCmd() *cobra.Command {
 			ctx := context.Background()
 			migrationName := args[0]
 
+			var executeAfter time.Time
+			if v.GetString("at") != "" {
+				t, err := parseApprovalTime(v.GetString("at"))
+				if err != nil {
+					return err
+				}
+				executeAfter = t
+			}
+
 			cfg, err := config.GetRESTConfig()
 			if err != nil {
 				return err
@@ -76,12 +85,29 @@ func ApproveMigrationCmd() *cobra.Command {
 					return err
 				}
 
+				approvedBy, err := config.GetCurrentUser()
+				if err != nil {
+					return err
+				}
+
 				migration.Status.ApprovedAt = time.Now().Unix()
+				migration.Status.ApprovedBy = approvedBy
 				migration.Status.Phase = v1alpha4.Approved
+				migration.Status.ExecuteAfter = 0
+				migration.Status.ScheduledAt = 0
+				if !executeAfter.IsZero() {
+					migration.Status.ExecuteAfter = executeAfter.Unix()
+					migration.Status.ScheduledAt = executeAfter.Unix()
+				}
 				if _, err := schemasClient.Migrations(namespaceName).Update(ctx, migration, metav1.UpdateOptions{}); err != nil {
 					return err
 				}
 
+				if !executeAfter.IsZero() {
+					fmt.Printf("Migration %s approved to execute at %s\n", migrationName, executeAfter.Format(time.RFC3339))
+					return nil
+				}
+
 				fmt.Printf("Migration %s approved\n", migrationName)
 				return nil
 			}
@@ -92,6 +118,27 @@ func ApproveMigrationCmd() *cobra.Command {
 	}
 
 	cmd.Flags().Bool("all-namespaces", false, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().String("at", "", "If present, the migration will not be executed before this time (RFC3339, for example 2026-11-01T02:00Z)")
 
 	return cmd
 }
+
+// parseApprovalTime parses a time passed to --at. Seconds and the timezone offset are optional,
+// times without an offset are parsed as UTC
+func parseApprovalTime(value string) (time.Time, error) {
+	layouts := []string{
+		time.RFC3339,
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+	}
+
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.Errorf("unable to parse time %q, expected a format like 2006-01-02T15:04Z", value)
+}
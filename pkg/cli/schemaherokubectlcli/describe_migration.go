@@ -86,6 +86,22 @@ func DescribeMigrationCmd() *cobra.Command {
 					time.Unix(foundMigration.Status.PlannedAt, 0).Format(time.RFC3339),
 					foundMigration.Spec.GeneratedDDL)
 
+				if foundMigration.Spec.Risk != "" {
+					fmt.Printf("\nRisk: %s\n", foundMigration.Spec.Risk)
+					for _, risky := range foundMigration.Spec.RiskyStatements {
+						fmt.Printf("  %s: %s\n    %s\n", risky.Risk, risky.Reason, risky.Statement)
+					}
+				}
+
+				if foundMigration.Status.BlockedReason != "" && foundMigration.Status.ExecutedAt == 0 {
+					fmt.Printf("\nBlocked: %s\n", foundMigration.Status.BlockedReason)
+				}
+
+				if foundMigration.Status.ScheduledAt > 0 && foundMigration.Status.ExecutedAt == 0 {
+					fmt.Printf("\nScheduled to execute at %s, when the next maintenance window opens\n",
+						time.Unix(foundMigration.Status.ScheduledAt, 0).Format(time.RFC3339))
+				}
+
 				fmt.Println("")
 				fmt.Println("To apply this migration:")
 				fmt.Printf(`  %s approve migration %s`, baseCommand, foundMigration.Name)
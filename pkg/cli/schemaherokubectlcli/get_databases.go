@@ -11,6 +11,7 @@ import (
 	"github.com/schemahero/schemahero/pkg/config"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -64,10 +65,10 @@ func GetDatabasesCmd() *cobra.Command {
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "NAME\tNAMESPACE\tPENDING")
+			fmt.Fprintln(w, "NAME\tNAMESPACE\tSTATUS\tPENDING")
 
 			for _, database := range matchingDatabases {
-				fmt.Fprintln(w, fmt.Sprintf("%s\t%s\t%s", database.Name, database.Namespace, "0"))
+				fmt.Fprintln(w, fmt.Sprintf("%s\t%s\t%s\t%s", database.Name, database.Namespace, databaseConnectionStatus(database), "0"))
 			}
 
 			w.Flush()
@@ -78,3 +79,17 @@ func GetDatabasesCmd() *cobra.Command {
 
 	return cmd
 }
+
+// databaseConnectionStatus describes the result of the last connection check that the manager made to the database
+func databaseConnectionStatus(database databasesv1alpha4.Database) string {
+	condition := meta.FindStatusCondition(database.Status.Conditions, databasesv1alpha4.DatabaseConditionConnected)
+	if condition == nil {
+		return "Unknown"
+	}
+
+	if condition.Status == metav1.ConditionTrue {
+		return "Connected"
+	}
+
+	return "Unreachable"
+}
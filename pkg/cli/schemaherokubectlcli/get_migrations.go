@@ -10,7 +10,6 @@ import (
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	schemasclientv1alpha4 "github.com/schemahero/schemahero/pkg/client/schemaheroclientset/typed/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/config"
-	"github.com/schemahero/schemahero/pkg/controller/migration"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -79,12 +78,7 @@ func GetMigrationsCmd() *cobra.Command {
 						continue
 					}
 
-					table, err := schemasClient.Tables(m.Spec.TableNamespace).Get(ctx, m.Spec.TableName, metav1.GetOptions{})
-					if err != nil {
-						return err
-					}
-
-					if table.Spec.Database == databaseNameFilter {
+					if m.Spec.DatabaseName == databaseNameFilter {
 						matchingMigrations = append(matchingMigrations, m)
 					}
 				}
@@ -97,11 +91,6 @@ func GetMigrationsCmd() *cobra.Command {
 
 			rows := [][]string{}
 			for _, m := range matchingMigrations {
-				table, err := migration.TableFromMigration(ctx, &m)
-				if err != nil {
-					return err
-				}
-
 				isIncluded := true
 				// if m.Status.ExecutedAt > 0 {
 				// 	continue
@@ -114,12 +103,14 @@ func GetMigrationsCmd() *cobra.Command {
 				if isIncluded {
 					rows = append(rows, []string{
 						m.Name,
-						table.Spec.Database,
-						table.Name,
+						m.Spec.DatabaseName,
+						migrationTableName(m),
+						m.Spec.Risk,
 						timestampToAge(m.Status.PlannedAt),
 						timestampToAge(m.Status.ExecutedAt),
 						timestampToAge(m.Status.ApprovedAt),
 						timestampToAge(m.Status.RejectedAt),
+						migrationSchedule(m),
 					})
 				}
 			}
@@ -130,10 +121,10 @@ func GetMigrationsCmd() *cobra.Command {
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "ID\tDATABASE\tTABLE\tPLANNED\tEXECUTED\tAPPROVED\tREJECTED")
+			fmt.Fprintln(w, "ID\tDATABASE\tTABLE\tRISK\tPLANNED\tEXECUTED\tAPPROVED\tREJECTED\tSCHEDULED")
 
 			for _, row := range rows {
-				fmt.Fprintln(w, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s", row[0], row[1], row[2], row[3], row[4], row[5], row[6]))
+				fmt.Fprintln(w, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s", row[0], row[1], row[2], row[3], row[4], row[5], row[6], row[7], row[8]))
 			}
 			w.Flush()
 
@@ -149,6 +140,32 @@ func GetMigrationsCmd() *cobra.Command {
 	return cmd
 }
 
+// migrationTableName returns the table that the migration was planned for, or - when it was planned for a database
+func migrationTableName(m schemasv1alpha4.Migration) string {
+	if m.Spec.TableName == "" {
+		return "-"
+	}
+
+	return m.Spec.TableName
+}
+
+// migrationSchedule returns the time that an approved migration is scheduled to execute at
+func migrationSchedule(m schemasv1alpha4.Migration) string {
+	if m.Status.ExecutedAt > 0 || m.Status.RejectedAt > 0 {
+		return ""
+	}
+
+	scheduledAt := m.Status.ScheduledAt
+	if scheduledAt == 0 {
+		scheduledAt = m.Status.ExecuteAfter
+	}
+	if scheduledAt == 0 {
+		return ""
+	}
+
+	return time.Unix(scheduledAt, 0).UTC().Format(time.RFC3339)
+}
+
 func timestampToAge(t int64) string {
 	if t == 0 {
 		return ""
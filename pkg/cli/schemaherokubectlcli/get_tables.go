@@ -11,6 +11,7 @@ import (
 	"github.com/schemahero/schemahero/pkg/config"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -75,7 +76,7 @@ func GetTablesCmd() *cobra.Command {
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "NAME\tDATABASE\tPENDING")
+			fmt.Fprintln(w, "NAME\tDATABASE\tSTATUS\tMIGRATION\tPENDING")
 
 			namespaceNames := map[string]struct{}{}
 			for _, table := range matchingTables {
@@ -113,7 +114,12 @@ func GetTablesCmd() *cobra.Command {
 					status = fmt.Sprintf("%d", pendingMigrations)
 				}
 
-				fmt.Fprintln(w, fmt.Sprintf("%s\t%s\t%s", table.Name, table.Spec.Database, status))
+				currentMigration := table.Status.CurrentMigration
+				if currentMigration == "" {
+					currentMigration = "-"
+				}
+
+				fmt.Fprintln(w, fmt.Sprintf("%s\t%s\t%s\t%s\t%s", table.Name, table.Spec.Database, conditionsSummary(table.Status.Conditions, table.Generation, table.Status.ObservedGeneration), currentMigration, status))
 			}
 			w.Flush()
 
@@ -125,3 +131,35 @@ func GetTablesCmd() *cobra.Command {
 
 	return cmd
 }
+
+// conditionsSummary describes the state of a table or view from the conditions on its status
+func conditionsSummary(conditions []metav1.Condition, generation int64, observedGeneration int64) string {
+	if len(conditions) == 0 {
+		return "Unknown"
+	}
+
+	if observedGeneration < generation {
+		return "Reconciling"
+	}
+
+	if condition := meta.FindStatusCondition(conditions, schemasv1alpha4.ConditionError); condition != nil && condition.Status == metav1.ConditionTrue {
+		return condition.Reason
+	}
+
+	if condition := meta.FindStatusCondition(conditions, schemasv1alpha4.ConditionDatabaseFound); condition != nil && condition.Status == metav1.ConditionFalse {
+		return condition.Reason
+	}
+
+	if condition := meta.FindStatusCondition(conditions, schemasv1alpha4.ConditionMigrationPending); condition != nil && condition.Status == metav1.ConditionTrue {
+		return condition.Reason
+	}
+
+	if condition := meta.FindStatusCondition(conditions, schemasv1alpha4.ConditionApplied); condition != nil {
+		if condition.Status == metav1.ConditionTrue {
+			return "Applied"
+		}
+		return condition.Reason
+	}
+
+	return "Unknown"
+}
@@ -0,0 +1,26 @@
+package schemaherokubectlcli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func HistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "history",
+		Short:         "",
+		Long:          `...`,
+		Args:          cobra.MinimumNArgs(1),
+		SilenceErrors: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	cmd.AddCommand(HistoryTableCmd())
+
+	return cmd
+}
@@ -0,0 +1,103 @@
+package schemaherokubectlcli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	schemasclientv1alpha4 "github.com/schemahero/schemahero/pkg/client/schemaheroclientset/typed/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/config"
+	"github.com/schemahero/schemahero/pkg/controller/migration"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func HistoryTableCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "table",
+		Short:         "",
+		Long:          `...`,
+		Args:          cobra.MinimumNArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+			ctx := context.Background()
+			tableName := args[0]
+
+			namespaceName := "default"
+			if v.GetString("namespace") != "" {
+				namespaceName = v.GetString("namespace")
+			}
+
+			cfg, err := config.GetRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			schemasClient, err := schemasclientv1alpha4.NewForConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			table, err := schemasClient.Tables(namespaceName).Get(ctx, tableName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			migrations, err := schemasClient.Migrations(namespaceName).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			history := migration.History(migrations.Items, "Table", table)
+			if len(history) == 0 {
+				fmt.Printf("No migrations have been executed for table %s.\n", table.Name)
+				return nil
+			}
+
+			for _, m := range history {
+				printMigrationHistory(m)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func printMigrationHistory(m schemasv1alpha4.Migration) {
+	fmt.Printf("\nMigration Name: %s\n", m.Name)
+
+	if specSHA := m.Annotations[schemasv1alpha4.MigrationSpecSHAAnnotation]; specSHA != "" {
+		fmt.Printf("Spec SHA:       %s\n", specSHA)
+	}
+	if generation := m.Labels[schemasv1alpha4.MigrationGenerationLabel]; generation != "" {
+		fmt.Printf("Generation:     %s\n", generation)
+	}
+
+	fmt.Printf("Planned At:     %s\n", timestampToRFC3339(m.Status.PlannedAt))
+	if m.Status.ApprovedBy != "" {
+		fmt.Printf("Approved At:    %s (by %s)\n", timestampToRFC3339(m.Status.ApprovedAt), m.Status.ApprovedBy)
+	} else {
+		fmt.Printf("Approved At:    %s\n", timestampToRFC3339(m.Status.ApprovedAt))
+	}
+	fmt.Printf("Executed At:    %s\n", timestampToRFC3339(m.Status.ExecutedAt))
+
+	fmt.Printf("DDL:\n  %s\n", strings.ReplaceAll(m.Spec.GeneratedDDL, "\n", "\n  "))
+}
+
+func timestampToRFC3339(t int64) string {
+	if t == 0 {
+		return ""
+	}
+
+	return time.Unix(t, 0).UTC().Format(time.RFC3339)
+}
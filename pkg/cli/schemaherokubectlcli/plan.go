@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/schemahero/schemahero/pkg/database"
@@ -133,6 +134,14 @@ func PlanCmd() *cobra.Command {
 						return fmt.Errorf("plan sync from file %q: %w", spec.SourceFilename, err)
 					}
 
+					if v.GetBool("detect-drift") {
+						if err := reportDrift(&db, spec.Spec, v.GetString("spec-type"), statements); err != nil {
+							return fmt.Errorf("detect drift from file %q: %w", spec.SourceFilename, err)
+						}
+					}
+
+					reportRisks(statements)
+
 					if f != nil {
 						for _, statement := range statements {
 							if _, err := f.WriteString(fmt.Sprintf("%s;\n", statement)); err != nil {
@@ -153,6 +162,18 @@ func PlanCmd() *cobra.Command {
 					return fmt.Errorf("plan sync from file %q: %w", v.GetString("spec-file"), err)
 				}
 
+				if v.GetBool("detect-drift") {
+					specContents, err := ioutil.ReadFile(filepath.Clean(v.GetString("spec-file")))
+					if err != nil {
+						return errors.Wrap(err, "failed to read file")
+					}
+					if err := reportDrift(&db, specContents, v.GetString("spec-type"), statements); err != nil {
+						return fmt.Errorf("detect drift from file %q: %w", v.GetString("spec-file"), err)
+					}
+				}
+
+				reportRisks(statements)
+
 				if f != nil {
 					for _, statement := range statements {
 						if _, err := f.WriteString(fmt.Sprintf("%s;\n", statement)); err != nil {
@@ -185,5 +206,36 @@ func PlanCmd() *cobra.Command {
 	cmd.Flags().Bool("overwrite", true, "when set, will overwrite the out file, if it already exists")
 
 	cmd.Flags().Bool("seed-data", false, "when set, will deploy seed data")
+	cmd.Flags().Bool("detect-drift", false, "when set, will report specs that were already applied according to the migration ledger, but still have changes planned")
 	return cmd
 }
+
+// reportDrift writes a warning to stderr when the migration ledger shows that the spec was already applied,
+// but there are still statements planned for it. The warning is kept out of the planned DDL so that the
+// output can still be applied
+func reportDrift(db *database.Database, specContents []byte, specType string, statements []string) error {
+	entry, err := db.DriftFromLedger(specContents, specType, statements)
+	if err != nil {
+		return errors.Wrap(err, "failed to check migration ledger")
+	}
+	if entry == nil {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "drift detected: %s was migrated to this spec by %q at %s, but %d statement(s) are planned\n",
+		entry.TableName, entry.MigrationID, entry.AppliedAt.UTC().Format(time.RFC3339), len(statements))
+
+	return nil
+}
+
+// reportRisks writes a warning to stderr for each planned statement that can lose data or lock the table.
+// Like drift, the warnings are kept out of the planned DDL so that the output can still be applied
+func reportRisks(statements []string) {
+	for _, classified := range types.ClassifyStatements(statements) {
+		if classified.Risk == types.StatementRiskSafe {
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "%s: %s: %s\n", classified.Risk, classified.Reason, classified.Statement)
+	}
+}
@@ -77,6 +77,10 @@ func RecalculateMigrationCmd() *cobra.Command {
 					return err
 				}
 
+				if migration.Spec.TableName == "" {
+					return errors.Errorf("migration %q was planned for database %s, not a table", migrationName, migration.Spec.DatabaseName)
+				}
+
 				recalculatedAt := time.Now().Format(time.RFC3339)
 
 				patch := map[string]interface{}{
@@ -35,6 +35,7 @@ func RootCmd() *cobra.Command {
 	cmd.AddCommand(DescribeCmd())
 	cmd.AddCommand(ApproveCmd())
 	cmd.AddCommand(RecalculateCmd())
+	cmd.AddCommand(HistoryCmd())
 	cmd.AddCommand(GenerateCmd())
 	cmd.AddCommand(FixturesCmd())
 
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/pkg/errors"
 	flag "github.com/spf13/pflag"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/rest"
@@ -21,3 +22,27 @@ func AddFlags(flags *flag.FlagSet) {
 func GetRESTConfig() (*rest.Config, error) {
 	return kubernetesConfigFlags.ToRESTConfig()
 }
+
+// GetCurrentUser returns the name of the user in the current kubeconfig context
+func GetCurrentUser() (string, error) {
+	if kubernetesConfigFlags.AuthInfoName != nil && *kubernetesConfigFlags.AuthInfoName != "" {
+		return *kubernetesConfigFlags.AuthInfoName, nil
+	}
+
+	rawConfig, err := kubernetesConfigFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load kubeconfig")
+	}
+
+	contextName := rawConfig.CurrentContext
+	if kubernetesConfigFlags.Context != nil && *kubernetesConfigFlags.Context != "" {
+		contextName = *kubernetesConfigFlags.Context
+	}
+
+	kubeContext, ok := rawConfig.Contexts[contextName]
+	if !ok {
+		return "", nil
+	}
+
+	return kubeContext.AuthInfo, nil
+}
@@ -31,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -38,10 +39,19 @@ import (
 
 var _ reconcile.Reconciler = &ReconcileDatabase{}
 
+// Reasons for the events that are recorded on databases
+const (
+	eventReasonManagerDeployed  = "ManagerDeployed"
+	eventReasonManagerFailed    = "ManagerFailed"
+	eventReasonConnected        = "Connected"
+	eventReasonConnectionFailed = "ConnectionFailed"
+)
+
 // ReconcileDatabase reconciles a Database object
 type ReconcileDatabase struct {
 	client.Client
 	scheme       *runtime.Scheme
+	recorder     record.EventRecorder
 	managerImage string
 	managerTag   string
 	debugLogs    bool
@@ -54,12 +64,23 @@ type ReconcileDatabase struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=databases.schemahero.io,resources=databases,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=databases.schemahero.io,resources=databases/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 func (r *ReconcileDatabase) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	databaseInstance, err := r.getInstance(request)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	result, err := r.reconcileManager(ctx, databaseInstance)
+	if err != nil {
+		r.recorder.Event(databaseInstance, corev1.EventTypeWarning, eventReasonManagerFailed, err.Error())
+	}
+
+	return result, err
+}
+
+// reconcileManager deploys the statefulset that runs the manager for the database, and the rbac it needs
+func (r *ReconcileDatabase) reconcileManager(ctx context.Context, databaseInstance *databasesv1alpha4.Database) (reconcile.Result, error) {
 	// A "database" object is realized in the cluster as a deployment object,
 	// in the namespace specified in the custom resource,
 
@@ -195,6 +216,8 @@ func (r *ReconcileDatabase) Reconcile(ctx context.Context, request reconcile.Req
 			logger.Error(err)
 			return reconcile.Result{}, err
 		}
+
+		r.recorder.Eventf(databaseInstance, corev1.EventTypeNormal, eventReasonManagerDeployed, "Deployed statefulset %s to manage the database", statefulsetName)
 	} else if err != nil {
 		logger.Error(errors.Wrapf(err, "failed to get statefulset %s", statefulsetName))
 		return reconcile.Result{}, err
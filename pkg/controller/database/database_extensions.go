@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	migrationcontroller "github.com/schemahero/schemahero/pkg/controller/migration"
+	"github.com/schemahero/schemahero/pkg/database"
+	"github.com/schemahero/schemahero/pkg/logger"
+	"github.com/schemahero/schemahero/pkg/metrics"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcilePostgresExtensions plans a migration to install and update the extensions in the spec of the database,
+// if one hasn't already been planned for them. The migration is owned by the database, and is approved and
+// executed the same way as migrations for tables
+func (r *ReconcileDatabaseSchema) reconcilePostgresExtensions(databaseInstance *databasesv1alpha4.Database, connection *databasesv1alpha4.PostgresConnection) (reconcile.Result, error) {
+	if len(connection.Extensions) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	logger.Debug("reconciling postgres extensions",
+		zap.String("database", databaseInstance.Name))
+
+	ctx := context.Background()
+
+	extensionsSHA, err := connection.GetExtensionsSHA()
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get extensions sha")
+	}
+
+	migration, err := migrationcontroller.FindMigrationForSpec(ctx, r.Client, "Database", databaseInstance, extensionsSHA)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get migration for extensions")
+	}
+	if migration != nil {
+		logger.Debug("extensions have already been planned",
+			zap.String("database", databaseInstance.Name),
+			zap.String("migration", migration.Name))
+		return reconcile.Result{}, nil
+	}
+
+	driver, connectionURI, err := databaseInstance.GetConnection(ctx)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get connection details for database")
+	}
+
+	db := database.Database{
+		Driver: driver,
+		URI:    connectionURI,
+	}
+
+	statements, err := db.PlanSyncExtensions(connection.Extensions)
+	metrics.ObservePlan("Database", databaseInstance.Name, driver, len(statements), err)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to plan extensions")
+	}
+
+	if len(statements) == 0 {
+		logger.Debug("no statements generated for extensions",
+			zap.String("database", databaseInstance.Name))
+		return reconcile.Result{}, nil
+	}
+
+	// extensions aren't planned for a table, so the migration doesn't have a table name
+	migration = &schemasv1alpha4.Migration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "schemas.schemahero.io/v1alpha4",
+			Kind:       "Migration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: databaseInstance.Namespace,
+		},
+		Spec: schemasv1alpha4.MigrationSpec{
+			GeneratedDDL:   strings.Join(statements, ";\n"),
+			DatabaseName:   databaseInstance.Name,
+			TableNamespace: databaseInstance.Namespace,
+		},
+		Status: schemasv1alpha4.MigrationStatus{
+			PlannedAt: time.Now().Unix(),
+			Phase:     schemasv1alpha4.Planned,
+		},
+	}
+
+	migrationcontroller.SetMigrationRisk(migration, statements)
+
+	if databaseInstance.Spec.ImmediateDeploy {
+		migration.Status.ApprovedAt = time.Now().Unix()
+		migration.Status.ApprovedBy = "immediateDeploy"
+	}
+
+	if err := migrationcontroller.SavePlannedMigration(ctx, r.Client, r.scheme, "Database", databaseInstance, extensionsSHA, migration); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to save migration")
+	}
+
+	r.recorder.Eventf(databaseInstance, corev1.EventTypeNormal, migrationcontroller.EventReasonMigrationPlanned,
+		"Planned migration %s with %d statement(s) to install or update extensions", migration.Name, len(statements))
+
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database"
+	"github.com/schemahero/schemahero/pkg/logger"
+	"github.com/schemahero/schemahero/pkg/metrics"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// healthCheckInterval is how often the connection to a database is checked
+const healthCheckInterval = time.Minute
+
+// reconcileHealth checks that the database can be connected to and records the result in the status. Checks
+// are limited to one per healthCheckInterval, because updating the status queues another reconcile. The
+// returned duration is how long until the next check is due
+func (r *ReconcileDatabaseSchema) reconcileHealth(ctx context.Context, databaseInstance *databasesv1alpha4.Database) (time.Duration, error) {
+	// the manager only has access to the credentials for the databases it was started for
+	if !r.isManagedDatabase(databaseInstance.Name) {
+		return 0, nil
+	}
+
+	now := time.Now()
+	if nextCheck := nextHealthCheck(databaseInstance.Status, now); nextCheck > 0 {
+		return nextCheck, nil
+	}
+
+	driver, engineVersion, latency, checkErr := checkConnection(ctx, databaseInstance)
+	if checkErr != nil {
+		metrics.IncConnectionFailures(databaseInstance.Name, driver)
+		logger.Debug("database connection check failed",
+			zap.String("name", databaseInstance.Name),
+			zap.Error(checkErr))
+	}
+
+	previousStatus := metav1.ConditionUnknown
+	if condition := meta.FindStatusCondition(databaseInstance.Status.Conditions, databasesv1alpha4.DatabaseConditionConnected); condition != nil {
+		previousStatus = condition.Status
+	}
+
+	setHealthStatus(&databaseInstance.Status, databaseInstance.Generation, now, engineVersion, latency, checkErr)
+	if err := r.Status().Update(ctx, databaseInstance); err != nil {
+		return 0, errors.Wrap(err, "failed to update database status")
+	}
+
+	// only record changes in connectivity, not every check
+	if checkErr != nil && previousStatus != metav1.ConditionFalse {
+		r.recorder.Event(databaseInstance, corev1.EventTypeWarning, eventReasonConnectionFailed, checkErr.Error())
+	} else if checkErr == nil && previousStatus != metav1.ConditionTrue {
+		r.recorder.Event(databaseInstance, corev1.EventTypeNormal, eventReasonConnected, "Connected to the database")
+	}
+
+	return healthCheckInterval, nil
+}
+
+func (r *ReconcileDatabaseSchema) isManagedDatabase(name string) bool {
+	if len(r.databaseNames) == 0 {
+		return true
+	}
+
+	for _, databaseName := range r.databaseNames {
+		if databaseName == name || databaseName == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkConnection returns the driver and version of the database, and how long it took to connect
+func checkConnection(ctx context.Context, databaseInstance *databasesv1alpha4.Database) (string, string, time.Duration, error) {
+	driver, connectionURI, err := databaseInstance.GetConnection(ctx)
+	if err != nil {
+		return "", "", 0, errors.Wrap(err, "failed to get connection details for database")
+	}
+
+	db := database.Database{
+		Driver: driver,
+		URI:    connectionURI,
+	}
+
+	startedAt := time.Now()
+	engineVersion, err := db.CheckConnection()
+	if err != nil {
+		return driver, "", 0, err
+	}
+
+	return driver, engineVersion, time.Since(startedAt), nil
+}
+
+// nextHealthCheck returns how long until the next health check is due, or 0 if it's due now
+func nextHealthCheck(status databasesv1alpha4.DatabaseStatus, now time.Time) time.Duration {
+	if status.LastPing == "" {
+		return 0
+	}
+
+	lastPing, err := time.Parse(time.RFC3339, status.LastPing)
+	if err != nil {
+		return 0
+	}
+
+	nextCheck := lastPing.Add(healthCheckInterval).Sub(now)
+	if nextCheck < 0 {
+		return 0
+	}
+
+	return nextCheck
+}
+
+// setHealthStatus records the result of a connection check in the status
+func setHealthStatus(status *databasesv1alpha4.DatabaseStatus, generation int64, now time.Time, engineVersion string, latency time.Duration, checkErr error) {
+	status.LastPing = now.UTC().Format(time.RFC3339)
+	status.IsConnected = checkErr == nil
+
+	condition := metav1.Condition{
+		Type:               databasesv1alpha4.DatabaseConditionConnected,
+		ObservedGeneration: generation,
+	}
+
+	if checkErr != nil {
+		status.LastError = checkErr.Error()
+		status.LatencyMilliseconds = 0
+
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ConnectionFailed"
+		condition.Message = checkErr.Error()
+	} else {
+		status.LastError = ""
+		status.LatencyMilliseconds = latency.Milliseconds()
+		if engineVersion != "" {
+			status.EngineVersion = engineVersion
+		}
+
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Connected"
+		condition.Message = "Connected to the database"
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
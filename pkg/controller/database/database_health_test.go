@@ -0,0 +1,113 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_nextHealthCheck(t *testing.T) {
+	now := time.Date(2022, 11, 2, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		lastPing string
+		expect   time.Duration
+	}{
+		{
+			name:     "never checked",
+			lastPing: "",
+			expect:   0,
+		},
+		{
+			name:     "checked recently",
+			lastPing: "2022-11-02T12:29:45Z",
+			expect:   45 * time.Second,
+		},
+		{
+			name:     "check is overdue",
+			lastPing: "2022-11-02T12:00:00Z",
+			expect:   0,
+		},
+		{
+			name:     "unparsable last ping",
+			lastPing: "yesterday",
+			expect:   0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := databasesv1alpha4.DatabaseStatus{
+				LastPing: test.lastPing,
+			}
+			assert.Equal(t, test.expect, nextHealthCheck(status, now))
+		})
+	}
+}
+
+func Test_setHealthStatus(t *testing.T) {
+	now := time.Date(2022, 11, 2, 12, 30, 0, 0, time.UTC)
+	status := databasesv1alpha4.DatabaseStatus{}
+
+	setHealthStatus(&status, 2, now, "14.5", 25*time.Millisecond, nil)
+	assert.True(t, status.IsConnected)
+	assert.Equal(t, "2022-11-02T12:30:00Z", status.LastPing)
+	assert.Equal(t, "14.5", status.EngineVersion)
+	assert.Equal(t, int64(25), status.LatencyMilliseconds)
+	assert.Empty(t, status.LastError)
+
+	condition := meta.FindStatusCondition(status.Conditions, databasesv1alpha4.DatabaseConditionConnected)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+
+	setHealthStatus(&status, 2, now.Add(time.Minute), "", 0, errors.New("connection refused"))
+	assert.False(t, status.IsConnected)
+	assert.Equal(t, "connection refused", status.LastError)
+	assert.Equal(t, "14.5", status.EngineVersion, "expected the last known engine version to be kept")
+
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[0].Status)
+	assert.Equal(t, "ConnectionFailed", status.Conditions[0].Reason)
+}
+
+func Test_isManagedDatabase(t *testing.T) {
+	tests := []struct {
+		name          string
+		databaseNames []string
+		expect        bool
+	}{
+		{
+			name:   "no database names",
+			expect: true,
+		},
+		{
+			name:          "named database",
+			databaseNames: []string{"other", "db"},
+			expect:        true,
+		},
+		{
+			name:          "all databases",
+			databaseNames: []string{"*"},
+			expect:        true,
+		},
+		{
+			name:          "other database",
+			databaseNames: []string{"other"},
+			expect:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ReconcileDatabaseSchema{databaseNames: tt.databaseNames}
+			assert.Equal(t, tt.expect, r.isManagedDatabase("db"))
+		})
+	}
+}
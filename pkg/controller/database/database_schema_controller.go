@@ -20,12 +20,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
 
 	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/logger"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -36,29 +39,90 @@ var _ reconcile.Reconciler = &ReconcileDatabaseSchema{}
 type ReconcileDatabaseSchema struct {
 	client.Client
 	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
 	databaseNames []string
+
+	// reconciledGenerations are the generations of the databases that the schema was last reconciled for. The
+	// requeues for health checks don't change the generation, so they don't connect to plan the schema again
+	reconciledGenerationsMu sync.Mutex
+	reconciledGenerations   map[types.NamespacedName]int64
 }
 
 // Reconcile reads that state of the cluster for a Database object and makes changes based on the state read
 // and what is in the Database.Spec for schemas
 // +kubebuilder:rbac:groups=databases.schemahero.io,resources=databases,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=databases.schemahero.io,resources=databases/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=schemas.schemahero.io,resources=migrations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 func (r *ReconcileDatabaseSchema) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	databaseInstance, err := r.getInstance(request)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	// a failed health check doesn't stop the schema from being reconciled
+	nextHealthCheck, err := r.reconcileHealth(ctx, databaseInstance)
+	if err != nil {
+		logger.Error(err)
+	}
+
+	result := reconcile.Result{}
+	if !r.isSchemaReconciled(request.NamespacedName, databaseInstance.Generation) {
+		result, err = r.reconcileDatabaseSchema(databaseInstance)
+		if err != nil {
+			return result, err
+		}
+		r.setSchemaReconciled(request.NamespacedName, databaseInstance.Generation)
+	}
+
+	// requeue to keep checking the connection to the database
+	if nextHealthCheck > 0 && (result.RequeueAfter == 0 || nextHealthCheck < result.RequeueAfter) {
+		result.RequeueAfter = nextHealthCheck
+	}
+
+	return result, nil
+}
+
+// isSchemaReconciled returns true when the schema of the database was already reconciled for the generation
+func (r *ReconcileDatabaseSchema) isSchemaReconciled(name types.NamespacedName, generation int64) bool {
+	r.reconciledGenerationsMu.Lock()
+	defer r.reconciledGenerationsMu.Unlock()
+
+	reconciledGeneration, ok := r.reconciledGenerations[name]
+	return ok && reconciledGeneration == generation
+}
+
+func (r *ReconcileDatabaseSchema) setSchemaReconciled(name types.NamespacedName, generation int64) {
+	r.reconciledGenerationsMu.Lock()
+	defer r.reconciledGenerationsMu.Unlock()
+
+	if r.reconciledGenerations == nil {
+		r.reconciledGenerations = map[types.NamespacedName]int64{}
+	}
+	r.reconciledGenerations[name] = generation
+}
+
+func (r *ReconcileDatabaseSchema) reconcileDatabaseSchema(databaseInstance *databasesv1alpha4.Database) (reconcile.Result, error) {
 	// SchemaHero does not current support any database-wide schema properties in rqlite
 	if databaseInstance.Spec.Connection.RQLite != nil {
 		logger.Debug("ignoring rqlite database schema reconcile request")
 		return reconcile.Result{}, nil
 	}
 
-	// SchemaHero does not current support any database-wide schema properties in postgresql
 	if databaseInstance.Spec.Connection.Postgres != nil {
-		logger.Debug("ignoring postgres database schema reconcile request")
-		return reconcile.Result{}, nil
+		result, err := r.reconcilePostgresExtensions(databaseInstance, databaseInstance.Spec.Connection.Postgres)
+		if err != nil {
+			logger.Error(err)
+		}
+		return result, err
+	}
+
+	if databaseInstance.Spec.Connection.TimescaleDB != nil {
+		result, err := r.reconcilePostgresExtensions(databaseInstance, databaseInstance.Spec.Connection.TimescaleDB)
+		if err != nil {
+			logger.Error(err)
+		}
+		return result, err
 	}
 
 	// SchemaHero does not current support any database-wide schema properties in cockroachdb
@@ -0,0 +1,20 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_isSchemaReconciled(t *testing.T) {
+	r := &ReconcileDatabaseSchema{}
+	name := types.NamespacedName{Namespace: "default", Name: "testdb"}
+
+	assert.False(t, r.isSchemaReconciled(name, 1))
+
+	r.setSchemaReconciled(name, 1)
+	assert.True(t, r.isSchemaReconciled(name, 1))
+	assert.False(t, r.isSchemaReconciled(name, 2))
+	assert.False(t, r.isSchemaReconciled(types.NamespacedName{Namespace: "default", Name: "otherdb"}, 1))
+}
@@ -164,6 +164,11 @@ func (r *ReconcileDatabase) reconcileRBACRole(ctx context.Context, databaseInsta
 					Resources: []string{"views/status"},
 					Verbs:     metav1.Verbs{"get", "update", "patch"},
 				},
+				{
+					APIGroups: []string{""},
+					Resources: []string{"events"},
+					Verbs:     metav1.Verbs{"create", "patch"},
+				},
 			},
 		}
 
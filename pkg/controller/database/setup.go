@@ -45,6 +45,7 @@ func newReconciler(mgr manager.Manager, managerImage string, managerTag string,
 	return &ReconcileDatabase{
 		Client:       mgr.GetClient(),
 		scheme:       mgr.GetScheme(),
+		recorder:     mgr.GetEventRecorderFor("schemahero-database-controller"),
 		managerImage: managerImage,
 		managerTag:   managerTag,
 		debugLogs:    debugLogs,
@@ -55,6 +56,7 @@ func newDatabaseSchemaReconciler(mgr manager.Manager, databaseNames []string) re
 	return &ReconcileDatabaseSchema{
 		Client:        mgr.GetClient(),
 		scheme:        mgr.GetScheme(),
+		recorder:      mgr.GetEventRecorderFor("schemahero-database-schema-controller"),
 		databaseNames: databaseNames,
 	}
 }
@@ -0,0 +1,88 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetDatabaseFoundCondition records if the database that the table or view is deployed to exists. Until it
+// does, nothing can be planned
+func SetDatabaseFoundCondition(conditions *[]metav1.Condition, generation int64, databaseName string, found bool) {
+	if found {
+		setCondition(conditions, generation, schemasv1alpha4.ConditionDatabaseFound, metav1.ConditionTrue, "DatabaseFound",
+			fmt.Sprintf("Database %s was found", databaseName))
+		return
+	}
+
+	setCondition(conditions, generation, schemasv1alpha4.ConditionDatabaseFound, metav1.ConditionFalse, "DatabaseNotFound",
+		fmt.Sprintf("Database %s was not found", databaseName))
+	setCondition(conditions, generation, schemasv1alpha4.ConditionPlanned, metav1.ConditionFalse, "WaitingForDatabase",
+		fmt.Sprintf("Waiting for database %s to be created", databaseName))
+}
+
+// SetErrorCondition records the error from the last reconcile of the table or view. A nil error clears the condition
+func SetErrorCondition(conditions *[]metav1.Condition, generation int64, reason string, err error) {
+	if err == nil {
+		setCondition(conditions, generation, schemasv1alpha4.ConditionError, metav1.ConditionFalse, "ReconcileSucceeded", "")
+		return
+	}
+
+	setCondition(conditions, generation, schemasv1alpha4.ConditionError, metav1.ConditionTrue, reason, err.Error())
+}
+
+// SetMigrationConditions records the state of the migration that was planned for the current spec of the table
+// or view. A nil migration means that planning found no changes to make
+func SetMigrationConditions(conditions *[]metav1.Condition, generation int64, migration *schemasv1alpha4.Migration) {
+	if migration == nil {
+		setCondition(conditions, generation, schemasv1alpha4.ConditionPlanned, metav1.ConditionTrue, "NoChanges",
+			"The database already matches the spec")
+		setCondition(conditions, generation, schemasv1alpha4.ConditionMigrationPending, metav1.ConditionFalse, "NoChanges", "")
+		setCondition(conditions, generation, schemasv1alpha4.ConditionApplied, metav1.ConditionTrue, "NoChanges",
+			"The database already matches the spec")
+		return
+	}
+
+	setCondition(conditions, generation, schemasv1alpha4.ConditionPlanned, metav1.ConditionTrue, "MigrationPlanned",
+		fmt.Sprintf("Migration %s was planned", migration.Name))
+
+	switch {
+	case migration.Status.ExecutedAt > 0:
+		setCondition(conditions, generation, schemasv1alpha4.ConditionMigrationPending, metav1.ConditionFalse, "MigrationExecuted", "")
+		setCondition(conditions, generation, schemasv1alpha4.ConditionApplied, metav1.ConditionTrue, "MigrationExecuted",
+			fmt.Sprintf("Migration %s was executed", migration.Name))
+	case migration.Status.RejectedAt > 0:
+		setCondition(conditions, generation, schemasv1alpha4.ConditionMigrationPending, metav1.ConditionFalse, "MigrationRejected", "")
+		setCondition(conditions, generation, schemasv1alpha4.ConditionApplied, metav1.ConditionFalse, "MigrationRejected",
+			fmt.Sprintf("Migration %s was rejected", migration.Name))
+	case migration.Status.ApprovedAt > 0 && migration.Status.BlockedReason != "":
+		message := fmt.Sprintf("Migration %s was approved but is blocked: %s", migration.Name, migration.Status.BlockedReason)
+		setCondition(conditions, generation, schemasv1alpha4.ConditionMigrationPending, metav1.ConditionTrue, "MigrationBlocked", message)
+		setCondition(conditions, generation, schemasv1alpha4.ConditionApplied, metav1.ConditionFalse, "MigrationBlocked", message)
+	case migration.Status.ApprovedAt > 0:
+		message := fmt.Sprintf("Migration %s was approved and is waiting to be executed", migration.Name)
+		if migration.Status.ScheduledAt > 0 {
+			message = fmt.Sprintf("Migration %s was approved and is scheduled to be executed at %s", migration.Name,
+				time.Unix(migration.Status.ScheduledAt, 0).UTC().Format(time.RFC3339))
+		}
+		setCondition(conditions, generation, schemasv1alpha4.ConditionMigrationPending, metav1.ConditionTrue, "MigrationApproved", message)
+		setCondition(conditions, generation, schemasv1alpha4.ConditionApplied, metav1.ConditionFalse, "MigrationPending", message)
+	default:
+		message := fmt.Sprintf("Migration %s is waiting to be approved", migration.Name)
+		setCondition(conditions, generation, schemasv1alpha4.ConditionMigrationPending, metav1.ConditionTrue, "AwaitingApproval", message)
+		setCondition(conditions, generation, schemasv1alpha4.ConditionApplied, metav1.ConditionFalse, "MigrationPending", message)
+	}
+}
+
+func setCondition(conditions *[]metav1.Condition, generation int64, conditionType string, status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
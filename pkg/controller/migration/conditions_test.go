@@ -0,0 +1,125 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_SetMigrationConditions(t *testing.T) {
+	migration := func(status schemasv1alpha4.MigrationStatus) *schemasv1alpha4.Migration {
+		return &schemasv1alpha4.Migration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "abc1234",
+			},
+			Status: status,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		migration     *schemasv1alpha4.Migration
+		expectPending metav1.ConditionStatus
+		expectApplied metav1.ConditionStatus
+		expectReason  string
+	}{
+		{
+			name:          "no changes",
+			migration:     nil,
+			expectPending: metav1.ConditionFalse,
+			expectApplied: metav1.ConditionTrue,
+			expectReason:  "NoChanges",
+		},
+		{
+			name:          "awaiting approval",
+			migration:     migration(schemasv1alpha4.MigrationStatus{PlannedAt: 1}),
+			expectPending: metav1.ConditionTrue,
+			expectApplied: metav1.ConditionFalse,
+			expectReason:  "AwaitingApproval",
+		},
+		{
+			name:          "approved",
+			migration:     migration(schemasv1alpha4.MigrationStatus{PlannedAt: 1, ApprovedAt: 2}),
+			expectPending: metav1.ConditionTrue,
+			expectApplied: metav1.ConditionFalse,
+			expectReason:  "MigrationApproved",
+		},
+		{
+			name:          "blocked",
+			migration:     migration(schemasv1alpha4.MigrationStatus{PlannedAt: 1, ApprovedAt: 2, BlockedReason: "destructive"}),
+			expectPending: metav1.ConditionTrue,
+			expectApplied: metav1.ConditionFalse,
+			expectReason:  "MigrationBlocked",
+		},
+		{
+			name:          "executed",
+			migration:     migration(schemasv1alpha4.MigrationStatus{PlannedAt: 1, ApprovedAt: 2, ExecutedAt: 3}),
+			expectPending: metav1.ConditionFalse,
+			expectApplied: metav1.ConditionTrue,
+			expectReason:  "MigrationExecuted",
+		},
+		{
+			name:          "rejected",
+			migration:     migration(schemasv1alpha4.MigrationStatus{PlannedAt: 1, RejectedAt: 2}),
+			expectPending: metav1.ConditionFalse,
+			expectApplied: metav1.ConditionFalse,
+			expectReason:  "MigrationRejected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions := []metav1.Condition{}
+			SetMigrationConditions(&conditions, 4, tt.migration)
+
+			planned := meta.FindStatusCondition(conditions, schemasv1alpha4.ConditionPlanned)
+			require.NotNil(t, planned)
+			assert.Equal(t, metav1.ConditionTrue, planned.Status)
+			assert.Equal(t, int64(4), planned.ObservedGeneration)
+
+			pending := meta.FindStatusCondition(conditions, schemasv1alpha4.ConditionMigrationPending)
+			require.NotNil(t, pending)
+			assert.Equal(t, tt.expectPending, pending.Status)
+
+			applied := meta.FindStatusCondition(conditions, schemasv1alpha4.ConditionApplied)
+			require.NotNil(t, applied)
+			assert.Equal(t, tt.expectApplied, applied.Status)
+
+			if tt.expectPending == metav1.ConditionTrue {
+				assert.Equal(t, tt.expectReason, pending.Reason)
+			} else {
+				assert.Equal(t, tt.expectReason, applied.Reason)
+			}
+		})
+	}
+}
+
+func Test_SetDatabaseFoundCondition(t *testing.T) {
+	conditions := []metav1.Condition{}
+
+	SetDatabaseFoundCondition(&conditions, 1, "db", false)
+	assert.True(t, meta.IsStatusConditionFalse(conditions, schemasv1alpha4.ConditionDatabaseFound))
+	assert.True(t, meta.IsStatusConditionFalse(conditions, schemasv1alpha4.ConditionPlanned))
+
+	SetDatabaseFoundCondition(&conditions, 1, "db", true)
+	assert.True(t, meta.IsStatusConditionTrue(conditions, schemasv1alpha4.ConditionDatabaseFound))
+}
+
+func Test_SetErrorCondition(t *testing.T) {
+	conditions := []metav1.Condition{}
+
+	SetErrorCondition(&conditions, 1, "DatabaseTypeMismatch", errors.New("unable to deploy"))
+	condition := meta.FindStatusCondition(conditions, schemasv1alpha4.ConditionError)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "DatabaseTypeMismatch", condition.Reason)
+	assert.Equal(t, "unable to deploy", condition.Message)
+
+	SetErrorCondition(&conditions, 1, "", nil)
+	assert.True(t, meta.IsStatusConditionFalse(conditions, schemasv1alpha4.ConditionError))
+}
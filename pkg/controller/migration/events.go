@@ -0,0 +1,99 @@
+package migration
+
+import (
+	"fmt"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons for the events that are recorded on tables, views and migrations
+const (
+	EventReasonDatabaseNotFound  = "DatabaseNotFound"
+	EventReasonMigrationPlanned  = "MigrationPlanned"
+	EventReasonNoChanges         = "NoChanges"
+	EventReasonApprovalRequired  = "ApprovalRequired"
+	EventReasonMigrationExecuted = "MigrationExecuted"
+	EventReasonMigrationRejected = "MigrationRejected"
+	EventReasonMigrationFailed   = "MigrationFailed"
+	EventReasonMigrationBlocked  = "MigrationBlocked"
+)
+
+// RecordConditionEvents records an event on the table or view for each condition that changed in the
+// reconcile. Events are only recorded for transitions so that requeued reconciles don't repeat them
+func RecordConditionEvents(recorder record.EventRecorder, object runtime.Object, previous []metav1.Condition, current []metav1.Condition) {
+	for _, condition := range current {
+		previousCondition := meta.FindStatusCondition(previous, condition.Type)
+		if previousCondition != nil &&
+			previousCondition.Status == condition.Status &&
+			previousCondition.Reason == condition.Reason &&
+			previousCondition.Message == condition.Message {
+			continue
+		}
+
+		eventType, reason, ok := eventForCondition(condition)
+		if !ok {
+			continue
+		}
+
+		recorder.Event(object, eventType, reason, condition.Message)
+	}
+}
+
+// eventForCondition returns the type and reason of the event to record when a condition changes, or false
+// when the change isn't interesting enough for an event
+func eventForCondition(condition metav1.Condition) (string, string, bool) {
+	switch condition.Type {
+	case schemasv1alpha4.ConditionDatabaseFound:
+		if condition.Status == metav1.ConditionFalse {
+			return corev1.EventTypeWarning, EventReasonDatabaseNotFound, true
+		}
+	case schemasv1alpha4.ConditionPlanned:
+		if condition.Status == metav1.ConditionTrue && condition.Reason == "NoChanges" {
+			return corev1.EventTypeNormal, EventReasonNoChanges, true
+		}
+		if condition.Status == metav1.ConditionTrue && condition.Reason == "MigrationPlanned" {
+			return corev1.EventTypeNormal, EventReasonMigrationPlanned, true
+		}
+	case schemasv1alpha4.ConditionMigrationPending:
+		if condition.Status == metav1.ConditionTrue && condition.Reason == "AwaitingApproval" {
+			return corev1.EventTypeNormal, EventReasonApprovalRequired, true
+		}
+	case schemasv1alpha4.ConditionApplied:
+		if condition.Status == metav1.ConditionTrue && condition.Reason == "MigrationExecuted" {
+			return corev1.EventTypeNormal, EventReasonMigrationExecuted, true
+		}
+		if condition.Status == metav1.ConditionFalse && condition.Reason == "MigrationRejected" {
+			return corev1.EventTypeWarning, EventReasonMigrationRejected, true
+		}
+	case schemasv1alpha4.ConditionError:
+		if condition.Status == metav1.ConditionTrue {
+			return corev1.EventTypeWarning, condition.Reason, true
+		}
+	}
+
+	return "", "", false
+}
+
+// recordMigrationEvent records an event on the migration and on the table or view that planned it, so
+// that the result of executing the migration is shown when describing either
+func recordMigrationEvent(recorder record.EventRecorder, migration *schemasv1alpha4.Migration, eventType string, reason string, message string) {
+	recorder.Event(migration, eventType, reason, message)
+
+	owner := metav1.GetControllerOf(migration)
+	if owner == nil {
+		return
+	}
+
+	recorder.Event(&corev1.ObjectReference{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Name:       owner.Name,
+		Namespace:  migration.Namespace,
+		UID:        owner.UID,
+	}, eventType, reason, fmt.Sprintf("Migration %s: %s", migration.Name, message))
+}
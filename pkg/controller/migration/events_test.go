@@ -0,0 +1,133 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func Test_RecordConditionEvents(t *testing.T) {
+	migration := func(status schemasv1alpha4.MigrationStatus) *schemasv1alpha4.Migration {
+		return &schemasv1alpha4.Migration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "abc1234",
+			},
+			Status: status,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		update func(conditions *[]metav1.Condition)
+		expect []string
+	}{
+		{
+			name: "database not found",
+			update: func(conditions *[]metav1.Condition) {
+				SetDatabaseFoundCondition(conditions, 1, "db", false)
+			},
+			expect: []string{
+				"Warning DatabaseNotFound Database db was not found",
+			},
+		},
+		{
+			name: "no changes",
+			update: func(conditions *[]metav1.Condition) {
+				SetDatabaseFoundCondition(conditions, 1, "db", true)
+				SetMigrationConditions(conditions, 1, nil)
+			},
+			expect: []string{
+				"Normal NoChanges The database already matches the spec",
+			},
+		},
+		{
+			name: "awaiting approval",
+			update: func(conditions *[]metav1.Condition) {
+				SetDatabaseFoundCondition(conditions, 1, "db", true)
+				SetMigrationConditions(conditions, 1, migration(schemasv1alpha4.MigrationStatus{PlannedAt: 1}))
+			},
+			expect: []string{
+				"Normal MigrationPlanned Migration abc1234 was planned",
+				"Normal ApprovalRequired Migration abc1234 is waiting to be approved",
+			},
+		},
+		{
+			name: "executed",
+			update: func(conditions *[]metav1.Condition) {
+				SetDatabaseFoundCondition(conditions, 1, "db", true)
+				SetMigrationConditions(conditions, 1, migration(schemasv1alpha4.MigrationStatus{PlannedAt: 1, ApprovedAt: 2, ExecutedAt: 3}))
+			},
+			expect: []string{
+				"Normal MigrationPlanned Migration abc1234 was planned",
+				"Normal MigrationExecuted Migration abc1234 was executed",
+			},
+		},
+		{
+			name: "type mismatch",
+			update: func(conditions *[]metav1.Condition) {
+				SetErrorCondition(conditions, 1, "DatabaseTypeMismatch", errors.New("mismatch"))
+			},
+			expect: []string{
+				"Warning DatabaseTypeMismatch mismatch",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			current := []metav1.Condition{}
+			tt.update(&current)
+
+			RecordConditionEvents(recorder, &schemasv1alpha4.Table{}, nil, current)
+			assert.Equal(t, tt.expect, drainEvents(recorder))
+
+			// the same conditions don't record the events again
+			RecordConditionEvents(recorder, &schemasv1alpha4.Table{}, current, current)
+			assert.Empty(t, drainEvents(recorder))
+		})
+	}
+}
+
+func Test_recordMigrationEvent(t *testing.T) {
+	isController := true
+	migration := &schemasv1alpha4.Migration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "abc1234",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "schemas.schemahero.io/v1alpha4",
+					Kind:       "Table",
+					Name:       "users",
+					UID:        "uid",
+					Controller: &isController,
+				},
+			},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	recordMigrationEvent(recorder, migration, corev1.EventTypeWarning, EventReasonMigrationFailed, "syntax error")
+
+	assert.Equal(t, []string{
+		"Warning MigrationFailed syntax error",
+		"Warning MigrationFailed Migration abc1234: syntax error",
+	}, drainEvents(recorder))
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	events := []string{}
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package migration
+
+import (
+	"sort"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// History returns the migrations that have been executed for the table or view, in the order they were executed.
+// Migrations planned before owner labels were added to migrations are matched by the table name in the spec
+func History(migrations []schemasv1alpha4.Migration, ownerKind string, owner metav1.Object) []schemasv1alpha4.Migration {
+	history := []schemasv1alpha4.Migration{}
+	for _, migration := range migrations {
+		if migration.Status.ExecutedAt == 0 {
+			continue
+		}
+
+		if !isOwnedBy(&migration, ownerKind, owner) {
+			continue
+		}
+
+		history = append(history, migration)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Status.ExecutedAt < history[j].Status.ExecutedAt
+	})
+
+	return history
+}
+
+func isOwnedBy(migration *schemasv1alpha4.Migration, ownerKind string, owner metav1.Object) bool {
+	if migration.Namespace != owner.GetNamespace() {
+		return false
+	}
+
+	ownerUID, ok := migration.Labels[schemasv1alpha4.MigrationOwnerUIDLabel]
+	if ok {
+		return ownerUID == string(owner.GetUID()) && migration.Labels[schemasv1alpha4.MigrationOwnerKindLabel] == ownerKind
+	}
+
+	return migration.Spec.TableNamespace == owner.GetNamespace() && migration.Spec.TableName == owner.GetName()
+}
@@ -0,0 +1,59 @@
+package migration
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_History(t *testing.T) {
+	table := testTable("a", 2)
+
+	owned := func(name string, executedAt int64) schemasv1alpha4.Migration {
+		m := schemasv1alpha4.Migration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Status: schemasv1alpha4.MigrationStatus{
+				ExecutedAt: executedAt,
+			},
+		}
+		SetOwnerLabels(&m, "Table", table, "sha")
+		return m
+	}
+
+	otherTable := owned("other", 5)
+	SetOwnerLabels(&otherTable, "Table", testTable("b", 1), "sha")
+
+	legacy := schemasv1alpha4.Migration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "legacy",
+			Namespace: "default",
+		},
+		Spec: schemasv1alpha4.MigrationSpec{
+			TableName:      table.Name,
+			TableNamespace: "default",
+		},
+		Status: schemasv1alpha4.MigrationStatus{
+			ExecutedAt: 1,
+		},
+	}
+
+	migrations := []schemasv1alpha4.Migration{
+		owned("second", 3),
+		owned("pending", 0),
+		otherTable,
+		legacy,
+		owned("first", 2),
+	}
+
+	names := []string{}
+	for _, m := range History(migrations, "Table", table) {
+		names = append(names, m.Name)
+	}
+
+	assert.Equal(t, []string{"legacy", "first", "second"}, names)
+}
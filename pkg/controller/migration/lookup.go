@@ -95,6 +95,22 @@ func ViewFromMigration(ctx context.Context, migration *schemasv1alpha4.Migration
 	return table, nil
 }
 
+// DatabaseFromMigration returns the database that planned the migration, for migrations that are owned by a
+// database instead of a table or view
+func DatabaseFromMigration(ctx context.Context, migration *schemasv1alpha4.Migration) (*databasesv1alpha4.Database, error) {
+	databasesClient, err := getDatabasesClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get databases client")
+	}
+
+	database, err := databasesClient.Databases(migration.Spec.TableNamespace).Get(ctx, migration.Spec.DatabaseName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database")
+	}
+
+	return database, nil
+}
+
 func DatabaseFromView(ctx context.Context, view *schemasv1alpha4.View) (*databasesv1alpha4.Database, error) {
 	databasesClient, err := getDatabasesClient()
 	if err != nil {
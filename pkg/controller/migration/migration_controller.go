@@ -23,10 +23,12 @@ import (
 	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/logger"
+	"github.com/schemahero/schemahero/pkg/metrics"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/runtime"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -38,6 +40,10 @@ import (
 // Add creates a new Migration Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager, databaseNames []string) error {
+	if err := metrics.RegisterMigrationCollector(mgr.GetClient(), databaseNames); err != nil {
+		return errors.Wrap(err, "failed to register migration metrics")
+	}
+
 	return add(mgr, newReconciler(databaseNames, mgr))
 }
 
@@ -46,6 +52,7 @@ func newReconciler(databaseNames []string, mgr manager.Manager) reconcile.Reconc
 	return &ReconcileMigration{
 		Client:        mgr.GetClient(),
 		scheme:        mgr.GetScheme(),
+		recorder:      mgr.GetEventRecorderFor("schemahero-migration-controller"),
 		databaseNames: databaseNames,
 	}
 }
@@ -84,6 +91,7 @@ var _ reconcile.Reconciler = &ReconcileMigration{}
 type ReconcileMigration struct {
 	client.Client
 	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
 	databaseNames []string
 }
 
@@ -94,6 +102,7 @@ type ReconcileMigration struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=schemas.schemahero.io,resources=migrations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=schemas.schemahero.io,resources=migrations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 func (r *ReconcileMigration) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	// This reconcile loop will be called for all Migration objects and all pods
 	// because of the informer that we have set up
@@ -0,0 +1,187 @@
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// minMigrationNameLength is the length of the generated migration names, this is
+	// extended only when a different migration already has the shorter name
+	minMigrationNameLength = 7
+
+	maxLabelValueLength = 63
+)
+
+// OwnerLabels returns the labels that identify the table or view, and the spec, that a migration was planned for
+func OwnerLabels(ownerKind string, owner metav1.Object, specSHA string) map[string]string {
+	return map[string]string{
+		schemasv1alpha4.MigrationOwnerKindLabel:  ownerKind,
+		schemasv1alpha4.MigrationOwnerUIDLabel:   string(owner.GetUID()),
+		schemasv1alpha4.MigrationGenerationLabel: strconv.FormatInt(owner.GetGeneration(), 10),
+		schemasv1alpha4.MigrationSpecSHALabel:    truncateLabelValue(specSHA),
+	}
+}
+
+// SetOwnerLabels records the table or view, and the spec, that the migration was planned for on the migration
+func SetOwnerLabels(migration *schemasv1alpha4.Migration, ownerKind string, owner metav1.Object, specSHA string) {
+	if migration.Labels == nil {
+		migration.Labels = map[string]string{}
+	}
+	for k, v := range OwnerLabels(ownerKind, owner, specSHA) {
+		migration.Labels[k] = v
+	}
+
+	if migration.Annotations == nil {
+		migration.Annotations = map[string]string{}
+	}
+	migration.Annotations[schemasv1alpha4.MigrationSpecSHAAnnotation] = specSHA
+}
+
+// IsPlannedFor returns true if the migration was planned for this generation and spec of the owner
+func IsPlannedFor(migration *schemasv1alpha4.Migration, ownerKind string, owner metav1.Object, specSHA string) bool {
+	for k, v := range OwnerLabels(ownerKind, owner, specSHA) {
+		if migration.Labels[k] != v {
+			return false
+		}
+	}
+
+	return migration.Annotations[schemasv1alpha4.MigrationSpecSHAAnnotation] == specSHA
+}
+
+// IsComplete returns true if the migration has been executed or rejected. Complete migrations are a record
+// of what happened and are never modified by a re-plan
+func IsComplete(migration *schemasv1alpha4.Migration) bool {
+	return migration.Status.ExecutedAt > 0 || migration.Status.RejectedAt > 0
+}
+
+// MigrationNames returns the candidate names for a migration planned for this generation and spec of the owner.
+// The first name is the shortest, the rest are only used when an earlier name is in use by another migration
+func MigrationNames(owner metav1.Object, specSHA string) []string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", owner.GetUID(), specSHA, owner.GetGeneration())))
+	nameSHA := fmt.Sprintf("%x", sum)
+
+	names := []string{}
+	for length := minMigrationNameLength; length < len(nameSHA); length += 4 {
+		names = append(names, nameSHA[:length])
+	}
+
+	return append(names, nameSHA)
+}
+
+// ListOwnedMigrations returns all migrations that were planned for the table or view
+func ListOwnedMigrations(ctx context.Context, c client.Client, ownerKind string, owner metav1.Object) ([]schemasv1alpha4.Migration, error) {
+	migrationList := schemasv1alpha4.MigrationList{}
+	err := c.List(ctx, &migrationList, client.InNamespace(owner.GetNamespace()), client.MatchingLabels{
+		schemasv1alpha4.MigrationOwnerKindLabel: ownerKind,
+		schemasv1alpha4.MigrationOwnerUIDLabel:  string(owner.GetUID()),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list migrations")
+	}
+
+	return migrationList.Items, nil
+}
+
+// FindMigrationForSpec returns the migration that has been planned for the current spec of the table or view.
+// A pending migration for the spec is returned first. If there is none, the most recently planned migration is
+// returned when it was planned for this spec, because that means there is nothing new to plan. A nil migration
+// means that the spec needs to be planned
+func FindMigrationForSpec(ctx context.Context, c client.Client, ownerKind string, owner metav1.Object, specSHA string) (*schemasv1alpha4.Migration, error) {
+	migrations, err := ListOwnedMigrations(ctx, c, ownerKind, owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list owned migrations")
+	}
+
+	var latest *schemasv1alpha4.Migration
+	for i := range migrations {
+		migration := &migrations[i]
+
+		isForSpec := migration.Annotations[schemasv1alpha4.MigrationSpecSHAAnnotation] == specSHA
+		if isForSpec && !IsComplete(migration) {
+			return migration, nil
+		}
+
+		if latest == nil || migration.Status.PlannedAt > latest.Status.PlannedAt {
+			latest = migration
+		}
+	}
+
+	if latest != nil && latest.Annotations[schemasv1alpha4.MigrationSpecSHAAnnotation] == specSHA {
+		return latest, nil
+	}
+
+	return nil, nil
+}
+
+// SavePlannedMigration names and creates a migration that was planned for the table or view. If a pending
+// migration was already planned for the same generation and spec, it's updated with the new plan. Migrations
+// that have been executed or rejected are never modified, and migrations planned for other objects that
+// happen to have the same name are never overwritten.
+func SavePlannedMigration(ctx context.Context, c client.Client, scheme *runtime.Scheme, ownerKind string, owner client.Object, specSHA string, migration *schemasv1alpha4.Migration) error {
+	SetOwnerLabels(migration, ownerKind, owner, specSHA)
+
+	for _, name := range MigrationNames(owner, specSHA) {
+		var existingMigration schemasv1alpha4.Migration
+		err := c.Get(ctx, types.NamespacedName{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+		}, &existingMigration)
+
+		if kuberneteserrors.IsNotFound(err) {
+			migration.Name = name
+			migration.Namespace = owner.GetNamespace()
+			if err := controllerutil.SetControllerReference(owner, migration, scheme); err != nil {
+				return errors.Wrap(err, "failed to set owner on migration")
+			}
+
+			if err := c.Create(ctx, migration); err != nil {
+				return errors.Wrap(err, "failed to create migration resource")
+			}
+
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "failed to get existing migration")
+		}
+
+		if !IsPlannedFor(&existingMigration, ownerKind, owner, specSHA) {
+			// this name is taken by a different migration, try the next name
+			continue
+		}
+
+		if IsComplete(&existingMigration) {
+			*migration = existingMigration
+			return nil
+		}
+
+		existingMigration.Status = migration.Status
+		existingMigration.Spec = migration.Spec
+		if err := c.Update(ctx, &existingMigration); err != nil {
+			return errors.Wrap(err, "failed to update migration resource")
+		}
+
+		*migration = existingMigration
+		return nil
+	}
+
+	return errors.Errorf("unable to find an available name for migration of %s %s", ownerKind, owner.GetName())
+}
+
+func truncateLabelValue(value string) string {
+	if len(value) > maxLabelValueLength {
+		return value[:maxLabelValueLength]
+	}
+
+	return value
+}
@@ -0,0 +1,175 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testTable(uid string, generation int64) *schemasv1alpha4.Table {
+	return &schemasv1alpha4.Table{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "table-" + uid,
+			Namespace:  "default",
+			UID:        types.UID(uid),
+			Generation: generation,
+		},
+	}
+}
+
+func Test_SavePlannedMigration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, schemasv1alpha4.AddToScheme(scheme))
+
+	ctx := context.Background()
+	table := testTable("a", 1)
+
+	t.Run("creates, updates and never modifies executed migrations", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(table).Build()
+
+		migration := schemasv1alpha4.Migration{
+			Spec: schemasv1alpha4.MigrationSpec{
+				GeneratedDDL: "create table a",
+			},
+		}
+		require.NoError(t, SavePlannedMigration(ctx, c, scheme, "Table", table, "sha1", &migration))
+		assert.Equal(t, MigrationNames(table, "sha1")[0], migration.Name)
+		assert.Equal(t, "sha1", migration.Annotations[schemasv1alpha4.MigrationSpecSHAAnnotation])
+		assert.Equal(t, "1", migration.Labels[schemasv1alpha4.MigrationGenerationLabel])
+
+		replanned := schemasv1alpha4.Migration{
+			Spec: schemasv1alpha4.MigrationSpec{
+				GeneratedDDL: "create table a (id int)",
+			},
+		}
+		require.NoError(t, SavePlannedMigration(ctx, c, scheme, "Table", table, "sha1", &replanned))
+		assert.Equal(t, migration.Name, replanned.Name)
+		assert.Equal(t, "create table a (id int)", replanned.Spec.GeneratedDDL)
+
+		replanned.Status.ExecutedAt = 1
+		require.NoError(t, c.Update(ctx, &replanned))
+
+		afterExecution := schemasv1alpha4.Migration{
+			Spec: schemasv1alpha4.MigrationSpec{
+				GeneratedDDL: "drop table a",
+			},
+		}
+		require.NoError(t, SavePlannedMigration(ctx, c, scheme, "Table", table, "sha1", &afterExecution))
+		assert.Equal(t, "create table a (id int)", afterExecution.Spec.GeneratedDDL)
+	})
+
+	t.Run("does not overwrite a migration for a different table", func(t *testing.T) {
+		existing := &schemasv1alpha4.Migration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      MigrationNames(table, "sha1")[0],
+				Namespace: "default",
+			},
+			Spec: schemasv1alpha4.MigrationSpec{
+				GeneratedDDL: "create table b",
+			},
+		}
+		SetOwnerLabels(existing, "Table", testTable("b", 1), "sha2")
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(table, existing).Build()
+
+		migration := schemasv1alpha4.Migration{
+			Spec: schemasv1alpha4.MigrationSpec{
+				GeneratedDDL: "create table a",
+			},
+		}
+		require.NoError(t, SavePlannedMigration(ctx, c, scheme, "Table", table, "sha1", &migration))
+		assert.Equal(t, MigrationNames(table, "sha1")[1], migration.Name)
+
+		unchanged := schemasv1alpha4.Migration{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: existing.Name}, &unchanged))
+		assert.Equal(t, "create table b", unchanged.Spec.GeneratedDDL)
+	})
+}
+
+func Test_FindMigrationForSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, schemasv1alpha4.AddToScheme(scheme))
+
+	ctx := context.Background()
+	table := testTable("a", 3)
+
+	migration := func(name string, specSHA string, plannedAt int64, executedAt int64) *schemasv1alpha4.Migration {
+		m := &schemasv1alpha4.Migration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Status: schemasv1alpha4.MigrationStatus{
+				PlannedAt:  plannedAt,
+				ExecutedAt: executedAt,
+			},
+		}
+		SetOwnerLabels(m, "Table", table, specSHA)
+		return m
+	}
+
+	tests := []struct {
+		name       string
+		migrations []*schemasv1alpha4.Migration
+		specSHA    string
+		want       string
+	}{
+		{
+			name:    "no migrations",
+			specSHA: "sha1",
+			want:    "",
+		},
+		{
+			name: "pending migration for spec",
+			migrations: []*schemasv1alpha4.Migration{
+				migration("m1", "sha1", 1, 0),
+				migration("m2", "sha2", 2, 0),
+			},
+			specSHA: "sha1",
+			want:    "m1",
+		},
+		{
+			name: "latest executed migration is for spec",
+			migrations: []*schemasv1alpha4.Migration{
+				migration("m1", "sha1", 1, 1),
+				migration("m2", "sha2", 2, 2),
+			},
+			specSHA: "sha2",
+			want:    "m2",
+		},
+		{
+			name: "spec was reverted to an earlier executed spec",
+			migrations: []*schemasv1alpha4.Migration{
+				migration("m1", "sha1", 1, 1),
+				migration("m2", "sha2", 2, 2),
+			},
+			specSHA: "sha1",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, m := range tt.migrations {
+				builder = builder.WithObjects(m)
+			}
+			c := builder.Build()
+
+			got, err := FindMigrationForSpec(ctx, c, "Table", table, tt.specSHA)
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, got)
+			} else {
+				require.NotNil(t, got)
+				assert.Equal(t, tt.want, got.Name)
+			}
+		})
+	}
+}
@@ -8,13 +8,19 @@ import (
 	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database"
+	databasetypes "github.com/schemahero/schemahero/pkg/database/types"
 	"github.com/schemahero/schemahero/pkg/logger"
+	"github.com/schemahero/schemahero/pkg/metrics"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// blockedMigrationRequeueAfter is how often a blocked migration is checked to see if the table now allows it
+const blockedMigrationRequeueAfter = time.Minute
+
 func (r *ReconcileMigration) reconcileMigration(ctx context.Context, migration *schemasv1alpha4.Migration) (reconcile.Result, error) {
 	logger.Debug("checking migration",
 		zap.String("name", migration.Name),
@@ -32,6 +38,57 @@ func (r *ReconcileMigration) reconcileMigration(ctx context.Context, migration *
 		return reconcile.Result{}, errors.Wrapf(err, "failed to get database from migration %s", migration.Name)
 	}
 
+	// migrations that can lose data are only executed when the table allows it
+	blockedReason, err := r.getMigrationBlockedReason(ctx, migration)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to check if migration %s is blocked", migration.Name)
+	}
+	if migration.Status.BlockedReason != blockedReason {
+		migration.Status.BlockedReason = blockedReason
+		if err := r.Update(ctx, migration); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to update migration blocked reason")
+		}
+		if blockedReason != "" {
+			recordMigrationEvent(r.recorder, migration, corev1.EventTypeWarning, EventReasonMigrationBlocked, blockedReason)
+		}
+	}
+	if blockedReason != "" {
+		logger.Debug("requeuing blocked migration",
+			zap.String("name", migration.Name),
+			zap.String("reason", blockedReason))
+
+		// the annotation that allows the migration is on the table, so check again later
+		return reconcile.Result{
+			Requeue:      true,
+			RequeueAfter: blockedMigrationRequeueAfter,
+		}, nil
+	}
+
+	// approved migrations are only executed after their requested time and while a maintenance window on the database is open
+	now := time.Now()
+	executeAt, err := getScheduledExecutionTime(migration, databaseInstance, now)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to get scheduled execution time for migration %s", migration.Name)
+	}
+	if executeAt.After(now) {
+		logger.Debug("requeuing migration until its scheduled execution time",
+			zap.String("name", migration.Name),
+			zap.String("database", databaseInstance.Name),
+			zap.Time("scheduledAt", executeAt))
+
+		if migration.Status.ScheduledAt != executeAt.Unix() {
+			migration.Status.ScheduledAt = executeAt.Unix()
+			if err := r.Update(ctx, migration); err != nil {
+				return reconcile.Result{}, errors.Wrap(err, "failed to update migration schedule")
+			}
+		}
+
+		return reconcile.Result{
+			Requeue:      true,
+			RequeueAfter: executeAt.Sub(now),
+		}, nil
+	}
+
 	driver, connectionURI, err := databaseInstance.GetConnection(ctx)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to get connection details for database")
@@ -42,11 +99,24 @@ func (r *ReconcileMigration) reconcileMigration(ctx context.Context, migration *
 		URI:    connectionURI,
 	}
 
+	if databaseInstance.Spec.MigrationLedger {
+		db.LedgerEntry = &databasetypes.LedgerEntry{
+			MigrationID: migration.Name,
+			TableName:   getNameInDatabase(ctx, migration),
+			SpecSHA:     migration.Annotations[schemasv1alpha4.MigrationSpecSHAAnnotation],
+		}
+	}
+
 	statements := db.GetStatementsFromDDL(migration.Spec.GeneratedDDL)
 
-	if err := db.ApplySync(statements); err != nil {
+	startedAt := time.Now()
+	err = db.ApplySync(statements)
+	metrics.ObserveMigrationExecution(databaseInstance.Name, driver, time.Since(startedAt), err)
+	if err != nil {
+		recordMigrationEvent(r.recorder, migration, corev1.EventTypeWarning, EventReasonMigrationFailed, err.Error())
 		return reconcile.Result{}, errors.Wrap(err, "failed to apply statements")
 	}
+	r.recorder.Eventf(migration, corev1.EventTypeNormal, EventReasonMigrationExecuted, "Executed %d statements in database %s", len(statements), databaseInstance.Name)
 
 	// update the status to applied
 	migration.Status.ExecutedAt = time.Now().Unix()
@@ -77,6 +147,23 @@ func (r *ReconcileMigration) reconcileMigration(ctx context.Context, migration *
 	return reconcile.Result{}, nil
 }
 
+// getMigrationBlockedReason returns why the migration can't be executed, or an empty string when it can.
+// Only migrations planned for tables can be blocked
+func (r *ReconcileMigration) getMigrationBlockedReason(ctx context.Context, migration *schemasv1alpha4.Migration) (string, error) {
+	if ownerKind := migration.Labels[schemasv1alpha4.MigrationOwnerKindLabel]; ownerKind == "View" || ownerKind == "Database" {
+		return "", nil
+	}
+
+	table, err := TableFromMigration(ctx, migration)
+	if kuberneteserrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrap(err, "failed to get table")
+	}
+
+	return getBlockedReason(migration, table), nil
+}
+
 func shouldApplyMigration(migration *schemasv1alpha4.Migration) bool {
 	if migration.Status.ApprovedAt > 0 && migration.Status.ExecutedAt == 0 {
 		return true
@@ -84,7 +171,33 @@ func shouldApplyMigration(migration *schemasv1alpha4.Migration) bool {
 	return false
 }
 
+// getScheduledExecutionTime returns the earliest time, at or after now, that an approved migration can be executed
+func getScheduledExecutionTime(migration *schemasv1alpha4.Migration, databaseInstance *databasesv1alpha4.Database, now time.Time) (time.Time, error) {
+	earliest := now
+	if migration.Status.ExecuteAfter > 0 {
+		executeAfter := time.Unix(migration.Status.ExecuteAfter, 0)
+		if executeAfter.After(now) {
+			earliest = executeAfter
+		}
+	}
+
+	executeAt, err := databaseInstance.NextMaintenanceWindow(earliest)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "failed to get maintenance window for database %s", databaseInstance.Name)
+	}
+
+	return executeAt, nil
+}
+
 func getDatabaseFromMigration(ctx context.Context, migration *schemasv1alpha4.Migration) (*databasesv1alpha4.Database, error) {
+	if migration.Labels[schemasv1alpha4.MigrationOwnerKindLabel] == "Database" {
+		database, err := DatabaseFromMigration(ctx, migration)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get database")
+		}
+		return database, nil
+	}
+
 	table, err := TableFromMigration(ctx, migration)
 	if err != nil {
 		if !kuberneteserrors.IsNotFound(err) {
@@ -108,3 +221,22 @@ func getDatabaseFromMigration(ctx context.Context, migration *schemasv1alpha4.Mi
 	}
 	return database, nil
 }
+
+// getNameInDatabase returns the name of the table or view that the migration was planned for, as it's named in
+// the database. The name of the kubernetes object is returned when the table or view can't be found, and migrations
+// planned for a database don't have a name
+func getNameInDatabase(ctx context.Context, migration *schemasv1alpha4.Migration) string {
+	if migration.Labels[schemasv1alpha4.MigrationOwnerKindLabel] == "Database" {
+		return ""
+	}
+
+	if table, err := TableFromMigration(ctx, migration); err == nil {
+		return table.Spec.Name
+	}
+
+	if view, err := ViewFromMigration(ctx, migration); err == nil {
+		return view.Spec.Name
+	}
+
+	return migration.Spec.TableName
+}
@@ -66,6 +66,21 @@ func Test_getDatabaseFromMigration(t *testing.T) {
 			},
 			want: db,
 		},
+		{
+			name: "db from database migration without a table name",
+			migration: &schemasv1alpha4.Migration{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						schemasv1alpha4.MigrationOwnerKindLabel: "Database",
+					},
+				},
+				Spec: schemasv1alpha4.MigrationSpec{
+					DatabaseName:   "testdb",
+					TableNamespace: "namespace1",
+				},
+			},
+			want: db,
+		},
 		{
 			name: "unknown db",
 			migration: &schemasv1alpha4.Migration{
@@ -134,3 +149,67 @@ func Test_shouldApplyMigration(t *testing.T) {
 		})
 	}
 }
+
+func Test_getScheduledExecutionTime(t *testing.T) {
+	now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		migration *schemasv1alpha4.Migration
+		database  *databasesv1alpha4.Database
+		want      time.Time
+	}{
+		{
+			name:      "no schedule",
+			migration: &schemasv1alpha4.Migration{},
+			database:  &databasesv1alpha4.Database{},
+			want:      now,
+		},
+		{
+			name: "execute after is in the past",
+			migration: &schemasv1alpha4.Migration{
+				Status: schemasv1alpha4.MigrationStatus{
+					ExecuteAfter: now.Add(-time.Hour).Unix(),
+				},
+			},
+			database: &databasesv1alpha4.Database{},
+			want:     now,
+		},
+		{
+			name: "execute after is in the future",
+			migration: &schemasv1alpha4.Migration{
+				Status: schemasv1alpha4.MigrationStatus{
+					ExecuteAfter: now.Add(14 * time.Hour).Unix(),
+				},
+			},
+			database: &databasesv1alpha4.Database{},
+			want:     now.Add(14 * time.Hour),
+		},
+		{
+			name: "execute after is outside of the maintenance window",
+			migration: &schemasv1alpha4.Migration{
+				Status: schemasv1alpha4.MigrationStatus{
+					ExecuteAfter: now.Add(time.Hour).Unix(),
+				},
+			},
+			database: &databasesv1alpha4.Database{
+				Spec: databasesv1alpha4.DatabaseSpec{
+					MaintenanceWindows: []databasesv1alpha4.MaintenanceWindow{
+						{
+							Start: "02:00",
+							End:   "04:00",
+						},
+					},
+				},
+			},
+			want: time.Date(2026, 11, 2, 2, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getScheduledExecutionTime(tt.migration, tt.database, now)
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "expected %s, got %s", tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package migration
+
+import (
+	"fmt"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	databasetypes "github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// SetMigrationRisk classifies the planned statements and records the risk of executing them on the migration
+func SetMigrationRisk(migration *schemasv1alpha4.Migration, statements []string) {
+	classified := databasetypes.ClassifyStatements(statements)
+
+	migration.Spec.Risk = string(databasetypes.HighestRisk(classified))
+	migration.Spec.RiskyStatements = nil
+	for _, c := range classified {
+		if c.Risk == databasetypes.StatementRiskSafe {
+			continue
+		}
+
+		migration.Spec.RiskyStatements = append(migration.Spec.RiskyStatements, schemasv1alpha4.MigrationStatementRisk{
+			Statement: c.Statement,
+			Risk:      string(c.Risk),
+			Reason:    c.Reason,
+		})
+	}
+}
+
+// AllowsDestructiveChanges returns true when the table has opted in to executing migrations that can lose data
+func AllowsDestructiveChanges(table *schemasv1alpha4.Table) bool {
+	return table.Annotations[schemasv1alpha4.AllowDestructiveChangesAnnotation] == "true"
+}
+
+// getBlockedReason returns why the migration can't be executed, or an empty string when it can. Migrations that
+// can lose data are blocked unless the table they were planned for allows destructive changes
+func getBlockedReason(migration *schemasv1alpha4.Migration, table *schemasv1alpha4.Table) string {
+	if migration.Spec.Risk != schemasv1alpha4.MigrationRiskDataLoss {
+		return ""
+	}
+
+	if AllowsDestructiveChanges(table) {
+		return ""
+	}
+
+	return fmt.Sprintf("Migration contains %d statement(s) that can lose data. Set the %s annotation on table %s to \"true\" to allow them",
+		countRiskyStatements(migration, schemasv1alpha4.MigrationRiskDataLoss), schemasv1alpha4.AllowDestructiveChangesAnnotation, migration.Spec.TableName)
+}
+
+func countRiskyStatements(migration *schemasv1alpha4.Migration, risk string) int {
+	count := 0
+	for _, s := range migration.Spec.RiskyStatements {
+		if s.Risk == risk {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,92 @@
+package migration
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_SetMigrationRisk(t *testing.T) {
+	migration := &schemasv1alpha4.Migration{}
+	SetMigrationRisk(migration, []string{
+		`alter table "users" add column "email" text`,
+		`alter table "users" drop column "name"`,
+		`create index "idx_users_email" on "users" ("email")`,
+	})
+
+	assert.Equal(t, schemasv1alpha4.MigrationRiskDataLoss, migration.Spec.Risk)
+	require.Len(t, migration.Spec.RiskyStatements, 2)
+	assert.Equal(t, schemasv1alpha4.MigrationRiskDataLoss, migration.Spec.RiskyStatements[0].Risk)
+	assert.Equal(t, `alter table "users" drop column "name"`, migration.Spec.RiskyStatements[0].Statement)
+	assert.Equal(t, schemasv1alpha4.MigrationRiskLocking, migration.Spec.RiskyStatements[1].Risk)
+
+	SetMigrationRisk(migration, []string{`alter table "users" add column "email" text`})
+	assert.Equal(t, schemasv1alpha4.MigrationRiskSafe, migration.Spec.Risk)
+	assert.Empty(t, migration.Spec.RiskyStatements)
+}
+
+func Test_getBlockedReason(t *testing.T) {
+	table := func(annotations map[string]string) *schemasv1alpha4.Table {
+		return &schemasv1alpha4.Table{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "users",
+				Annotations: annotations,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		risk        string
+		table       *schemasv1alpha4.Table
+		wantBlocked bool
+	}{
+		{
+			name:        "safe",
+			risk:        schemasv1alpha4.MigrationRiskSafe,
+			table:       table(nil),
+			wantBlocked: false,
+		},
+		{
+			name:        "locking",
+			risk:        schemasv1alpha4.MigrationRiskLocking,
+			table:       table(nil),
+			wantBlocked: false,
+		},
+		{
+			name:        "data loss without annotation",
+			risk:        schemasv1alpha4.MigrationRiskDataLoss,
+			table:       table(nil),
+			wantBlocked: true,
+		},
+		{
+			name:        "data loss with annotation set to false",
+			risk:        schemasv1alpha4.MigrationRiskDataLoss,
+			table:       table(map[string]string{schemasv1alpha4.AllowDestructiveChangesAnnotation: "false"}),
+			wantBlocked: true,
+		},
+		{
+			name:        "data loss with annotation",
+			risk:        schemasv1alpha4.MigrationRiskDataLoss,
+			table:       table(map[string]string{schemasv1alpha4.AllowDestructiveChangesAnnotation: "true"}),
+			wantBlocked: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migration := &schemasv1alpha4.Migration{
+				Spec: schemasv1alpha4.MigrationSpec{
+					TableName: "users",
+					Risk:      tt.risk,
+				},
+			}
+
+			reason := getBlockedReason(migration, tt.table)
+			assert.Equal(t, tt.wantBlocked, reason != "")
+		})
+	}
+}
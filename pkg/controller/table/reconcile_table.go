@@ -10,16 +10,19 @@ import (
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	databasesclientv1alpha4 "github.com/schemahero/schemahero/pkg/client/schemaheroclientset/typed/databases/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/config"
+	migrationcontroller "github.com/schemahero/schemahero/pkg/controller/migration"
 	"github.com/schemahero/schemahero/pkg/database"
 	"github.com/schemahero/schemahero/pkg/logger"
+	"github.com/schemahero/schemahero/pkg/metrics"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+var errDatabaseTypeMismatch = errors.New("unable to deploy table to connection of different type")
+
 // reconcileTable is called after filtering events that are not relevant to this
 // controller. this function is the main reconcile loop for the table type
 func (r *ReconcileTable) reconcileTable(ctx context.Context, instance *schemasv1alpha4.Table) (reconcile.Result, error) {
@@ -29,14 +32,34 @@ func (r *ReconcileTable) reconcileTable(ctx context.Context, instance *schemasv1
 		zap.String("database", instance.Spec.Database),
 		zap.String("lastPlannedTableSpecSHA", instance.Status.LastPlannedTableSpecSHA))
 
-	// early exit if the sha of the spec hasn't changed
+	status := instance.Status.DeepCopy()
+	status.ObservedGeneration = instance.Generation
+
+	result, err := r.reconcileTableSpec(ctx, instance, status)
+	reason := "ReconcileFailed"
+	if errors.Cause(err) == errDatabaseTypeMismatch {
+		reason = "DatabaseTypeMismatch"
+	}
+	migrationcontroller.SetErrorCondition(&status.Conditions, instance.Generation, reason, err)
+
+	if updateErr := r.updateStatus(ctx, instance, status); updateErr != nil {
+		if err != nil {
+			logger.Error(updateErr)
+			return result, err
+		}
+		return result, updateErr
+	}
+
+	return result, err
+}
+
+// reconcileTableSpec plans a migration for the current spec of the table, if one hasn't already
+// been planned, and records the state of that migration in the status
+func (r *ReconcileTable) reconcileTableSpec(ctx context.Context, instance *schemasv1alpha4.Table, status *schemasv1alpha4.TableStatus) (reconcile.Result, error) {
 	currentTableSpecSHA, err := instance.GetSHA()
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to get instance sha")
 	}
-	if instance.Status.LastPlannedTableSpecSHA == currentTableSpecSHA {
-		return reconcile.Result{}, nil
-	}
 
 	// get the full database spec from the api
 	database, err := r.getDatabaseInstance(ctx, instance.Namespace, instance.Spec.Database)
@@ -47,7 +70,7 @@ func (r *ReconcileTable) reconcileTable(ctx context.Context, instance *schemasv1
 	// the database object might not yet exist
 	// this can happen if the table was deployed at the same time or before the database object
 	if database == nil {
-		// TDOO add a status field with this state
+		migrationcontroller.SetDatabaseFoundCondition(&status.Conditions, instance.Generation, instance.Spec.Database, false)
 		logger.Debug("requeuing table reconcile request for 10 seconds because database instance was not present",
 			zap.String("database.name", instance.Spec.Database),
 			zap.String("database.namespace", instance.Namespace))
@@ -57,36 +80,58 @@ func (r *ReconcileTable) reconcileTable(ctx context.Context, instance *schemasv1
 			RequeueAfter: time.Second * 10,
 		}, nil
 	}
+	migrationcontroller.SetDatabaseFoundCondition(&status.Conditions, instance.Generation, instance.Spec.Database, true)
 
-	matchingType := checkDatabaseTypeMatches(&database.Spec.Connection, instance.Spec.Schema)
+	matchingType := CheckDatabaseTypeMatches(&database.Spec.Connection, instance.Spec.Schema)
 	if !matchingType {
-		// TODO add a status field with this state
-		return reconcile.Result{}, errors.New("unable to deploy table to connection of different type")
+		return reconcile.Result{}, errDatabaseTypeMismatch
 	}
 
-	// Look for a migration with for this table
-	tableSHA, err := instance.GetSHA()
+	// look for an already calculated migration for this spec of the table
+	migration, err := r.getMigrationSpec(ctx, instance, currentTableSpecSHA)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get table sha")
+		return reconcile.Result{}, errors.Wrap(err, "failed to get migration spec")
 	}
 
-	tableSHA = tableSHA[:7]
+	// when the spec was planned and there's no migration for it, planning found nothing to change
+	needsPlan := migration == nil && status.LastPlannedTableSpecSHA != currentTableSpecSHA
+	if migration != nil && isRecalculateRequested(instance, migration) {
+		needsPlan = true
+	}
 
-	// look for an already calculated migration spec for this table
-	migration, err := r.getMigrationSpec(instance.Namespace, tableSHA)
-	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get migration spec")
+	if needsPlan {
+		migration, err = r.plan(ctx, database, instance)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to plan")
+		}
 	}
 
+	status.LastPlannedTableSpecSHA = currentTableSpecSHA
+	status.CurrentMigration = ""
 	if migration != nil {
-		// a migration has already been queued. why are we reconciling?
-		// if it's not in the sha calculation, then it's not something
-		// we are about
-		return reconcile.Result{}, nil
+		status.CurrentMigration = migration.Name
 	}
+	migrationcontroller.SetMigrationConditions(&status.Conditions, instance.Generation, migration)
 
-	// at this point, we need to execute a plan
-	return r.plan(ctx, database, instance)
+	return reconcile.Result{}, nil
+}
+
+// updateStatus writes the status to the table when it has changed, and records events for the
+// conditions that changed
+func (r *ReconcileTable) updateStatus(ctx context.Context, instance *schemasv1alpha4.Table, status *schemasv1alpha4.TableStatus) error {
+	if equality.Semantic.DeepEqual(instance.Status, *status) {
+		return nil
+	}
+
+	previousConditions := instance.Status.Conditions
+	instance.Status = *status
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return errors.Wrap(err, "failed to update table status")
+	}
+
+	migrationcontroller.RecordConditionEvents(r.recorder, instance, previousConditions, status.Conditions)
+
+	return nil
 }
 
 func (r *ReconcileTable) getInstance(request reconcile.Request) (*schemasv1alpha4.Table, error) {
@@ -100,14 +145,28 @@ func (r *ReconcileTable) getInstance(request reconcile.Request) (*schemasv1alpha
 }
 
 // getMigrationSpec will find a migration spec for this exact table object
-func (r *ReconcileTable) getMigrationSpec(namespace string, tableSHA string) (*schemasv1alpha4.Migration, error) {
+func (r *ReconcileTable) getMigrationSpec(ctx context.Context, instance *schemasv1alpha4.Table, tableSHA string) (*schemasv1alpha4.Migration, error) {
 	logger.Debug("getting migration spec",
-		zap.String("namespace", namespace),
+		zap.String("namespace", instance.Namespace),
+		zap.String("name", instance.Name),
 		zap.String("tableSHA", tableSHA))
 
-	// TODO
+	return migrationcontroller.FindMigrationForSpec(ctx, r.Client, "Table", instance, tableSHA)
+}
+
+// isRecalculateRequested returns true when the table was annotated to recalculate a pending
+// migration after that migration was planned
+func isRecalculateRequested(instance *schemasv1alpha4.Table, migration *schemasv1alpha4.Migration) bool {
+	if migrationcontroller.IsComplete(migration) {
+		return false
+	}
+
+	recalculatedAt, err := time.Parse(time.RFC3339, instance.Annotations["recalculatedAt"])
+	if err != nil {
+		return false
+	}
 
-	return nil, nil
+	return recalculatedAt.Unix() >= migration.Status.PlannedAt
 }
 
 func (r *ReconcileTable) getDatabaseInstance(ctx context.Context, namespace string, name string) (*databasesv1alpha4.Database, error) {
@@ -138,7 +197,8 @@ func (r *ReconcileTable) getDatabaseInstance(ctx context.Context, namespace stri
 	return database, nil
 }
 
-func checkDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection, tableSchema *schemasv1alpha4.TableSchema) bool {
+// CheckDatabaseTypeMatches returns true when the table schema has a block for the type of the database connection
+func CheckDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection, tableSchema *schemasv1alpha4.TableSchema) bool {
 	if connection.Postgres != nil {
 		return tableSchema.Postgres != nil
 	} else if connection.Mysql != nil {
@@ -159,15 +219,15 @@ func checkDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection,
 }
 
 // plan will connect to the database and generate a migration spec, deploying the
-// migration object
-func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1alpha4.Database, tableInstance *schemasv1alpha4.Table) (reconcile.Result, error) {
+// migration object. A nil migration is returned when there are no changes to make
+func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1alpha4.Database, tableInstance *schemasv1alpha4.Table) (*schemasv1alpha4.Migration, error) {
 	logger.Debug("planning migration",
 		zap.String("databaseName", databaseInstance.Name),
 		zap.String("tableName", tableInstance.Name))
 
 	driver, connectionURI, err := databaseInstance.GetConnection(ctx)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get connection details for database")
+		return nil, errors.Wrap(err, "failed to get connection details for database")
 	}
 
 	db := database.Database{
@@ -179,7 +239,8 @@ func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1
 	// plan the schema
 	schemaStatements, err := db.PlanSyncTableSpec(&tableInstance.Spec)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to plan sync")
+		metrics.ObservePlan("Table", databaseInstance.Name, driver, 0, err)
+		return nil, errors.Wrap(err, "failed to plan sync")
 	}
 
 	// plan the seed data
@@ -187,25 +248,27 @@ func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1
 	if databaseInstance.Spec.DeploySeedData {
 		stmts, err := db.PlanSyncSeedData(&tableInstance.Spec)
 		if err != nil {
-			return reconcile.Result{}, errors.Wrap(err, "failed to plan seed")
+			metrics.ObservePlan("Table", databaseInstance.Name, driver, 0, err)
+			return nil, errors.Wrap(err, "failed to plan seed")
 		}
 
 		seedStatements = append(seedStatements, stmts...)
 	}
 
+	metrics.ObservePlan("Table", databaseInstance.Name, driver, len(schemaStatements)+len(seedStatements), nil)
+
 	if len(schemaStatements) == 0 && len(seedStatements) == 0 {
 		logger.Debug("no statements generated for migration",
 			zap.String("databaseName", databaseInstance.Name),
 			zap.String("tableName", tableInstance.Name))
 
-		return reconcile.Result{}, nil
+		return nil, nil
 	}
 
 	tableSHA, err := tableInstance.GetSHA()
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get sha of table")
+		return nil, errors.Wrap(err, "failed to get sha of table")
 	}
-	tableSHA = tableSHA[:7]
 
 	allGeneratedStatements := append(schemaStatements, seedStatements...)
 	generatedDDL := strings.Join(allGeneratedStatements, ";\n")
@@ -216,7 +279,6 @@ func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1
 			Kind:       "Migration",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      tableSHA,
 			Namespace: tableInstance.Namespace,
 		},
 		Spec: schemasv1alpha4.MigrationSpec{
@@ -231,36 +293,17 @@ func (r *ReconcileTable) plan(ctx context.Context, databaseInstance *databasesv1
 		},
 	}
 
+	migrationcontroller.SetMigrationRisk(&migration, allGeneratedStatements)
+
 	if databaseInstance.Spec.ImmediateDeploy {
 		migration.Status.ApprovedAt = time.Now().Unix()
+		migration.Status.ApprovedBy = "immediateDeploy"
 		migration.Status.Phase = schemasv1alpha4.Planned
 	}
 
-	var existingMigration schemasv1alpha4.Migration
-	err = r.Get(ctx, types.NamespacedName{
-		Name:      migration.Name,
-		Namespace: migration.Namespace,
-	}, &existingMigration)
-
-	if kuberneteserrors.IsNotFound(err) {
-		// create it
-		if err := controllerutil.SetControllerReference(tableInstance, &migration, r.scheme); err != nil {
-			return reconcile.Result{}, errors.Wrap(err, "failed to set owner on miration")
-		}
-
-		if err := r.Create(ctx, &migration); err != nil {
-			return reconcile.Result{}, errors.Wrap(err, "failed to create migration resource")
-		}
-	} else if err == nil {
-		// update it
-		existingMigration.Status = migration.Status
-		existingMigration.Spec = migration.Spec
-		if err = r.Update(ctx, &existingMigration); err != nil {
-			return reconcile.Result{}, errors.Wrap(err, "failed to update migration resource")
-		}
-	} else {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get existing migration")
+	if err := migrationcontroller.SavePlannedMigration(ctx, r.Client, r.scheme, "Table", tableInstance, tableSHA, &migration); err != nil {
+		return nil, errors.Wrap(err, "failed to save migration")
 	}
 
-	return reconcile.Result{}, nil
+	return &migration, nil
 }
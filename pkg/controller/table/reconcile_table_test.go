@@ -8,7 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_checkDatabaseTypeMatches(t *testing.T) {
+func Test_CheckDatabaseTypeMatches(t *testing.T) {
 	tests := []struct {
 		name        string
 		connection  databasesv1alpha4.DatabaseConnection
@@ -101,7 +101,7 @@ func Test_checkDatabaseTypeMatches(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actual := checkDatabaseTypeMatches(&test.connection, &test.tableSchema)
+			actual := CheckDatabaseTypeMatches(&test.connection, &test.tableSchema)
 			assert.Equal(t, test.expect, actual)
 		})
 	}
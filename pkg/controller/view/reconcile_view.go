@@ -10,16 +10,19 @@ import (
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	databasesclientv1alpha4 "github.com/schemahero/schemahero/pkg/client/schemaheroclientset/typed/databases/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/config"
+	migrationcontroller "github.com/schemahero/schemahero/pkg/controller/migration"
 	"github.com/schemahero/schemahero/pkg/database"
 	"github.com/schemahero/schemahero/pkg/logger"
+	"github.com/schemahero/schemahero/pkg/metrics"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+var errDatabaseTypeMismatch = errors.New("unable to deploy view to connection of different type")
+
 // reconcileView is called after filtering events that are not relevant to this
 // controller. this function is the main reconcile loop for the view type
 func (r *ReconcileView) reconcileView(ctx context.Context, instance *schemasv1alpha4.View) (reconcile.Result, error) {
@@ -29,14 +32,34 @@ func (r *ReconcileView) reconcileView(ctx context.Context, instance *schemasv1al
 		zap.String("database", instance.Spec.Database),
 		zap.String("lastPlannedViewSpecSHA", instance.Status.LastPlannedViewSpecSHA))
 
-	// early exit if the sha of the spec hasn't changed
-	currentTableSpecSHA, err := instance.GetSHA()
+	status := instance.Status.DeepCopy()
+	status.ObservedGeneration = instance.Generation
+
+	result, err := r.reconcileViewSpec(ctx, instance, status)
+	reason := "ReconcileFailed"
+	if errors.Cause(err) == errDatabaseTypeMismatch {
+		reason = "DatabaseTypeMismatch"
+	}
+	migrationcontroller.SetErrorCondition(&status.Conditions, instance.Generation, reason, err)
+
+	if updateErr := r.updateStatus(ctx, instance, status); updateErr != nil {
+		if err != nil {
+			logger.Error(updateErr)
+			return result, err
+		}
+		return result, updateErr
+	}
+
+	return result, err
+}
+
+// reconcileViewSpec plans a migration for the current spec of the view, if one hasn't already
+// been planned, and records the state of that migration in the status
+func (r *ReconcileView) reconcileViewSpec(ctx context.Context, instance *schemasv1alpha4.View, status *schemasv1alpha4.ViewStatus) (reconcile.Result, error) {
+	currentViewSpecSHA, err := instance.GetSHA()
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to get instance sha")
 	}
-	if instance.Status.LastPlannedViewSpecSHA == currentTableSpecSHA {
-		return reconcile.Result{}, nil
-	}
 
 	// get the full database spec from the api
 	database, err := r.getDatabaseInstance(ctx, instance.Namespace, instance.Spec.Database)
@@ -45,9 +68,9 @@ func (r *ReconcileView) reconcileView(ctx context.Context, instance *schemasv1al
 	}
 
 	// the database object might not yet exist
-	// this can happen if the table was deployed at the same time or before the database object
+	// this can happen if the view was deployed at the same time or before the database object
 	if database == nil {
-		// TDOO add a status field with this state
+		migrationcontroller.SetDatabaseFoundCondition(&status.Conditions, instance.Generation, instance.Spec.Database, false)
 		logger.Debug("requeuing view reconcile request for 10 seconds because database instance was not present",
 			zap.String("database.name", instance.Spec.Database),
 			zap.String("database.namespace", instance.Namespace))
@@ -57,36 +80,53 @@ func (r *ReconcileView) reconcileView(ctx context.Context, instance *schemasv1al
 			RequeueAfter: time.Second * 10,
 		}, nil
 	}
+	migrationcontroller.SetDatabaseFoundCondition(&status.Conditions, instance.Generation, instance.Spec.Database, true)
 
-	matchingType := checkDatabaseTypeMatches(&database.Spec.Connection, instance.Spec.Schema)
+	matchingType := CheckDatabaseTypeMatches(&database.Spec.Connection, instance.Spec.Schema)
 	if !matchingType {
-		// TODO add a status field with this state
-		return reconcile.Result{}, errors.New("unable to deploy table to connection of different type")
+		return reconcile.Result{}, errDatabaseTypeMismatch
 	}
 
-	// Look for a migration with for this view
-	viewSHA, err := instance.GetSHA()
+	// look for an already calculated migration for this spec of the view
+	migration, err := r.getMigrationSpec(ctx, instance, currentViewSpecSHA)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get view sha")
+		return reconcile.Result{}, errors.Wrap(err, "failed to get migration spec")
 	}
 
-	viewSHA = viewSHA[:7]
-
-	// look for an already calculated migration spec for this view
-	migration, err := r.getMigrationSpec(instance.Namespace, viewSHA)
-	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get migration spec")
+	// when the spec was planned and there's no migration for it, planning found nothing to change
+	if migration == nil && status.LastPlannedViewSpecSHA != currentViewSpecSHA {
+		migration, err = r.plan(ctx, database, instance)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to plan")
+		}
 	}
 
+	status.LastPlannedViewSpecSHA = currentViewSpecSHA
+	status.CurrentMigration = ""
 	if migration != nil {
-		// a migration has already been queued. why are we reconciling?
-		// if it's not in the sha calculation, then it's not something
-		// we are about
-		return reconcile.Result{}, nil
+		status.CurrentMigration = migration.Name
 	}
+	migrationcontroller.SetMigrationConditions(&status.Conditions, instance.Generation, migration)
 
-	// at this point, we need to execute a plan
-	return r.plan(ctx, database, instance)
+	return reconcile.Result{}, nil
+}
+
+// updateStatus writes the status to the view when it has changed, and records events for the
+// conditions that changed
+func (r *ReconcileView) updateStatus(ctx context.Context, instance *schemasv1alpha4.View, status *schemasv1alpha4.ViewStatus) error {
+	if equality.Semantic.DeepEqual(instance.Status, *status) {
+		return nil
+	}
+
+	previousConditions := instance.Status.Conditions
+	instance.Status = *status
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return errors.Wrap(err, "failed to update view status")
+	}
+
+	migrationcontroller.RecordConditionEvents(r.recorder, instance, previousConditions, status.Conditions)
+
+	return nil
 }
 
 func (r *ReconcileView) getInstance(request reconcile.Request) (*schemasv1alpha4.View, error) {
@@ -127,7 +167,8 @@ func (r *ReconcileView) getDatabaseInstance(ctx context.Context, namespace strin
 	return database, nil
 }
 
-func checkDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection, viewSchema *schemasv1alpha4.ViewSchema) bool {
+// CheckDatabaseTypeMatches returns true when the view schema has a block for the type of the database connection
+func CheckDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection, viewSchema *schemasv1alpha4.ViewSchema) bool {
 	if connection.Postgres != nil {
 		return viewSchema.Postgres != nil
 	} else if connection.Mysql != nil {
@@ -146,26 +187,25 @@ func checkDatabaseTypeMatches(connection *databasesv1alpha4.DatabaseConnection,
 }
 
 // getMigrationSpec will find a migration spec for this exact view object
-func (r *ReconcileView) getMigrationSpec(namespace string, viewSHA string) (*schemasv1alpha4.Migration, error) {
+func (r *ReconcileView) getMigrationSpec(ctx context.Context, instance *schemasv1alpha4.View, viewSHA string) (*schemasv1alpha4.Migration, error) {
 	logger.Debug("getting migration spec",
-		zap.String("namespace", namespace),
+		zap.String("namespace", instance.Namespace),
+		zap.String("name", instance.Name),
 		zap.String("viewSHA", viewSHA))
 
-	// TODO
-
-	return nil, nil
+	return migrationcontroller.FindMigrationForSpec(ctx, r.Client, "View", instance, viewSHA)
 }
 
 // plan will connect to the database and generate a migration spec, deploying the
-// migration object
-func (r *ReconcileView) plan(ctx context.Context, databaseInstance *databasesv1alpha4.Database, viewInstance *schemasv1alpha4.View) (reconcile.Result, error) {
+// migration object. A nil migration is returned when there are no changes to make
+func (r *ReconcileView) plan(ctx context.Context, databaseInstance *databasesv1alpha4.Database, viewInstance *schemasv1alpha4.View) (*schemasv1alpha4.Migration, error) {
 	logger.Debug("planning migration",
 		zap.String("databaseName", databaseInstance.Name),
 		zap.String("viewName", viewInstance.Name))
 
 	driver, connectionURI, err := databaseInstance.GetConnection(ctx)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get connection details for database")
+		return nil, errors.Wrap(err, "failed to get connection details for database")
 	}
 
 	db := database.Database{
@@ -175,8 +215,9 @@ func (r *ReconcileView) plan(ctx context.Context, databaseInstance *databasesv1a
 	}
 
 	schemaStatements, err := db.PlanSyncViewSpec(&viewInstance.Spec)
+	metrics.ObservePlan("View", databaseInstance.Name, driver, len(schemaStatements), err)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to plan migration")
+		return nil, errors.Wrap(err, "failed to plan migration")
 	}
 
 	if len(schemaStatements) == 0 {
@@ -184,14 +225,13 @@ func (r *ReconcileView) plan(ctx context.Context, databaseInstance *databasesv1a
 			zap.String("databaseName", databaseInstance.Name),
 			zap.String("viewName", viewInstance.Name))
 
-		return reconcile.Result{}, nil
+		return nil, nil
 	}
 
 	viewSHA, err := viewInstance.GetSHA()
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get sha of view")
+		return nil, errors.Wrap(err, "failed to get sha of view")
 	}
-	viewSHA = viewSHA[:7]
 
 	generatedDDL := strings.Join(schemaStatements, ";\n")
 
@@ -201,7 +241,6 @@ func (r *ReconcileView) plan(ctx context.Context, databaseInstance *databasesv1a
 			Kind:       "Migration",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      viewSHA,
 			Namespace: viewInstance.Namespace,
 		},
 		Spec: schemasv1alpha4.MigrationSpec{
@@ -216,36 +255,17 @@ func (r *ReconcileView) plan(ctx context.Context, databaseInstance *databasesv1a
 		},
 	}
 
+	migrationcontroller.SetMigrationRisk(&migration, schemaStatements)
+
 	if databaseInstance.Spec.ImmediateDeploy {
 		migration.Status.ApprovedAt = time.Now().Unix()
+		migration.Status.ApprovedBy = "immediateDeploy"
 		migration.Status.Phase = schemasv1alpha4.Planned
 	}
 
-	var existingMigration schemasv1alpha4.Migration
-	err = r.Get(ctx, types.NamespacedName{
-		Name:      migration.Name,
-		Namespace: migration.Namespace,
-	}, &existingMigration)
-
-	if kuberneteserrors.IsNotFound(err) {
-		// create it
-		if err := controllerutil.SetControllerReference(viewInstance, &migration, r.scheme); err != nil {
-			return reconcile.Result{}, errors.Wrap(err, "failed to set owner on miration")
-		}
-
-		if err := r.Create(ctx, &migration); err != nil {
-			return reconcile.Result{}, errors.Wrap(err, "failed to create migration resource")
-		}
-	} else if err == nil {
-		// update it
-		existingMigration.Status = migration.Status
-		existingMigration.Spec = migration.Spec
-		if err = r.Update(ctx, &existingMigration); err != nil {
-			return reconcile.Result{}, errors.Wrap(err, "failed to update migration resource")
-		}
-	} else {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get existing migration")
+	if err := migrationcontroller.SavePlannedMigration(ctx, r.Client, r.scheme, "View", viewInstance, viewSHA, &migration); err != nil {
+		return nil, errors.Wrap(err, "failed to save migration")
 	}
 
-	return reconcile.Result{}, nil
+	return &migration, nil
 }
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -46,6 +47,7 @@ func newReconciler(databaseNames []string, mgr manager.Manager) reconcile.Reconc
 	return &ReconcileView{
 		Client:        mgr.GetClient(),
 		scheme:        mgr.GetScheme(),
+		recorder:      mgr.GetEventRecorderFor("schemahero-view-controller"),
 		databaseNames: databaseNames,
 	}
 }
@@ -66,6 +68,18 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return errors.Wrap(err, "failed to start watch on views")
 	}
 
+	// Watch for changes to the migrations planned for views, to keep the view status up to date
+	// as migrations are approved and executed
+	err = c.Watch(&source.Kind{
+		Type: &schemasv1alpha4.Migration{},
+	}, &handler.EnqueueRequestForOwner{
+		OwnerType:    &schemasv1alpha4.View{},
+		IsController: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to start watch on migrations")
+	}
+
 	// Add an informer on pods, which are created to deploy schemas. the informer will
 	// update the status of the view custom resource and do a little garbage collection
 	generatedClient := kubernetes.NewForConfigOrDie(mgr.GetConfig())
@@ -86,6 +100,7 @@ var _ reconcile.Reconciler = &ReconcileView{}
 type ReconcileView struct {
 	client.Client
 	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
 	databaseNames []string
 }
 
@@ -96,6 +111,7 @@ type ReconcileView struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=schemas.schemahero.io,resources=views,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=schemas.schemahero.io,resources=views/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 func (r *ReconcileView) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	// This reconcile loop will be called for all View objects and all pods
 	// because of the informer that we have set up
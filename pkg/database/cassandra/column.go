@@ -3,6 +3,7 @@ package cassandra
 import (
 	"fmt"
 
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
@@ -51,3 +52,33 @@ func InsertColumnStatement(keyspace string, tableName string, desiredColumn *sch
 
 	return statement, nil
 }
+
+// RenameColumnStatement returns the statement to rename an existing column to its desired name. Cassandra can
+// only rename columns that are part of the primary key
+func RenameColumnStatement(keyspace string, tableName string, primaryKey [][]string, existingName string, desiredName string) (string, error) {
+	isPrimaryKey := false
+	for _, partition := range primaryKey {
+		for _, column := range partition {
+			if column == desiredName || column == existingName {
+				isPrimaryKey = true
+			}
+		}
+	}
+	if !isPrimaryKey {
+		return "", errors.Errorf("cannot rename column %s to %s: cassandra can only rename primary key columns", existingName, desiredName)
+	}
+
+	return fmt.Sprintf(`alter table "%s.%s" rename %s to %s`, keyspace, tableName, existingName, desiredName), nil
+}
+
+// columnsRenamedFrom returns the previous name of each desired column that was renamed, keyed by the desired name
+func columnsRenamedFrom(desiredColumns []*schemasv1alpha4.CassandraColumn) map[string]string {
+	renamedFrom := map[string]string{}
+	for _, desiredColumn := range desiredColumns {
+		if desiredColumn.RenamedFrom != "" {
+			renamedFrom[desiredColumn.Name] = desiredColumn.RenamedFrom
+		}
+	}
+
+	return renamedFrom
+}
@@ -56,3 +56,43 @@ func Test_cassandraColumnAsInsert(t *testing.T) {
 		})
 	}
 }
+
+func Test_RenameColumnStatement(t *testing.T) {
+	tests := []struct {
+		name              string
+		primaryKey        [][]string
+		existingName      string
+		desiredName       string
+		expectedStatement string
+		expectError       bool
+	}{
+		{
+			name:              "primary key column",
+			primaryKey:        [][]string{{"id"}, {"created_at"}},
+			existingName:      "created",
+			desiredName:       "created_at",
+			expectedStatement: `alter table "k.t" rename created to created_at`,
+		},
+		{
+			name:         "regular column",
+			primaryKey:   [][]string{{"id"}},
+			existingName: "email_address",
+			desiredName:  "email",
+			expectError:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statement, err := RenameColumnStatement("k", "t", test.primaryKey, test.existingName, test.desiredName)
+			if test.expectError {
+				req.Error(err)
+				return
+			}
+			req.NoError(err)
+			assert.Equal(t, test.expectedStatement, statement)
+		})
+	}
+}
@@ -51,7 +51,7 @@ func PlanCassandraView(hosts []string, username string, password string, keyspac
 	return nil, errors.New("not implemented")
 }
 
-func PlanCassandraTable(hosts []string, username string, password string, keyspace string, tableName string, cassandraTableSchema *schemasv1alpha4.CassandraTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+func PlanCassandraTable(hosts []string, username string, password string, keyspace string, tableName string, renamedFrom string, cassandraTableSchema *schemasv1alpha4.CassandraTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
 	c, err := Connect(hosts, username, password, keyspace)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to cassandra")
@@ -66,6 +66,20 @@ func PlanCassandraTable(hosts []string, username string, password string, keyspa
 		return nil, errors.Wrap(err, "failed to scan")
 	}
 
+	// cassandra can't rename tables, so a renamed table that still has its old name can't be planned. Creating
+	// a new, empty table would leave the data behind in the old one
+	if tableExists == 0 && renamedFrom != "" && renamedFrom != tableName && !cassandraTableSchema.IsDeleted {
+		row := c.session.Query(query, keyspace, renamedFrom)
+		existingTableExists := 0
+		if err := row.Scan(&existingTableExists); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		if existingTableExists > 0 {
+			return nil, errors.Errorf("cannot rename table %s to %s: cassandra does not support renaming tables", renamedFrom, tableName)
+		}
+	}
+
 	if tableExists == 0 && cassandraTableSchema.IsDeleted {
 		return []string{}, nil
 	} else if tableExists > 0 && cassandraTableSchema.IsDeleted {
@@ -228,24 +242,41 @@ func buildColumnStatements(c *CassandraConnection, tableName string, cassandraTa
 keyspace_name = ? and table_name = ?`
 	scanner := c.session.Query(query, c.keyspace, tableName).Iter().Scanner()
 
-	alterAndDropStatements := []string{}
-	foundColumnNames := []string{}
-
+	existingColumns := []types.Column{}
 	for scanner.Next() {
-
 		var columnName, columnType string
 
 		if err := scanner.Scan(&columnName, &columnType); err != nil {
 			return nil, errors.Wrap(err, "failed to scan")
 		}
 
-		foundColumnNames = append(foundColumnNames, columnName)
-
-		existingColumn := types.Column{
+		existingColumns = append(existingColumns, types.Column{
 			Name:     columnName,
 			DataType: columnType,
+		})
+	}
+
+	existingColumnNames := []string{}
+	for _, existingColumn := range existingColumns {
+		existingColumnNames = append(existingColumnNames, existingColumn.Name)
+	}
+	renames := types.ColumnRenames(existingColumnNames, columnsRenamedFrom(cassandraTableSchema.Columns))
+
+	alterAndDropStatements := []string{}
+	foundColumnNames := []string{}
+	for _, existingColumn := range existingColumns {
+		// renamed columns are compared to the desired column by their new name
+		if newName, ok := renames[existingColumn.Name]; ok {
+			statement, err := RenameColumnStatement(c.keyspace, tableName, cassandraTableSchema.PrimaryKey, existingColumn.Name, newName)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to create rename column statement")
+			}
+			alterAndDropStatements = append(alterAndDropStatements, statement)
+			existingColumn.Name = newName
 		}
 
+		foundColumnNames = append(foundColumnNames, existingColumn.Name)
+
 		columnStatement, err := AlterColumnStatements(c.keyspace, tableName, cassandraTableSchema.Columns, &existingColumn)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create alter column statement")
@@ -0,0 +1,75 @@
+package cassandra
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// the ledger is keyed by a generated id because the migration id and table name are optional,
+// and cassandra doesn't allow empty values in a partition key
+func createLedgerTableStatement(keyspace string) string {
+	return fmt.Sprintf(`create table if not exists %s.%s (id timeuuid primary key, migration_id text, table_name text, spec_sha text, ddl_hash text, applied_at timestamp, duration_ms bigint)`, keyspace, types.LedgerTableName)
+}
+
+// RecordLedgerEntry writes the entry to the migration ledger table, creating the table if it doesn't exist
+func RecordLedgerEntry(hosts []string, username string, password string, keyspace string, entry types.LedgerEntry) error {
+	c, err := Connect(hosts, username, password, keyspace)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to cassandra")
+	}
+	defer c.Close()
+
+	if err := c.session.Query(createLedgerTableStatement(keyspace)).Exec(); err != nil {
+		return errors.Wrap(err, "failed to create ledger table")
+	}
+
+	query := fmt.Sprintf(`insert into %s.%s (id, migration_id, table_name, spec_sha, ddl_hash, applied_at, duration_ms) values (?, ?, ?, ?, ?, ?, ?)`, keyspace, types.LedgerTableName)
+	if err := c.session.Query(query, gocql.TimeUUID(), entry.MigrationID, entry.TableName, entry.SpecSHA, entry.DDLHash, entry.AppliedAt.UTC(), entry.Duration.Milliseconds()).Exec(); err != nil {
+		return errors.Wrap(err, "failed to insert ledger entry")
+	}
+
+	return nil
+}
+
+// LatestLedgerEntry returns the most recently applied ledger entry for the table, or nil if there is none
+func LatestLedgerEntry(hosts []string, username string, password string, keyspace string, tableName string) (*types.LedgerEntry, error) {
+	c, err := Connect(hosts, username, password, keyspace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to cassandra")
+	}
+	defer c.Close()
+
+	query := `select count(1) from system_schema.tables where keyspace_name=? and table_name = ?`
+	row := c.session.Query(query, keyspace, types.LedgerTableName)
+	ledgerExists := 0
+	if err := row.Scan(&ledgerExists); err != nil {
+		return nil, errors.Wrap(err, "failed to scan")
+	}
+	if ledgerExists == 0 {
+		return nil, nil
+	}
+
+	// the ledger is small, so filtering on the table name is preferred to maintaining an index
+	query = fmt.Sprintf(`select migration_id, table_name, spec_sha, ddl_hash, applied_at, duration_ms from %s.%s where table_name = ? allow filtering`, keyspace, types.LedgerTableName)
+	iter := c.session.Query(query, tableName).Iter()
+
+	var latest *types.LedgerEntry
+	entry := types.LedgerEntry{}
+	var durationMS int64
+	for iter.Scan(&entry.MigrationID, &entry.TableName, &entry.SpecSHA, &entry.DDLHash, &entry.AppliedAt, &durationMS) {
+		if latest == nil || entry.AppliedAt.After(latest.AppliedAt) {
+			entry.Duration = time.Duration(durationMS) * time.Millisecond
+			e := entry
+			latest = &e
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to read ledger")
+	}
+
+	return latest, nil
+}
@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/client/schemaheroclientset/scheme"
 	"github.com/schemahero/schemahero/pkg/database/cassandra"
@@ -33,6 +35,11 @@ type Database struct {
 	Password       string
 	Keyspace       string
 	DeploySeedData bool
+
+	// LedgerEntry, when set, is recorded in the migration ledger table in the database
+	// after ApplySync has applied the statements. The hash, time and duration are filled in. A failure to
+	// record it is logged, and doesn't fail ApplySync
+	LedgerEntry *types.LedgerEntry
 }
 
 func (d *Database) CreateFixturesSync() error {
@@ -298,19 +305,19 @@ func (d *Database) PlanSyncTableSpec(spec *schemasv1alpha4.TableSpec) ([]string,
 	}
 
 	if d.Driver == "postgres" {
-		return postgres.PlanPostgresTable(d.URI, spec.Name, spec.Schema.Postgres, seedData)
+		return postgres.PlanPostgresTable(d.URI, spec.Name, spec.RenamedFrom, spec.Schema.Postgres, seedData)
 	} else if d.Driver == "mysql" {
-		return mysql.PlanMysqlTable(d.URI, spec.Name, spec.Schema.Mysql, seedData)
+		return mysql.PlanMysqlTable(d.URI, spec.Name, spec.RenamedFrom, spec.Schema.Mysql, seedData)
 	} else if d.Driver == "cockroachdb" {
-		return postgres.PlanPostgresTable(d.URI, spec.Name, spec.Schema.CockroachDB, seedData)
+		return postgres.PlanPostgresTable(d.URI, spec.Name, spec.RenamedFrom, spec.Schema.CockroachDB, seedData)
 	} else if d.Driver == "cassandra" {
-		return cassandra.PlanCassandraTable(d.Hosts, d.Username, d.Password, d.Keyspace, spec.Name, spec.Schema.Cassandra, seedData)
+		return cassandra.PlanCassandraTable(d.Hosts, d.Username, d.Password, d.Keyspace, spec.Name, spec.RenamedFrom, spec.Schema.Cassandra, seedData)
 	} else if d.Driver == "sqlite" {
-		return sqlite.PlanSqliteTable(d.URI, spec.Name, spec.Schema.SQLite, seedData)
+		return sqlite.PlanSqliteTable(d.URI, spec.Name, spec.RenamedFrom, spec.Schema.SQLite, seedData)
 	} else if d.Driver == "rqlite" {
-		return rqlite.PlanRqliteTable(d.URI, spec.Name, spec.Schema.RQLite, seedData)
+		return rqlite.PlanRqliteTable(d.URI, spec.Name, spec.RenamedFrom, spec.Schema.RQLite, seedData)
 	} else if d.Driver == "timescaledb" {
-		return timescaledb.PlanTimescaleDBTable(d.URI, spec.Name, spec.Schema.TimescaleDB, seedData)
+		return timescaledb.PlanTimescaleDBTable(d.URI, spec.Name, spec.RenamedFrom, spec.Schema.TimescaleDB, seedData)
 	}
 
 	return nil, errors.Errorf("unknown database driver: %q", d.Driver)
@@ -373,7 +380,42 @@ func (d *Database) PlanSyncTypeSpec(spec *schemasv1alpha4.DataTypeSpec) ([]strin
 	return nil, errors.Errorf("planning types is not supported for driver %q", d.Driver)
 }
 
+// PlanSyncExtensions returns the statements to install and update the extensions in the database
+func (d *Database) PlanSyncExtensions(extensions []databasesv1alpha4.PostgresExtension) ([]string, error) {
+	if len(extensions) == 0 {
+		return []string{}, nil
+	}
+
+	if d.Driver == "postgres" || d.Driver == "timescaledb" {
+		return postgres.PlanPostgresExtensions(d.URI, extensions)
+	}
+
+	return nil, errors.Errorf("planning extensions is not supported for driver %q", d.Driver)
+}
+
 func (d *Database) ApplySync(statements []string) error {
+	startedAt := time.Now()
+	if err := d.applySync(statements); err != nil {
+		return err
+	}
+
+	if d.LedgerEntry == nil {
+		return nil
+	}
+
+	entry := *d.LedgerEntry
+	entry.DDLHash = types.DDLHash(statements)
+	entry.AppliedAt = startedAt
+	entry.Duration = time.Since(startedAt)
+	// the statements have been applied, and returning an error would apply them again
+	if err := d.RecordLedgerEntry(entry); err != nil {
+		logger.Warnf("applied migration, but failed to record ledger entry: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (d *Database) applySync(statements []string) error {
 	if d.Driver == "postgres" {
 		return postgres.DeployPostgresStatements(d.URI, statements)
 	} else if d.Driver == "mysql" {
@@ -393,6 +435,87 @@ func (d *Database) ApplySync(statements []string) error {
 	return errors.Errorf("unknown database driver: %q", d.Driver)
 }
 
+// CheckConnection connects to the database and returns the engine version that it reports.
+// The version is empty for engines that don't report one
+func (d *Database) CheckConnection() (string, error) {
+	switch d.Driver {
+	case "postgres", "cockroachdb", "timescaledb":
+		p, err := postgres.Connect(d.URI)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to connect to postgres")
+		}
+		defer p.Close()
+		return p.EngineVersion(), nil
+	case "mysql":
+		m, err := mysql.Connect(d.URI)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to connect to mysql")
+		}
+		defer m.Close()
+		return m.EngineVersion(), nil
+	case "cassandra":
+		c, err := cassandra.Connect(d.Hosts, d.Username, d.Password, d.Keyspace)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to connect to cassandra")
+		}
+		defer c.Close()
+		return "", nil
+	case "sqlite":
+		s, err := sqlite.Connect(d.URI)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to connect to sqlite")
+		}
+		defer s.Close()
+		return "", nil
+	case "rqlite":
+		r, err := rqlite.Connect(d.URI)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to connect to rqlite")
+		}
+		defer r.Close()
+		return r.EngineVersion(), nil
+	}
+
+	return "", errors.Errorf("unknown database driver: %q", d.Driver)
+}
+
+// RecordLedgerEntry writes the entry to the migration ledger table in the database
+func (d *Database) RecordLedgerEntry(entry types.LedgerEntry) error {
+	switch d.Driver {
+	case "postgres", "cockroachdb", "timescaledb":
+		return postgres.RecordLedgerEntry(d.URI, entry)
+	case "mysql":
+		return mysql.RecordLedgerEntry(d.URI, entry)
+	case "cassandra":
+		return cassandra.RecordLedgerEntry(d.Hosts, d.Username, d.Password, d.Keyspace, entry)
+	case "sqlite":
+		return sqlite.RecordLedgerEntry(d.URI, entry)
+	case "rqlite":
+		return rqlite.RecordLedgerEntry(d.URI, entry)
+	}
+
+	return errors.Errorf("unknown database driver: %q", d.Driver)
+}
+
+// LatestLedgerEntry returns the most recent entry in the migration ledger table for the table,
+// or nil if nothing has been recorded for it
+func (d *Database) LatestLedgerEntry(tableName string) (*types.LedgerEntry, error) {
+	switch d.Driver {
+	case "postgres", "cockroachdb", "timescaledb":
+		return postgres.LatestLedgerEntry(d.URI, tableName)
+	case "mysql":
+		return mysql.LatestLedgerEntry(d.URI, tableName)
+	case "cassandra":
+		return cassandra.LatestLedgerEntry(d.Hosts, d.Username, d.Password, d.Keyspace, tableName)
+	case "sqlite":
+		return sqlite.LatestLedgerEntry(d.URI, tableName)
+	case "rqlite":
+		return rqlite.LatestLedgerEntry(d.URI, tableName)
+	}
+
+	return nil, errors.Errorf("unknown database driver: %q", d.Driver)
+}
+
 // Combine lines that don't terminate with a semicolon.
 // Semicolon on the last line is optional.
 func (d *Database) GetStatementsFromDDL(ddl string) []string {
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/client/schemaheroclientset/scheme"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"gopkg.in/yaml.v2"
+)
+
+// DriftFromLedger compares the statements planned for a spec with the migration ledger in the database.
+// When the latest ledger entry for the table was recorded for this same spec, the database should already
+// match it, and any planned statements mean that the database was changed outside of SchemaHero. The
+// ledger entry is returned in that case, otherwise nil is returned
+func (d *Database) DriftFromLedger(specContents []byte, specType string, statements []string) (*types.LedgerEntry, error) {
+	if len(statements) == 0 {
+		return nil, nil
+	}
+
+	tableName, specSHA, err := ledgerKeyForSpec(specContents, specType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ledger key for spec")
+	}
+	if tableName == "" {
+		return nil, nil
+	}
+
+	entry, err := d.LatestLedgerEntry(tableName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get latest ledger entry for %s", tableName)
+	}
+	if entry == nil || entry.SpecSHA != specSHA {
+		return nil, nil
+	}
+
+	return entry, nil
+}
+
+// ledgerKeyForSpec returns the name in the database and the sha of a table or view spec, as they are recorded
+// in the migration ledger. An empty name is returned for specs that are not recorded in the ledger
+func ledgerKeyForSpec(specContents []byte, specType string) (string, string, error) {
+	decode := scheme.Codecs.UniversalDeserializer().Decode
+	obj, gvk, err := decode(specContents, nil, nil)
+	if err == nil && gvk.Group == "schemas.schemahero.io" && gvk.Version == "v1alpha4" {
+		switch gvk.Kind {
+		case "Table":
+			table := obj.(*schemasv1alpha4.Table)
+			sha, err := table.GetSHA()
+			if err != nil {
+				return "", "", errors.Wrap(err, "failed to get table sha")
+			}
+			return table.Spec.Name, sha, nil
+		case "View":
+			view := obj.(*schemasv1alpha4.View)
+			sha, err := view.GetSHA()
+			if err != nil {
+				return "", "", errors.Wrap(err, "failed to get view sha")
+			}
+			return view.Spec.Name, sha, nil
+		}
+	}
+
+	if specType != "table" {
+		return "", "", nil
+	}
+
+	table := schemasv1alpha4.Table{}
+	if err := yaml.Unmarshal(specContents, &table); err != nil || table.Spec.Schema == nil {
+		table = schemasv1alpha4.Table{}
+		if err := yaml.Unmarshal(specContents, &table.Spec); err != nil {
+			return "", "", errors.Wrap(err, "failed to unmarshal table spec")
+		}
+	}
+
+	sha, err := table.GetSHA()
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to get table sha")
+	}
+	return table.Spec.Name, sha, nil
+}
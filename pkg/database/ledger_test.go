@@ -0,0 +1,80 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ledgerTestTable = `apiVersion: schemas.schemahero.io/v1alpha4
+kind: Table
+metadata:
+  name: users
+spec:
+  database: db
+  name: users
+  schema:
+    sqlite:
+      columns:
+      - name: id
+        type: integer
+`
+
+func Test_DriftFromLedger(t *testing.T) {
+	db := Database{
+		Driver: "sqlite",
+		URI:    filepath.Join(t.TempDir(), "ledger.db"),
+	}
+
+	tableName, specSHA, err := ledgerKeyForSpec([]byte(ledgerTestTable), "table")
+	require.NoError(t, err)
+	assert.Equal(t, "users", tableName)
+
+	entry, err := db.LatestLedgerEntry(tableName)
+	require.NoError(t, err)
+	assert.Nil(t, entry, "expected no entry before the ledger table exists")
+
+	statements, err := db.PlanSync([]byte(ledgerTestTable), "table")
+	require.NoError(t, err)
+	require.NotEmpty(t, statements)
+
+	// the spec has never been applied, so planned statements are not drift
+	drift, err := db.DriftFromLedger([]byte(ledgerTestTable), "table", statements)
+	require.NoError(t, err)
+	assert.Nil(t, drift)
+
+	db.LedgerEntry = &types.LedgerEntry{
+		MigrationID: "abc1234",
+		TableName:   tableName,
+		SpecSHA:     specSHA,
+	}
+	require.NoError(t, db.ApplySync(statements))
+	db.LedgerEntry = nil
+
+	entry, err = db.LatestLedgerEntry(tableName)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "abc1234", entry.MigrationID)
+	assert.Equal(t, specSHA, entry.SpecSHA)
+	assert.Equal(t, types.DDLHash(statements), entry.DDLHash)
+	assert.False(t, entry.AppliedAt.IsZero())
+
+	statements, err = db.PlanSync([]byte(ledgerTestTable), "table")
+	require.NoError(t, err)
+	assert.Empty(t, statements)
+
+	// change the table outside of schemahero
+	require.NoError(t, db.ApplySync([]string{`drop table "users"`}))
+
+	statements, err = db.PlanSync([]byte(ledgerTestTable), "table")
+	require.NoError(t, err)
+	require.NotEmpty(t, statements)
+
+	drift, err = db.DriftFromLedger([]byte(ledgerTestTable), "table", statements)
+	require.NoError(t, err)
+	require.NotNil(t, drift)
+	assert.Equal(t, "abc1234", drift.MigrationID)
+}
@@ -31,10 +31,29 @@ func AlterColumnStatements(tableName string, primaryKeys []string, desiredColumn
 				column.Collation = existingColumn.Collation
 			}
 
+			// modify column replaces the comment, so the existing comment is kept when the spec doesn't set one
+			if desiredColumn.Comment == nil {
+				column.Comment = existingColumn.Comment
+			}
+
 			if columnsMatch(*existingColumn, *column, defaultCharset, defaultCollation) {
 				return []string{}, nil
 			}
 
+			// mysql can't change how a generated column is stored, so it's dropped and added again
+			if !types.GeneratedEqual(existingColumn.Generated, column.Generated) {
+				insertStatement, err := InsertColumnStatement(tableName, desiredColumn)
+				if err != nil {
+					return nil, err
+				}
+
+				statements := AlterDropColumnStatement{
+					TableName: tableName,
+					Column:    types.Column{Name: existingColumn.Name},
+				}.DDL()
+				return append(statements, insertStatement), nil
+			}
+
 			return AlterModifyColumnStatement{
 				TableName:      tableName,
 				ExistingColumn: *existingColumn,
@@ -85,6 +104,14 @@ func columnsMatch(col1 types.Column, col2 types.Column, defaultCharset string, d
 		return false
 	}
 
+	if !types.GeneratedEqual(col1.Generated, col2.Generated) {
+		return false
+	}
+
+	if col1.Comment != col2.Comment {
+		return false
+	}
+
 	col1Constraints, col2Constraints := col1.Constraints, col2.Constraints
 	if col1Constraints == nil {
 		col1Constraints = &types.ColumnConstraints{}
@@ -58,6 +58,10 @@ func (s AlterModifyColumnStatement) ddl(useConstraintsFromExistingColumn bool) [
 		}
 	}
 
+	if s.Column.Generated != nil {
+		stmts = append(stmts, generatedColumnClause(s.Column.Generated))
+	}
+
 	if useConstraintsFromExistingColumn {
 		if s.ExistingColumn.Constraints != nil {
 			if *s.ExistingColumn.Constraints.NotNull {
@@ -80,10 +84,14 @@ func (s AlterModifyColumnStatement) ddl(useConstraintsFromExistingColumn bool) [
 		stmts = append(stmts, "auto_increment")
 	}
 
-	if s.Column.ColumnDefault != nil {
+	if s.Column.ColumnDefault != nil && s.Column.Generated == nil {
 		stmts = append(stmts, fmt.Sprintf("default \"%s\"", *s.Column.ColumnDefault))
 	}
 
+	if s.Column.Comment != "" {
+		stmts = append(stmts, fmt.Sprintf("comment %s", commentLiteral(s.Column.Comment)))
+	}
+
 	return []string{strings.Join(stmts, " ")}
 }
 
@@ -128,6 +136,18 @@ func (s AlterDropColumnStatement) DDL() []string {
 	}
 }
 
+type AlterRenameColumnStatement struct {
+	TableName    string
+	ExistingName string
+	DesiredName  string
+}
+
+func (s AlterRenameColumnStatement) DDL() []string {
+	return []string{
+		fmt.Sprintf("alter table `%s` rename column `%s` to `%s`", s.TableName, s.ExistingName, s.DesiredName),
+	}
+}
+
 type AlterRemoveConstrantStatement struct {
 	TableName  string
 	Constraint types.KeyConstraint
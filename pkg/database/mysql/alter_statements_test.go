@@ -55,6 +55,16 @@ func TestAlterDDL(t *testing.T) {
 	}
 }
 
+func TestAlterRenameColumnStatement_DDL(t *testing.T) {
+	s := AlterRenameColumnStatement{
+		TableName:    "t",
+		ExistingName: "email_address",
+		DesiredName:  "email",
+	}
+
+	assert.Equal(t, []string{"alter table `t` rename column `email_address` to `email`"}, s.DDL())
+}
+
 func TestAlterAddConstrantStatement_String(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -13,6 +13,7 @@ import (
 func Test_AlterColumnStatment(t *testing.T) {
 	defaultEleven := "11"
 	defaultEmpty := ""
+	commentValue := "the customer's id"
 
 	tests := []struct {
 		name               string
@@ -332,6 +333,104 @@ func Test_AlterColumnStatment(t *testing.T) {
 				"alter table `t` modify column `c` int (11) not null",
 			},
 		},
+		{
+			name:      "no change generated",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.MysqlTableColumn{
+				{
+					Name: "total",
+					Type: "integer",
+					Generated: &schemasv1alpha4.MysqlTableColumnGenerated{
+						Expression: "price * quantity",
+						Stored:     true,
+					},
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "total",
+				DataType: "int (11)",
+				Generated: &types.ColumnGenerated{
+					Expression: "(`price` * `quantity`)",
+					Stored:     true,
+				},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name:      "change generated from virtual to stored",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.MysqlTableColumn{
+				{
+					Name: "total",
+					Type: "integer",
+					Generated: &schemasv1alpha4.MysqlTableColumnGenerated{
+						Expression: "price * quantity",
+						Stored:     true,
+					},
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "total",
+				DataType: "int (11)",
+				Generated: &types.ColumnGenerated{
+					Expression: "(`price` * `quantity`)",
+				},
+			},
+			expectedStatements: []string{
+				"alter table `t` drop column `total`",
+				"alter table `t` add column `total` int (11) as (price * quantity) stored",
+			},
+		},
+		{
+			name:      "keep comment that isn't in the spec",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.MysqlTableColumn{
+				{
+					Name: "b",
+					Type: "bigint",
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "b",
+				DataType: "int (11)",
+				Comment:  "set by hand",
+			},
+			expectedStatements: []string{"alter table `t` modify column `b` bigint (20) comment 'set by hand'"},
+		},
+		{
+			name:      "change comment",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.MysqlTableColumn{
+				{
+					Name:    "b",
+					Type:    "integer",
+					Comment: &commentValue,
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "b",
+				DataType: "int (11)",
+				Comment:  "old",
+			},
+			expectedStatements: []string{"alter table `t` modify column `b` int (11) comment 'the customer''s id'"},
+		},
+		{
+			name:      "remove comment",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.MysqlTableColumn{
+				{
+					Name:    "b",
+					Type:    "integer",
+					Comment: &defaultEmpty,
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "b",
+				DataType: "int (11)",
+				Comment:  "old",
+			},
+			expectedStatements: []string{"alter table `t` modify column `b` int (11)"},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+func checkConstraintClause(check *schemasv1alpha4.MysqlTableCheck) string {
+	return fmt.Sprintf("constraint `%s` check (%s)", check.Name, check.Expression)
+}
+
+func AddCheckStatement(tableName string, check *schemasv1alpha4.MysqlTableCheck) string {
+	return fmt.Sprintf("alter table `%s` add %s", tableName, checkConstraintClause(check))
+}
+
+func RemoveCheckStatement(tableName string, check *types.Check) string {
+	return fmt.Sprintf("alter table `%s` drop check `%s`", tableName, check.Name)
+}
+
+// ListTableChecks returns the check constraints on the table. Check constraints are enforced from mysql 8.0.16,
+// and older versions don't have information_schema.check_constraints
+func (m *MysqlConnection) ListTableChecks(databaseName string, tableName string) ([]*types.Check, error) {
+	query := `select cc.CONSTRAINT_NAME, cc.CHECK_CLAUSE
+	from information_schema.CHECK_CONSTRAINTS cc
+	inner join information_schema.TABLE_CONSTRAINTS tc
+	  on tc.CONSTRAINT_SCHEMA = cc.CONSTRAINT_SCHEMA and tc.CONSTRAINT_NAME = cc.CONSTRAINT_NAME
+	where tc.CONSTRAINT_TYPE = 'CHECK'
+	and tc.TABLE_SCHEMA = ?
+	and tc.TABLE_NAME = ?`
+	rows, err := m.db.Query(query, databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query check constraints")
+	}
+	defer rows.Close()
+
+	checks := []*types.Check{}
+	for rows.Next() {
+		check := types.Check{}
+		if err := rows.Scan(&check.Name, &check.Expression); err != nil {
+			return nil, errors.Wrap(err, "failed to scan check constraint")
+		}
+
+		checks = append(checks, &check)
+	}
+
+	return checks, nil
+}
+
+// isJSONValidCheck returns true for the check that mariadb adds to a json column, which is named after the
+// column. It's part of the column, and isn't in the spec
+func isJSONValidCheck(check *types.Check) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(check.Expression), ""), fmt.Sprintf("json_valid(`%s`)", check.Name))
+}
+
+// removedChecks returns the current checks that aren't in the spec, or that changed. Checks aren't removed
+// from a table without checks in the spec
+func removedChecks(desiredChecks []*schemasv1alpha4.MysqlTableCheck, currentChecks []*types.Check) []*types.Check {
+	removed := []*types.Check{}
+	if desiredChecks == nil {
+		return removed
+	}
+
+NextCurrentCheck:
+	for _, currentCheck := range currentChecks {
+		if isJSONValidCheck(currentCheck) {
+			continue
+		}
+
+		for _, desiredCheck := range desiredChecks {
+			if currentCheck.Equals(types.MysqlSchemaCheckToCheck(desiredCheck)) {
+				continue NextCurrentCheck
+			}
+		}
+
+		removed = append(removed, currentCheck)
+	}
+
+	return removed
+}
+
+// addedChecks returns the checks in the spec that aren't on the table, or that changed
+func addedChecks(desiredChecks []*schemasv1alpha4.MysqlTableCheck, currentChecks []*types.Check) []*schemasv1alpha4.MysqlTableCheck {
+	added := []*schemasv1alpha4.MysqlTableCheck{}
+NextDesiredCheck:
+	for _, desiredCheck := range desiredChecks {
+		for _, currentCheck := range currentChecks {
+			if currentCheck.Equals(types.MysqlSchemaCheckToCheck(desiredCheck)) {
+				continue NextDesiredCheck
+			}
+		}
+
+		added = append(added, desiredCheck)
+	}
+
+	return added
+}
@@ -0,0 +1,47 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkChanges(t *testing.T) {
+	desiredChecks := []*schemasv1alpha4.MysqlTableCheck{
+		{Name: "price_positive", Expression: "price >= 0"},
+		{Name: "quantity_positive", Expression: "quantity > 0"},
+	}
+	currentChecks := []*types.Check{
+		{Name: "price_positive", Expression: "(`price` > 0)"},
+		{Name: "quantity_positive", Expression: "(`quantity` > 0)"},
+		{Name: "name_set", Expression: "(length(`name`) > 0)"},
+		{Name: "attributes", Expression: "json_valid(`attributes`)"},
+	}
+
+	removed := []string{}
+	for _, check := range removedChecks(desiredChecks, currentChecks) {
+		removed = append(removed, RemoveCheckStatement("products", check))
+	}
+	assert.Equal(t, []string{
+		"alter table `products` drop check `price_positive`",
+		"alter table `products` drop check `name_set`",
+	}, removed)
+
+	added := []string{}
+	for _, check := range addedChecks(desiredChecks, currentChecks) {
+		added = append(added, AddCheckStatement("products", check))
+	}
+	assert.Equal(t, []string{
+		"alter table `products` add constraint `price_positive` check (price >= 0)",
+	}, added)
+}
+
+func Test_removedChecksWithoutChecksInSpec(t *testing.T) {
+	currentChecks := []*types.Check{
+		{Name: "name_set", Expression: "(length(`name`) > 0)"},
+	}
+
+	assert.Empty(t, removedChecks(nil, currentChecks))
+}
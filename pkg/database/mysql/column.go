@@ -27,6 +27,17 @@ func schemaColumnToColumn(schemaColumn *schemasv1alpha4.MysqlTableColumn) (*type
 		}
 	}
 
+	if schemaColumn.Generated != nil {
+		column.Generated = &types.ColumnGenerated{
+			Expression: schemaColumn.Generated.Expression,
+			Stored:     schemaColumn.Generated.Stored,
+		}
+	}
+
+	if schemaColumn.Comment != nil {
+		column.Comment = *schemaColumn.Comment
+	}
+
 	requestedType := schemaColumn.Type
 	unaliasedColumnType := unaliasUnparameterizedColumnType(requestedType)
 	if unaliasedColumnType != "" {
@@ -71,6 +82,10 @@ func mysqlColumnAsInsert(column *schemasv1alpha4.MysqlTableColumn) (string, erro
 		formatted = fmt.Sprintf("%s collate %s", formatted, mysqlColumn.Collation)
 	}
 
+	if mysqlColumn.Generated != nil {
+		formatted = fmt.Sprintf("%s %s", formatted, generatedColumnClause(mysqlColumn.Generated))
+	}
+
 	if mysqlColumn.Constraints != nil && mysqlColumn.Constraints.NotNull != nil {
 		if *mysqlColumn.Constraints.NotNull {
 			formatted = fmt.Sprintf("%s not null", formatted)
@@ -83,7 +98,7 @@ func mysqlColumnAsInsert(column *schemasv1alpha4.MysqlTableColumn) (string, erro
 		formatted = fmt.Sprintf("%s auto_increment", formatted)
 	}
 
-	if mysqlColumn.ColumnDefault != nil {
+	if mysqlColumn.ColumnDefault != nil && mysqlColumn.Generated == nil {
 		quoteDefaultValue := true
 
 		if mysqlColumn.DataType == "datetime" || mysqlColumn.DataType == "timestamp" {
@@ -99,9 +114,21 @@ func mysqlColumnAsInsert(column *schemasv1alpha4.MysqlTableColumn) (string, erro
 		}
 	}
 
+	if mysqlColumn.Comment != "" {
+		formatted = fmt.Sprintf("%s comment %s", formatted, commentLiteral(mysqlColumn.Comment))
+	}
+
 	return formatted, nil
 }
 
+// generatedColumnClause returns the clause that makes a column generated from its expression
+func generatedColumnClause(generated *types.ColumnGenerated) string {
+	if generated.Stored {
+		return fmt.Sprintf("as (%s) stored", generated.Expression)
+	}
+	return fmt.Sprintf("as (%s) virtual", generated.Expression)
+}
+
 func InsertColumnStatement(tableName string, desiredColumn *schemasv1alpha4.MysqlTableColumn) (string, error) {
 	columnFields, err := mysqlColumnAsInsert(desiredColumn)
 	if err != nil {
@@ -112,3 +139,25 @@ func InsertColumnStatement(tableName string, desiredColumn *schemasv1alpha4.Mysq
 
 	return statement, nil
 }
+
+// columnsRenamedFrom returns the previous name of each desired column that was renamed, keyed by the desired name
+func columnsRenamedFrom(desiredColumns []*schemasv1alpha4.MysqlTableColumn) map[string]string {
+	renamedFrom := map[string]string{}
+	for _, desiredColumn := range desiredColumns {
+		if desiredColumn.RenamedFrom != "" {
+			renamedFrom[desiredColumn.Name] = desiredColumn.RenamedFrom
+		}
+	}
+
+	return renamedFrom
+}
+
+// ValidateColumn returns an error when the type of the column is not one that can be deployed to mysql
+func ValidateColumn(column *schemasv1alpha4.MysqlTableColumn) error {
+	if column.Generated != nil && column.Default != nil {
+		return fmt.Errorf("generated column %q cannot have a default", column.Name)
+	}
+
+	_, err := schemaColumnToColumn(column)
+	return err
+}
@@ -67,6 +67,20 @@ func Test_mysqlColumnAsInsert(t *testing.T) {
 			},
 			expectedStatement: "`c` varchar (255) character set latin1 collate latin1_danish_ci not null default '11'",
 		},
+		{
+			name: "virtual generated",
+			column: &schemasv1alpha4.MysqlTableColumn{
+				Name: "full_name",
+				Type: "varchar(255)",
+				Constraints: &schemasv1alpha4.MysqlTableColumnConstraints{
+					NotNull: &trueValue,
+				},
+				Generated: &schemasv1alpha4.MysqlTableColumnGenerated{
+					Expression: "concat(first_name, ' ', last_name)",
+				},
+			},
+			expectedStatement: "`full_name` varchar (255) as (concat(first_name, ' ', last_name)) virtual not null",
+		},
 	}
 
 	for _, test := range tests {
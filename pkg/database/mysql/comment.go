@@ -0,0 +1,42 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// commentLiteral returns the comment as a quoted string. An empty string removes the comment
+func commentLiteral(comment string) string {
+	escaped := strings.ReplaceAll(comment, `\`, `\\`)
+	escaped = strings.ReplaceAll(escaped, "'", "''")
+	return fmt.Sprintf("'%s'", escaped)
+}
+
+func TableCommentStatement(tableName string, comment string) string {
+	return fmt.Sprintf("alter table `%s` comment %s", tableName, commentLiteral(comment))
+}
+
+// buildTableCommentStatements returns the statement to change the comment on the table when it's set in the spec.
+// Column comments are part of the column definitions, and are changed with the columns
+func buildTableCommentStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
+	if mysqlTableSchema.Comment == nil {
+		return []string{}, nil
+	}
+
+	query := `select TABLE_COMMENT from information_schema.TABLES where TABLE_SCHEMA = ? and TABLE_NAME = ?`
+	row := m.db.QueryRow(query, m.databaseName, tableName)
+
+	var existingComment string
+	if err := row.Scan(&existingComment); err != nil {
+		return nil, errors.Wrap(err, "failed to read existing table comment")
+	}
+
+	if existingComment == *mysqlTableSchema.Comment {
+		return []string{}, nil
+	}
+
+	return []string{TableCommentStatement(tableName, *mysqlTableSchema.Comment)}, nil
+}
@@ -45,9 +45,16 @@ func Connect(uri string) (*MysqlConnection, error) {
 		return nil, err
 	}
 
+	// the version is reported as it is by mysql, such as 8.0.27 or 10.5.8-MariaDB
+	var engineVersion string
+	if err := db.QueryRow("select version()").Scan(&engineVersion); err != nil {
+		return nil, errors.Wrap(err, "failed to get version")
+	}
+
 	mysqlConnection := MysqlConnection{
-		db:           db,
-		databaseName: databaseName,
+		db:            db,
+		databaseName:  databaseName,
+		engineVersion: engineVersion,
 	}
 
 	return &mysqlConnection, nil
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 )
 
@@ -75,6 +76,10 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.MysqlT
 		columns = append(columns, indexClause(tableName, index))
 	}
 
+	for _, check := range tableSchema.Checks {
+		columns = append(columns, checkConstraintClause(check))
+	}
+
 	query := fmt.Sprintf("create table `%s` (%s)", tableName, strings.Join(columns, ", "))
 
 	if tableSchema.DefaultCharset != "" {
@@ -83,6 +88,21 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.MysqlT
 	if tableSchema.Collation != "" {
 		query = fmt.Sprintf("%s collate %s", query, tableSchema.Collation)
 	}
+	if err := ValidateTableOptions(tableSchema); err != nil {
+		return nil, errors.Wrap(err, "invalid table options")
+	}
+	for _, clause := range createTableOptionClauses(tableSchema) {
+		query = fmt.Sprintf("%s %s", query, clause)
+	}
+	if tableSchema.Comment != nil && *tableSchema.Comment != "" {
+		query = fmt.Sprintf("%s comment %s", query, commentLiteral(*tableSchema.Comment))
+	}
+	if tableSchema.Partitioning != nil {
+		if err := ValidatePartitioning(tableSchema.Partitioning); err != nil {
+			return nil, errors.Wrap(err, "invalid partitioning")
+		}
+		query = fmt.Sprintf("%s %s", query, partitionByClause(tableSchema.Partitioning))
+	}
 
 	return []string{query}, nil
 }
@@ -10,6 +10,9 @@ import (
 )
 
 func Test_CreateTableStatement(t *testing.T) {
+	tableComment := "Customer orders"
+	columnComment := "the order's number"
+
 	tests := []struct {
 		name               string
 		tableSchema        *schemasv1alpha4.MysqlTableSchema
@@ -34,6 +37,27 @@ func Test_CreateTableStatement(t *testing.T) {
 				"create table `simple` (`id` int (11), primary key (`id`))",
 			},
 		},
+		{
+			name: "table with a check",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				Checks: []*schemasv1alpha4.MysqlTableCheck{
+					{
+						Name:       "price_positive",
+						Expression: "price > 0",
+					},
+				},
+				Columns: []*schemasv1alpha4.MysqlTableColumn{
+					{
+						Name: "price",
+						Type: "integer",
+					},
+				},
+			},
+			tableName: "products",
+			expectedStatements: []string{
+				"create table `products` (`price` int (11), constraint `price_positive` check (price > 0))",
+			},
+		},
 		{
 			name: "varchar composite primary key",
 			tableSchema: &schemasv1alpha4.MysqlTableSchema{
@@ -164,6 +188,94 @@ func Test_CreateTableStatement(t *testing.T) {
 				"create table `test` (`id` int (11), primary key (`id`)) collate latin1_german1_ci",
 			},
 		},
+		{
+			name: "table with engine and row format",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				PrimaryKey: []string{
+					"id",
+				},
+				Columns: []*schemasv1alpha4.MysqlTableColumn{
+					{
+						Name: "id",
+						Type: "integer",
+					},
+				},
+				DefaultCharset:     "latin1",
+				Engine:             "InnoDB",
+				RowFormat:          "COMPRESSED",
+				KeyBlockSize:       8,
+				AutoIncrementStart: 1000,
+			},
+			tableName: "test",
+			expectedStatements: []string{
+				"create table `test` (`id` int (11), primary key (`id`)) default character set latin1 engine InnoDB row_format compressed key_block_size 8 auto_increment 1000",
+			},
+		},
+		{
+			name: "table and column comments",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				Columns: []*schemasv1alpha4.MysqlTableColumn{
+					{
+						Name:    "id",
+						Type:    "integer",
+						Comment: &columnComment,
+					},
+				},
+				Comment: &tableComment,
+			},
+			tableName: "orders",
+			expectedStatements: []string{
+				"create table `orders` (`id` int (11) comment 'the order''s number') comment 'Customer orders'",
+			},
+		},
+		{
+			name: "range partitioned",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				PrimaryKey: []string{"id", "year"},
+				Columns: []*schemasv1alpha4.MysqlTableColumn{
+					{
+						Name: "id",
+						Type: "integer",
+					},
+					{
+						Name: "year",
+						Type: "integer",
+					},
+				},
+				Partitioning: &schemasv1alpha4.MysqlTablePartitioning{
+					Strategy: "range",
+					Key:      "year",
+					Partitions: []*schemasv1alpha4.MysqlTablePartition{
+						{Name: "p2024", LessThan: "2025"},
+						{Name: "pmax", LessThan: "maxvalue"},
+					},
+				},
+			},
+			tableName: "events",
+			expectedStatements: []string{
+				"create table `events` (`id` int (11), `year` int (11), primary key (`id`, `year`)) partition by range (year) (partition `p2024` values less than (2025), partition `pmax` values less than maxvalue)",
+			},
+		},
+		{
+			name: "hash partitioned",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				Columns: []*schemasv1alpha4.MysqlTableColumn{
+					{
+						Name: "id",
+						Type: "integer",
+					},
+				},
+				Partitioning: &schemasv1alpha4.MysqlTablePartitioning{
+					Strategy: "hash",
+					Key:      "id",
+					Count:    4,
+				},
+			},
+			tableName: "events",
+			expectedStatements: []string{
+				"create table `events` (`id` int (11)) partition by hash (id) partitions 4",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -15,7 +15,7 @@ func PlanMysqlView(uri string, viewName string, mysqlViewSchema *schemasv1alpha4
 	return nil, errors.New("not implemented")
 }
 
-func PlanMysqlTable(uri string, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+func PlanMysqlTable(uri string, tableName string, renamedFrom string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
 	m, err := Connect(uri)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to mysql")
@@ -30,6 +30,23 @@ func PlanMysqlTable(uri string, tableName string, mysqlTableSchema *schemasv1alp
 		return nil, errors.Wrap(err, "failed to scan")
 	}
 
+	// a renamed table is changed to match the spec under its existing name, and then renamed
+	renameTo := ""
+	if tableExists == 0 && renamedFrom != "" && renamedFrom != tableName && !mysqlTableSchema.IsDeleted {
+		row := m.db.QueryRow(query, renamedFrom, m.databaseName)
+		existingTableExists := 0
+		if err := row.Scan(&existingTableExists); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		if existingTableExists > 0 {
+			renameTo = tableName
+			tableName = renamedFrom
+			tableExists = existingTableExists
+			mysqlTableSchema = withGeneratedNames(renameTo, mysqlTableSchema)
+		}
+	}
+
 	if tableExists == 0 && mysqlTableSchema.IsDeleted {
 		return []string{}, nil
 	} else if tableExists > 0 && mysqlTableSchema.IsDeleted {
@@ -65,6 +82,19 @@ func PlanMysqlTable(uri string, tableName string, mysqlTableSchema *schemasv1alp
 	}
 	statements = append(statements, charsetAndCollationStatements...)
 
+	tableCommentStatements, err := buildTableCommentStatements(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build table comment statements")
+	}
+	statements = append(statements, tableCommentStatements...)
+
+	// the engine is changed before foreign keys are added, because they need an engine that supports them
+	tableOptionsStatements, err := buildTableOptionsStatements(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build table options statements")
+	}
+	statements = append(statements, tableOptionsStatements...)
+
 	// remove primary keys before removing columns
 	removePrimaryKeyStatements, err := buildRemovePrimaryKeyStatements(m, tableName, mysqlTableSchema)
 	if err != nil {
@@ -79,6 +109,13 @@ func PlanMysqlTable(uri string, tableName string, mysqlTableSchema *schemasv1alp
 	}
 	statements = append(statements, removeIndexStatements...)
 
+	// checks need to be removed before the columns that they use are removed
+	removeCheckStatements, err := buildRemoveCheckStatements(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build remove check statements")
+	}
+	statements = append(statements, removeCheckStatements...)
+
 	// table needs to be altered?
 	columnStatements, err := buildColumnStatements(m, tableName, mysqlTableSchema)
 	if err != nil {
@@ -107,8 +144,30 @@ func PlanMysqlTable(uri string, tableName string, mysqlTableSchema *schemasv1alp
 	}
 	statements = append(statements, addIndexStatements...)
 
+	// add checks after columns are added
+	addCheckStatements, err := buildAddCheckStatements(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build add check statements")
+	}
+	statements = append(statements, addCheckStatements...)
+
+	if mysqlTableSchema.OnlineAlter {
+		statements = onlineAlterStatements(statements)
+	}
+
+	// partition changes
+	partitionStatements, err := buildPartitionStatements(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build partition statements")
+	}
+	statements = append(statements, partitionStatements...)
+
 	statements = append(statements, seedDataStatements...)
 
+	if renameTo != "" {
+		statements = append(statements, RenameTableStatement(tableName, renameTo))
+	}
+
 	return statements, nil
 }
 
@@ -133,9 +192,20 @@ func executeStatements(m *MysqlConnection, statements []string) error {
 			continue
 		}
 		fmt.Printf("Executing query %q\n", statement)
-		if _, err := m.db.ExecContext(context.Background(), statement); err != nil {
+		_, err := m.db.ExecContext(context.Background(), statement)
+		if err == nil {
+			continue
+		}
+
+		// an online alter that mysql can't make in place is made by copying the table instead
+		tableName, clause, ok := parseOnlineAlterStatement(statement)
+		if !ok || !isAlterNotSupportedError(err) {
 			return err
 		}
+		fmt.Printf("Altering table %q with a shadow table: %s\n", tableName, err.Error())
+		if err := shadowTableAlter(m, tableName, clause); err != nil {
+			return errors.Wrapf(err, "failed to alter table %s with a shadow table", tableName)
+		}
 	}
 
 	return nil
@@ -273,7 +343,8 @@ func buildColumnStatements(m *MysqlConnection, tableName string, mysqlTableSchem
 	}
 
 	query := `select
-COLUMN_NAME, COLUMN_DEFAULT, IS_NULLABLE, EXTRA, COLUMN_TYPE, CHARACTER_MAXIMUM_LENGTH, CHARACTER_SET_NAME, COLLATION_NAME
+COLUMN_NAME, COLUMN_DEFAULT, IS_NULLABLE, EXTRA, COLUMN_TYPE, CHARACTER_MAXIMUM_LENGTH, CHARACTER_SET_NAME, COLLATION_NAME,
+GENERATION_EXPRESSION, COLUMN_COMMENT
 from information_schema.COLUMNS
 where TABLE_NAME = ?`
 	rows, err := m.db.Query(query, tableName)
@@ -282,15 +353,16 @@ where TABLE_NAME = ?`
 	}
 	defer rows.Close()
 
-	alterAndDropStatements := []string{}
-	foundColumnNames := []string{}
+	existingColumns := []types.Column{}
 	for rows.Next() {
 		var columnName, dataType, isNullable, extra string
 		var columnDefault sql.NullString
 		var charMaxLength sql.NullInt64
 		var columnCharset, columnCollation sql.NullString
+		var generationExpression sql.NullString
+		var columnComment string
 
-		if err := rows.Scan(&columnName, &columnDefault, &isNullable, &extra, &dataType, &charMaxLength, &columnCharset, &columnCollation); err != nil {
+		if err := rows.Scan(&columnName, &columnDefault, &isNullable, &extra, &dataType, &charMaxLength, &columnCharset, &columnCollation, &generationExpression, &columnComment); err != nil {
 			return nil, errors.Wrap(err, "failed to scan")
 		}
 
@@ -310,8 +382,6 @@ where TABLE_NAME = ?`
 			dataType = fmt.Sprintf("%s (%d)", dataType, charMaxLength.Int64)
 		}
 
-		foundColumnNames = append(foundColumnNames, columnName)
-
 		charset := ""
 		if columnCharset.Valid {
 			charset = columnCharset.String
@@ -329,6 +399,7 @@ where TABLE_NAME = ?`
 			Attributes:  &types.ColumnAttributes{},
 			Charset:     charset,
 			Collation:   collation,
+			Comment:     columnComment,
 		}
 
 		if isNullable == "NO" {
@@ -347,6 +418,42 @@ where TABLE_NAME = ?`
 			existingColumn.ColumnDefault = &columnDefault.String
 		}
 
+		// extra is "VIRTUAL GENERATED" or "STORED GENERATED" for generated columns, and mariadb calls stored
+		// columns "PERSISTENT GENERATED". "DEFAULT_GENERATED" is a column with an expression as its default
+		upperExtra := strings.ToUpper(extra)
+		isVirtual := strings.Contains(upperExtra, "VIRTUAL GENERATED")
+		isStored := strings.Contains(upperExtra, "STORED GENERATED") || strings.Contains(upperExtra, "PERSISTENT GENERATED")
+		if (isVirtual || isStored) && generationExpression.Valid {
+			existingColumn.Generated = &types.ColumnGenerated{
+				Expression: generationExpression.String,
+				Stored:     isStored,
+			}
+		}
+
+		existingColumns = append(existingColumns, existingColumn)
+	}
+
+	existingColumnNames := []string{}
+	for _, existingColumn := range existingColumns {
+		existingColumnNames = append(existingColumnNames, existingColumn.Name)
+	}
+	renames := types.ColumnRenames(existingColumnNames, columnsRenamedFrom(mysqlTableSchema.Columns))
+
+	alterAndDropStatements := []string{}
+	foundColumnNames := []string{}
+	for _, existingColumn := range existingColumns {
+		// renamed columns are compared to the desired column by their new name
+		if newName, ok := renames[existingColumn.Name]; ok {
+			alterAndDropStatements = append(alterAndDropStatements, AlterRenameColumnStatement{
+				TableName:    tableName,
+				ExistingName: existingColumn.Name,
+				DesiredName:  newName,
+			}.DDL()...)
+			existingColumn.Name = newName
+		}
+
+		foundColumnNames = append(foundColumnNames, existingColumn.Name)
+
 		columnStatement, err := AlterColumnStatements(tableName, mysqlTableSchema.PrimaryKey, mysqlTableSchema.Columns, &existingColumn, defaultCharset, defaultCollation)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create alter column statement")
@@ -376,11 +483,35 @@ where TABLE_NAME = ?`
 	return alterAndDropStatements, nil
 }
 
+// listColumnRenames returns the columns of the table that the desired columns rename, keyed by their current
+// names. The existing keys, indexes and checks still use the current names until the renames are made
+func listColumnRenames(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) (map[string]string, error) {
+	existingColumns, err := m.GetTableSchema(tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get table schema")
+	}
+
+	existingColumnNames := []string{}
+	for _, existingColumn := range existingColumns {
+		existingColumnNames = append(existingColumnNames, existingColumn.Name)
+	}
+
+	return types.ColumnRenames(existingColumnNames, columnsRenamedFrom(mysqlTableSchema.Columns)), nil
+}
+
 func buildRemovePrimaryKeyStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
 	currentPrimaryKey, err := m.GetTablePrimaryKey(tableName)
 	if err != nil {
 		return nil, err
 	}
+	if currentPrimaryKey != nil {
+		// renaming a column renames it in the primary key too
+		renames, err := listColumnRenames(m, tableName, mysqlTableSchema)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list column renames")
+		}
+		currentPrimaryKey.Columns = types.RenameColumnNames(currentPrimaryKey.Columns, renames)
+	}
 	var mysqlTableSchemaPrimaryKey *types.KeyConstraint
 	if len(mysqlTableSchema.PrimaryKey) > 0 {
 		mysqlTableSchemaPrimaryKey = &types.KeyConstraint{
@@ -409,6 +540,14 @@ func buildAddPrimaryKeyStatements(m *MysqlConnection, tableName string, mysqlTab
 	if err != nil {
 		return nil, err
 	}
+	if currentPrimaryKey != nil {
+		// renaming a column renames it in the primary key too
+		renames, err := listColumnRenames(m, tableName, mysqlTableSchema)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list column renames")
+		}
+		currentPrimaryKey.Columns = types.RenameColumnNames(currentPrimaryKey.Columns, renames)
+	}
 
 	var mysqlTableSchemaPrimaryKey *types.KeyConstraint
 	if len(mysqlTableSchema.PrimaryKey) > 0 {
@@ -435,12 +574,22 @@ func buildAddPrimaryKeyStatements(m *MysqlConnection, tableName string, mysqlTab
 
 func buildForeignKeyStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
 	foreignKeyStatements := []string{}
+	droppedKeys := []string{}
 	currentForeignKeys, err := m.ListTableForeignKeys(m.databaseName, tableName)
 	if err != nil {
 		return nil, err
 	}
+	renames, err := listColumnRenames(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list column renames")
+	}
+	types.RenameForeignKeyColumns(currentForeignKeys, renames)
 
 	for _, foreignKey := range mysqlTableSchema.ForeignKeys {
+		if foreignKey.Name == "" {
+			foreignKey.Name = types.GenerateMysqlFKName(tableName, foreignKey)
+		}
+
 		var statement string
 		var matchedForeignKey *types.ForeignKey
 		for _, currentForeignKey := range currentForeignKeys {
@@ -448,13 +597,16 @@ func buildForeignKeyStatements(m *MysqlConnection, tableName string, mysqlTableS
 				goto Next
 			}
 
-			matchedForeignKey = currentForeignKey
+			if currentForeignKey.Name == foreignKey.Name {
+				matchedForeignKey = currentForeignKey
+			}
 		}
 
 		// drop and readd?  is this always ok
 		// TODO can we alter
 		if matchedForeignKey != nil {
 			statement = RemoveForeignKeyStatement(tableName, matchedForeignKey)
+			droppedKeys = append(droppedKeys, matchedForeignKey.Name)
 			foreignKeyStatements = append(foreignKeyStatements, statement)
 		}
 
@@ -472,6 +624,12 @@ func buildForeignKeyStatements(m *MysqlConnection, tableName string, mysqlTableS
 			}
 		}
 
+		for _, droppedKey := range droppedKeys {
+			if droppedKey == currentForeignKey.Name {
+				goto NextCurrentFK
+			}
+		}
+
 		statement = RemoveForeignKeyStatement(tableName, currentForeignKey)
 		foreignKeyStatements = append(foreignKeyStatements, statement)
 
@@ -487,6 +645,11 @@ func buildRemoveIndexStatements(m *MysqlConnection, tableName string, mysqlTable
 	if err != nil {
 		return nil, err
 	}
+	renames, err := listColumnRenames(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list column renames")
+	}
+	types.RenameIndexColumns(currentIndexes, renames)
 
 	for _, currentIndex := range currentIndexes {
 		isMatch := false
@@ -514,6 +677,11 @@ func buildAddIndexStatements(m *MysqlConnection, tableName string, mysqlTableSch
 	if err != nil {
 		return nil, err
 	}
+	renames, err := listColumnRenames(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list column renames")
+	}
+	types.RenameIndexColumns(currentIndexes, renames)
 
 	for _, desiredIndex := range mysqlTableSchema.Indexes {
 		isMatch := false
@@ -530,3 +698,51 @@ func buildAddIndexStatements(m *MysqlConnection, tableName string, mysqlTableSch
 
 	return indexStatements, nil
 }
+
+// buildRemoveCheckStatements returns the statements to drop the checks that changed or aren't in the spec. The
+// checks of a table without checks in the spec aren't changed
+func buildRemoveCheckStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
+	if mysqlTableSchema.Checks == nil {
+		return []string{}, nil
+	}
+
+	currentChecks, err := m.ListTableChecks(m.databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list table checks")
+	}
+	renames, err := listColumnRenames(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list column renames")
+	}
+	types.RenameCheckColumns(currentChecks, renames)
+
+	checkStatements := []string{}
+	for _, check := range removedChecks(mysqlTableSchema.Checks, currentChecks) {
+		checkStatements = append(checkStatements, RemoveCheckStatement(tableName, check))
+	}
+
+	return checkStatements, nil
+}
+
+func buildAddCheckStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
+	if mysqlTableSchema.Checks == nil {
+		return []string{}, nil
+	}
+
+	currentChecks, err := m.ListTableChecks(m.databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list table checks")
+	}
+	renames, err := listColumnRenames(m, tableName, mysqlTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list column renames")
+	}
+	types.RenameCheckColumns(currentChecks, renames)
+
+	checkStatements := []string{}
+	for _, check := range addedChecks(mysqlTableSchema.Checks, currentChecks) {
+		checkStatements = append(checkStatements, AddCheckStatement(tableName, check))
+	}
+
+	return checkStatements, nil
+}
@@ -83,6 +83,24 @@ func Test_AddForeignKeyStatement(t *testing.T) {
 			},
 			expectedStatement: `alter table t2 add constraint t2_c2_fkey foreign key (c2) references t1 (c1) on delete cascade`,
 		},
+		{
+			name:      "no name, one column, on update cascade",
+			tableName: "t2",
+			schemaForeignKey: &schemasv1alpha4.MysqlTableForeignKey{
+				OnUpdate: "cascade",
+				Match:    "simple",
+				Columns: []string{
+					"c2",
+				},
+				References: schemasv1alpha4.MysqlTableForeignKeyReferences{
+					Table: "t1",
+					Columns: []string{
+						"c1",
+					},
+				},
+			},
+			expectedStatement: `alter table t2 add constraint t2_c2_fkey foreign key (c2) references t1 (c1) match simple on update cascade`,
+		},
 	}
 
 	for _, test := range tests {
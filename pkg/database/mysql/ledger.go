@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// ledgerTimeFormat is used to read and write applied_at as a string, so that it's stored in UTC
+// regardless of the parseTime and loc parameters in the connection uri
+const ledgerTimeFormat = "2006-01-02 15:04:05.000000"
+
+func createLedgerTableStatement() string {
+	return fmt.Sprintf("create table if not exists %s (migration_id varchar(255), table_name varchar(255), spec_sha varchar(255), ddl_hash varchar(64) not null, applied_at datetime(6) not null, duration_ms bigint not null)", types.LedgerTableName)
+}
+
+// RecordLedgerEntry writes the entry to the migration ledger table, creating the table if it doesn't exist
+func RecordLedgerEntry(uri string, entry types.LedgerEntry) error {
+	m, err := Connect(uri)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect")
+	}
+	defer m.db.Close()
+
+	if _, err := m.db.Exec(createLedgerTableStatement()); err != nil {
+		return errors.Wrap(err, "failed to create ledger table")
+	}
+
+	query := fmt.Sprintf("insert into %s (migration_id, table_name, spec_sha, ddl_hash, applied_at, duration_ms) values (?, ?, ?, ?, ?, ?)", types.LedgerTableName)
+	if _, err := m.db.Exec(query, entry.MigrationID, entry.TableName, entry.SpecSHA, entry.DDLHash, entry.AppliedAt.UTC().Format(ledgerTimeFormat), entry.Duration.Milliseconds()); err != nil {
+		return errors.Wrap(err, "failed to insert ledger entry")
+	}
+
+	return nil
+}
+
+// LatestLedgerEntry returns the most recently applied ledger entry for the table, or nil if there is none
+func LatestLedgerEntry(uri string, tableName string) (*types.LedgerEntry, error) {
+	m, err := Connect(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect")
+	}
+	defer m.db.Close()
+
+	query := "select count(1) from information_schema.tables where table_schema = ? and table_name = ?"
+	row := m.db.QueryRow(query, m.databaseName, types.LedgerTableName)
+	ledgerExists := 0
+	if err := row.Scan(&ledgerExists); err != nil {
+		return nil, errors.Wrap(err, "failed to scan")
+	}
+	if ledgerExists == 0 {
+		return nil, nil
+	}
+
+	query = fmt.Sprintf("select coalesce(migration_id, ''), coalesce(table_name, ''), coalesce(spec_sha, ''), ddl_hash, date_format(applied_at, '%%Y-%%m-%%d %%H:%%i:%%s.%%f'), duration_ms from %s where table_name = ? order by applied_at desc limit 1", types.LedgerTableName)
+	row = m.db.QueryRow(query, tableName)
+
+	entry := types.LedgerEntry{}
+	var appliedAt string
+	var durationMS int64
+	if err := row.Scan(&entry.MigrationID, &entry.TableName, &entry.SpecSHA, &entry.DDLHash, &appliedAt, &durationMS); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to scan ledger entry")
+	}
+
+	entry.AppliedAt, err = time.Parse(ledgerTimeFormat, appliedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse applied at")
+	}
+	entry.Duration = time.Duration(durationMS) * time.Millisecond
+
+	return &entry, nil
+}
@@ -0,0 +1,500 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+)
+
+// onlineAlterOptions are appended to the alters of tables with online alter. Alters that end with them are
+// made with a shadow table when mysql can't make them in place
+const onlineAlterOptions = "algorithm=inplace, lock=none"
+
+// shadowCopyChunkSize is the number of rows that are copied to the shadow table by each statement
+const shadowCopyChunkSize = 1000
+
+var (
+	onlineAlterRegexp   = regexp.MustCompile("(?is)^alter table (`[^`]+`|\\S+) (.+), " + onlineAlterOptions + "$")
+	renamedColumnRegexp = regexp.MustCompile("(?i)\\b(?:rename column `([^`]+)` to|change column `([^`]+)`) `([^`]+)`")
+
+	// keyAlterRegexp matches the alters that change the primary key or the foreign keys of a table. Mysql can't
+	// make them in place without a lock, and the shadow table can't be used for them
+	keyAlterRegexp = regexp.MustCompile("(?is)^alter table (`[^`]+`|\\S+) (drop primary key|drop foreign key|drop constraint|add constraint (`[^`]+`|\\S+) (primary|foreign) key)\\b")
+)
+
+// OnlineAlterStatement returns the alter with the options that make mysql change the table in place, without
+// blocking writes to it, or fail without changing it
+func OnlineAlterStatement(statement string) string {
+	return fmt.Sprintf("%s, %s", statement, onlineAlterOptions)
+}
+
+// onlineAlterStatements returns the statements with the alters changed to online alters. Alters of the primary
+// key or the foreign keys, and statements that aren't alters, aren't changed
+func onlineAlterStatements(statements []string) []string {
+	onlineStatements := []string{}
+	for _, statement := range statements {
+		if strings.HasPrefix(strings.ToLower(statement), "alter table ") && !keyAlterRegexp.MatchString(statement) {
+			statement = OnlineAlterStatement(statement)
+		}
+		onlineStatements = append(onlineStatements, statement)
+	}
+
+	return onlineStatements
+}
+
+// parseOnlineAlterStatement returns the name of the table and the change that an online alter makes to it
+func parseOnlineAlterStatement(statement string) (string, string, bool) {
+	matches := onlineAlterRegexp.FindStringSubmatch(strings.TrimSpace(statement))
+	if matches == nil {
+		return "", "", false
+	}
+
+	return strings.Trim(matches[1], "`"), matches[2], true
+}
+
+// isAlterNotSupportedError returns true when mysql refused to make an alter with the algorithm or lock that
+// it was asked for. Mysql checks this before it changes the table
+func isAlterNotSupportedError(err error) bool {
+	mysqlErr, ok := errors.Cause(err).(*mysqldriver.MySQLError)
+	if !ok {
+		return false
+	}
+
+	// ER_ALTER_OPERATION_NOT_SUPPORTED and ER_ALTER_OPERATION_NOT_SUPPORTED_REASON
+	return mysqlErr.Number == 1845 || mysqlErr.Number == 1846
+}
+
+// shadowTable is the names of the tables and triggers that are used to alter a table by copying it
+type shadowTable struct {
+	tableName string
+}
+
+// ghostName is the table that the rows are copied to, and that replaces the table
+func (s shadowTable) ghostName() string {
+	return fmt.Sprintf("_%s_gho", s.tableName)
+}
+
+// oldName is the name of the table after it's replaced, until it's dropped
+func (s shadowTable) oldName() string {
+	return fmt.Sprintf("_%s_old", s.tableName)
+}
+
+func (s shadowTable) triggerName(event string) string {
+	return fmt.Sprintf("_%s_%s", s.tableName, event)
+}
+
+// shadowColumn is a column that's copied to the shadow table, with its name in the shadow table
+type shadowColumn struct {
+	Name      string
+	GhostName string
+}
+
+// renamedColumns returns the new names of the columns that the alter renames, keyed by their existing names
+func renamedColumns(clause string) map[string]string {
+	renamed := map[string]string{}
+	for _, matches := range renamedColumnRegexp.FindAllStringSubmatch(clause, -1) {
+		existingName := matches[1]
+		if existingName == "" {
+			existingName = matches[2]
+		}
+		renamed[existingName] = matches[3]
+	}
+
+	return renamed
+}
+
+// shadowCopyColumns returns the columns of the table that are in the shadow table. ghostColumns are the
+// columns of the shadow table that rows can be inserted into, so generated columns aren't in it
+func shadowCopyColumns(columns []string, ghostColumns []string, renamed map[string]string) []shadowColumn {
+	copied := []shadowColumn{}
+	for _, column := range columns {
+		ghostName := column
+		if newName, ok := renamed[column]; ok {
+			ghostName = newName
+		}
+
+		if !containsString(ghostColumns, ghostName) {
+			continue
+		}
+
+		copied = append(copied, shadowColumn{
+			Name:      column,
+			GhostName: ghostName,
+		})
+	}
+
+	return copied
+}
+
+// unfilledShadowColumns returns the required columns of the shadow table that aren't copied from the table
+func unfilledShadowColumns(requiredColumns []string, columns []shadowColumn) []string {
+	ghostNames := []string{}
+	for _, column := range columns {
+		ghostNames = append(ghostNames, column.GhostName)
+	}
+
+	unfilled := []string{}
+	for _, column := range requiredColumns {
+		if !containsString(ghostNames, column) {
+			unfilled = append(unfilled, column)
+		}
+	}
+
+	return unfilled
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func quotedColumns(columns []string) string {
+	quoted := []string{}
+	for _, column := range columns {
+		quoted = append(quoted, fmt.Sprintf("`%s`", column))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// replaceIntoGhostStatement returns the statement that a trigger runs to write the new values of a row to the
+// shadow table
+func replaceIntoGhostStatement(s shadowTable, columns []shadowColumn) string {
+	ghostColumns := []string{}
+	values := []string{}
+	for _, column := range columns {
+		ghostColumns = append(ghostColumns, column.GhostName)
+		values = append(values, fmt.Sprintf("new.`%s`", column.Name))
+	}
+
+	return fmt.Sprintf("replace into `%s` (%s) values (%s)", s.ghostName(), quotedColumns(ghostColumns), strings.Join(values, ", "))
+}
+
+// deleteFromGhostStatement returns the statement that a trigger runs to remove the old row from the shadow table
+func deleteFromGhostStatement(s shadowTable, primaryKey []string) string {
+	conditions := []string{}
+	for _, column := range primaryKey {
+		conditions = append(conditions, fmt.Sprintf("`%s` <=> old.`%s`", column, column))
+	}
+
+	return fmt.Sprintf("delete from `%s` where %s", s.ghostName(), strings.Join(conditions, " and "))
+}
+
+// shadowTriggerStatements returns the statements to create the triggers that copy the writes to the table to
+// the shadow table while its rows are copied. An update deletes the old row first, because it can change the
+// primary key
+func shadowTriggerStatements(s shadowTable, columns []shadowColumn, primaryKey []string) []string {
+	replaceInto := replaceIntoGhostStatement(s, columns)
+	deleteFrom := deleteFromGhostStatement(s, primaryKey)
+
+	return []string{
+		fmt.Sprintf("create trigger `%s` after insert on `%s` for each row %s", s.triggerName("ins"), s.tableName, replaceInto),
+		fmt.Sprintf("create trigger `%s` after update on `%s` for each row begin %s; %s; end", s.triggerName("upd"), s.tableName, deleteFrom, replaceInto),
+		fmt.Sprintf("create trigger `%s` after delete on `%s` for each row %s", s.triggerName("del"), s.tableName, deleteFrom),
+	}
+}
+
+// chunkConditions returns the conditions on the primary key of the rows in a chunk, which starts after the
+// lower bound and ends with the upper bound
+func chunkConditions(primaryKey []string, hasLowerBound bool, hasUpperBound bool) []string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(primaryKey)), ", ")
+
+	conditions := []string{}
+	if hasLowerBound {
+		conditions = append(conditions, fmt.Sprintf("(%s) > (%s)", quotedColumns(primaryKey), placeholders))
+	}
+	if hasUpperBound {
+		conditions = append(conditions, fmt.Sprintf("(%s) <= (%s)", quotedColumns(primaryKey), placeholders))
+	}
+
+	return conditions
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return " where " + strings.Join(conditions, " and ")
+}
+
+// shadowCopyStatement returns the statement that copies a chunk of rows to the shadow table. Rows that the
+// triggers have already written are newer than the table's rows, so they're skipped. The rows of the chunk are
+// locked while they're copied, so the triggers don't write them in the meantime, and any other error, such as a
+// value that doesn't fit the changed column or a duplicate in a new unique key, fails the copy
+func shadowCopyStatement(s shadowTable, columns []shadowColumn, primaryKey []string, hasLowerBound bool, hasUpperBound bool) string {
+	names := []string{}
+	ghostNames := []string{}
+	for _, column := range columns {
+		names = append(names, column.Name)
+		ghostNames = append(ghostNames, column.GhostName)
+	}
+
+	primaryKeyMatches := []string{}
+	for _, column := range primaryKey {
+		primaryKeyMatches = append(primaryKeyMatches, fmt.Sprintf("`%s`.`%s` = `%s`.`%s`", s.ghostName(), column, s.tableName, column))
+	}
+	conditions := chunkConditions(primaryKey, hasLowerBound, hasUpperBound)
+	conditions = append(conditions, fmt.Sprintf("not exists (select 1 from `%s` where %s)", s.ghostName(), strings.Join(primaryKeyMatches, " and ")))
+
+	return fmt.Sprintf("insert into `%s` (%s) select %s from `%s`%s lock in share mode",
+		s.ghostName(), quotedColumns(ghostNames), quotedColumns(names), s.tableName, whereClause(conditions))
+}
+
+// shadowRowCountsStatement returns the statement that counts the rows of the table and the shadow table. The
+// counts are read in one statement, so they're from the same snapshot, and the triggers write to both tables
+// in the same transaction
+func shadowRowCountsStatement(s shadowTable) string {
+	return fmt.Sprintf("select (select count(1) from `%s`), (select count(1) from `%s`)", s.tableName, s.ghostName())
+}
+
+// shadowTableAlter makes the change to the table by copying it to a shadow table with the change, and then
+// replacing the table with the shadow table. Writes to the table aren't blocked while its rows are copied
+func shadowTableAlter(m *MysqlConnection, tableName string, clause string) error {
+	s := shadowTable{tableName: tableName}
+
+	primaryKey, err := m.primaryKeyColumns(tableName)
+	if err != nil {
+		return errors.Wrap(err, "failed to get primary key")
+	}
+	if len(primaryKey) == 0 {
+		return fmt.Errorf("table %s doesn't have a primary key to copy its rows by", tableName)
+	}
+
+	query := `select count(1) from information_schema.TRIGGERS where EVENT_OBJECT_SCHEMA = ? and EVENT_OBJECT_TABLE = ?`
+	row := m.db.QueryRow(query, m.databaseName, tableName)
+	triggerCount := 0
+	if err := row.Scan(&triggerCount); err != nil {
+		return errors.Wrap(err, "failed to count triggers")
+	}
+	if triggerCount > 0 {
+		return fmt.Errorf("table %s has triggers, and can't be copied with triggers", tableName)
+	}
+
+	// the shadow table is created without foreign keys, and the foreign keys of other tables would still
+	// reference the old table after the rename
+	query = `select count(1) from information_schema.REFERENTIAL_CONSTRAINTS where CONSTRAINT_SCHEMA = ? and (TABLE_NAME = ? or REFERENCED_TABLE_NAME = ?)`
+	row = m.db.QueryRow(query, m.databaseName, tableName, tableName)
+	foreignKeyCount := 0
+	if err := row.Scan(&foreignKeyCount); err != nil {
+		return errors.Wrap(err, "failed to count foreign keys")
+	}
+	if foreignKeyCount > 0 {
+		return fmt.Errorf("table %s has foreign keys, and can't be copied", tableName)
+	}
+
+	// the shadow tables are left behind when a copy doesn't finish
+	if err := executeStatements(m, []string{
+		fmt.Sprintf("drop table if exists `%s`, `%s`", s.ghostName(), s.oldName()),
+		fmt.Sprintf("create table `%s` like `%s`", s.ghostName(), tableName),
+		fmt.Sprintf("alter table `%s` %s", s.ghostName(), clause),
+	}); err != nil {
+		return errors.Wrap(err, "failed to create shadow table")
+	}
+
+	if err := copyToShadowTable(m, s, clause, primaryKey); err != nil {
+		cleanup := []string{
+			fmt.Sprintf("drop trigger if exists `%s`", s.triggerName("ins")),
+			fmt.Sprintf("drop trigger if exists `%s`", s.triggerName("upd")),
+			fmt.Sprintf("drop trigger if exists `%s`", s.triggerName("del")),
+			fmt.Sprintf("drop table if exists `%s`", s.ghostName()),
+		}
+		if cleanupErr := executeStatements(m, cleanup); cleanupErr != nil {
+			fmt.Printf("Failed to remove shadow table %q: %s\n", s.ghostName(), cleanupErr.Error())
+		}
+		return err
+	}
+
+	// the triggers are on the old table after the rename, and are dropped with it
+	if err := executeStatements(m, []string{
+		fmt.Sprintf("rename table `%s` to `%s`, `%s` to `%s`", tableName, s.oldName(), s.ghostName(), tableName),
+		fmt.Sprintf("drop table `%s`", s.oldName()),
+	}); err != nil {
+		return errors.Wrap(err, "failed to replace table with shadow table")
+	}
+
+	return nil
+}
+
+// copyToShadowTable creates the triggers on the table, and then copies its rows to the shadow table in chunks
+// of the primary key. The last chunk doesn't have an upper bound, and the rows that are inserted after it's
+// copied are written by the triggers. The tables must have the same number of rows when the copy is done
+func copyToShadowTable(m *MysqlConnection, s shadowTable, clause string, primaryKey []string) error {
+	ghostPrimaryKey, err := m.primaryKeyColumns(s.ghostName())
+	if err != nil {
+		return errors.Wrap(err, "failed to get shadow table primary key")
+	}
+	if strings.Join(ghostPrimaryKey, ",") != strings.Join(primaryKey, ",") {
+		return fmt.Errorf("the change to table %s changes its primary key, and can't be made with a copy", s.tableName)
+	}
+
+	columns, err := m.insertableColumns(s.tableName, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to get table columns")
+	}
+	ghostColumns, err := m.insertableColumns(s.ghostName(), false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get shadow table columns")
+	}
+	copyColumns := shadowCopyColumns(columns, ghostColumns, renamedColumns(clause))
+
+	// the triggers would fail the writes to the table if the shadow table has a column that they don't fill
+	requiredColumns, err := m.requiredColumns(s.ghostName())
+	if err != nil {
+		return errors.Wrap(err, "failed to get shadow table required columns")
+	}
+	if unfilled := unfilledShadowColumns(requiredColumns, copyColumns); len(unfilled) > 0 {
+		return fmt.Errorf("the change to table %s adds not null columns without a default (%s), and can't be made with a copy", s.tableName, strings.Join(unfilled, ", "))
+	}
+
+	if err := executeStatements(m, shadowTriggerStatements(s, copyColumns, primaryKey)); err != nil {
+		return errors.Wrap(err, "failed to create triggers")
+	}
+
+	var lowerBound []interface{}
+	for {
+		upperBound, err := m.chunkUpperBound(s.tableName, primaryKey, lowerBound)
+		if err != nil {
+			return errors.Wrap(err, "failed to get chunk bound")
+		}
+
+		args := append(append([]interface{}{}, lowerBound...), upperBound...)
+		statement := shadowCopyStatement(s, copyColumns, primaryKey, lowerBound != nil, upperBound != nil)
+		if _, err := m.db.ExecContext(context.Background(), statement, args...); err != nil {
+			return errors.Wrap(err, "failed to copy rows")
+		}
+
+		if upperBound == nil {
+			break
+		}
+		lowerBound = upperBound
+	}
+
+	var rowCount, ghostRowCount int64
+	if err := m.db.QueryRow(shadowRowCountsStatement(s)).Scan(&rowCount, &ghostRowCount); err != nil {
+		return errors.Wrap(err, "failed to count rows")
+	}
+	if rowCount != ghostRowCount {
+		return fmt.Errorf("table %s has %d rows, and %d were copied to the shadow table", s.tableName, rowCount, ghostRowCount)
+	}
+
+	return nil
+}
+
+// chunkUpperBound returns the primary key of the last row of the chunk that starts after the lower bound, or
+// nil when the chunk is the last one
+func (m *MysqlConnection) chunkUpperBound(tableName string, primaryKey []string, lowerBound []interface{}) ([]interface{}, error) {
+	query := fmt.Sprintf("select %s from `%s`%s order by %s limit 1 offset %d",
+		quotedColumns(primaryKey), tableName, whereClause(chunkConditions(primaryKey, lowerBound != nil, false)), quotedColumns(primaryKey), shadowCopyChunkSize-1)
+	rows, err := m.db.Query(query, lowerBound...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query chunk bound")
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get column types")
+	}
+
+	values := make([]interface{}, len(primaryKey))
+	dest := make([]interface{}, len(primaryKey))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, errors.Wrap(err, "failed to scan chunk bound")
+	}
+
+	for i, value := range values {
+		values[i] = primaryKeyArg(columnTypes[i].DatabaseTypeName(), value)
+	}
+
+	return values, nil
+}
+
+// primaryKeyArg returns the value of a primary key column as an argument that's compared to the column with its
+// type. Integers are compared as numbers, and strings with the collation of the column
+func primaryKeyArg(databaseTypeName string, value interface{}) interface{} {
+	b, ok := value.([]byte)
+	if !ok {
+		return value
+	}
+
+	if strings.HasSuffix(databaseTypeName, "INT") {
+		if strings.HasPrefix(databaseTypeName, "UNSIGNED") {
+			if u, err := strconv.ParseUint(string(b), 10, 64); err == nil {
+				return u
+			}
+		} else if i, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+			return i
+		}
+	}
+
+	return string(b)
+}
+
+// primaryKeyColumns returns the columns of the primary key of the table, in the order of the key
+func (m *MysqlConnection) primaryKeyColumns(tableName string) ([]string, error) {
+	query := `select COLUMN_NAME from information_schema.STATISTICS
+where TABLE_SCHEMA = ? and TABLE_NAME = ? and INDEX_NAME = 'PRIMARY'
+order by SEQ_IN_INDEX`
+	return m.queryColumnNames(query, tableName)
+}
+
+// insertableColumns returns the columns of the table in order. Generated columns can't be inserted into, and
+// are left out unless includeGenerated is set
+func (m *MysqlConnection) insertableColumns(tableName string, includeGenerated bool) ([]string, error) {
+	query := `select COLUMN_NAME from information_schema.COLUMNS
+where TABLE_SCHEMA = ? and TABLE_NAME = ?`
+	if !includeGenerated {
+		query += ` and coalesce(GENERATION_EXPRESSION, '') = ''`
+	}
+	query += ` order by ORDINAL_POSITION`
+
+	return m.queryColumnNames(query, tableName)
+}
+
+// requiredColumns returns the columns of the table that an insert must have a value for, because they're not
+// null and don't have a default
+func (m *MysqlConnection) requiredColumns(tableName string) ([]string, error) {
+	query := `select COLUMN_NAME from information_schema.COLUMNS
+where TABLE_SCHEMA = ? and TABLE_NAME = ? and IS_NULLABLE = 'NO' and COLUMN_DEFAULT is null
+and EXTRA not like '%auto_increment%' and coalesce(GENERATION_EXPRESSION, '') = ''
+order by ORDINAL_POSITION`
+	return m.queryColumnNames(query, tableName)
+}
+
+func (m *MysqlConnection) queryColumnNames(query string, tableName string) ([]string, error) {
+	rows, err := m.db.Query(query, m.databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query columns")
+	}
+	defer rows.Close()
+
+	columns := []string{}
+	for rows.Next() {
+		var column sql.NullString
+		if err := rows.Scan(&column); err != nil {
+			return nil, errors.Wrap(err, "failed to scan column")
+		}
+		columns = append(columns, column.String)
+	}
+
+	return columns, rows.Err()
+}
@@ -0,0 +1,155 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_onlineAlterStatements(t *testing.T) {
+	statements := onlineAlterStatements([]string{
+		"alter table `orders` add column `note` text",
+		"alter table orders convert to character set utf8mb4 collate utf8mb4_general_ci",
+		"alter table `orders` drop primary key",
+		"alter table `orders` add constraint `orders_pkey` primary key (`id`)",
+		"alter table orders add constraint orders_customer_id_fkey foreign key (customer_id) references customers (id)",
+		"alter table orders drop constraint orders_customer_id_fkey",
+		"insert into `orders` (`id`) values (1)",
+	})
+
+	assert.Equal(t, []string{
+		"alter table `orders` add column `note` text, algorithm=inplace, lock=none",
+		"alter table orders convert to character set utf8mb4 collate utf8mb4_general_ci, algorithm=inplace, lock=none",
+		"alter table `orders` drop primary key",
+		"alter table `orders` add constraint `orders_pkey` primary key (`id`)",
+		"alter table orders add constraint orders_customer_id_fkey foreign key (customer_id) references customers (id)",
+		"alter table orders drop constraint orders_customer_id_fkey",
+		"insert into `orders` (`id`) values (1)",
+	}, statements)
+}
+
+func Test_parseOnlineAlterStatement(t *testing.T) {
+	tests := []struct {
+		name              string
+		statement         string
+		expectedTableName string
+		expectedClause    string
+		expectedOK        bool
+	}{
+		{
+			name:              "quoted table name",
+			statement:         "alter table `orders` modify column `total` decimal (12, 2), algorithm=inplace, lock=none",
+			expectedTableName: "orders",
+			expectedClause:    "modify column `total` decimal (12, 2)",
+			expectedOK:        true,
+		},
+		{
+			name:              "unquoted table name",
+			statement:         "alter table orders convert to character set latin1 collate latin1_swedish_ci, algorithm=inplace, lock=none",
+			expectedTableName: "orders",
+			expectedClause:    "convert to character set latin1 collate latin1_swedish_ci",
+			expectedOK:        true,
+		},
+		{
+			name:      "not an online alter",
+			statement: "alter table `orders` drop column `note`",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tableName, clause, ok := parseOnlineAlterStatement(test.statement)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedTableName, tableName)
+			assert.Equal(t, test.expectedClause, clause)
+		})
+	}
+}
+
+func Test_shadowCopyColumns(t *testing.T) {
+	columns := shadowCopyColumns(
+		[]string{"id", "name", "note", "total"},
+		[]string{"id", "full_name", "total"},
+		renamedColumns("rename column `name` to `full_name`"),
+	)
+
+	assert.Equal(t, []shadowColumn{
+		{Name: "id", GhostName: "id"},
+		{Name: "name", GhostName: "full_name"},
+		{Name: "total", GhostName: "total"},
+	}, columns)
+}
+
+func Test_unfilledShadowColumns(t *testing.T) {
+	columns := []shadowColumn{
+		{Name: "id", GhostName: "id"},
+		{Name: "name", GhostName: "full_name"},
+	}
+
+	assert.Equal(t, []string{}, unfilledShadowColumns([]string{"id", "full_name"}, columns))
+	assert.Equal(t, []string{"note"}, unfilledShadowColumns([]string{"id", "note"}, columns))
+}
+
+func Test_shadowTriggerStatements(t *testing.T) {
+	s := shadowTable{tableName: "orders"}
+	columns := []shadowColumn{
+		{Name: "id", GhostName: "id"},
+		{Name: "total", GhostName: "total"},
+	}
+
+	assert.Equal(t, []string{
+		"create trigger `_orders_ins` after insert on `orders` for each row replace into `_orders_gho` (`id`, `total`) values (new.`id`, new.`total`)",
+		"create trigger `_orders_upd` after update on `orders` for each row begin delete from `_orders_gho` where `id` <=> old.`id`; replace into `_orders_gho` (`id`, `total`) values (new.`id`, new.`total`); end",
+		"create trigger `_orders_del` after delete on `orders` for each row delete from `_orders_gho` where `id` <=> old.`id`",
+	}, shadowTriggerStatements(s, columns, []string{"id"}))
+}
+
+func Test_shadowCopyStatement(t *testing.T) {
+	s := shadowTable{tableName: "orders"}
+	columns := []shadowColumn{
+		{Name: "tenant_id", GhostName: "tenant_id"},
+		{Name: "id", GhostName: "id"},
+	}
+	primaryKey := []string{"tenant_id", "id"}
+
+	tests := []struct {
+		name          string
+		hasLowerBound bool
+		hasUpperBound bool
+		expected      string
+	}{
+		{
+			name:          "first chunk",
+			hasUpperBound: true,
+			expected:      "insert into `_orders_gho` (`tenant_id`, `id`) select `tenant_id`, `id` from `orders` where (`tenant_id`, `id`) <= (?, ?) and not exists (select 1 from `_orders_gho` where `_orders_gho`.`tenant_id` = `orders`.`tenant_id` and `_orders_gho`.`id` = `orders`.`id`) lock in share mode",
+		},
+		{
+			name:          "middle chunk",
+			hasLowerBound: true,
+			hasUpperBound: true,
+			expected:      "insert into `_orders_gho` (`tenant_id`, `id`) select `tenant_id`, `id` from `orders` where (`tenant_id`, `id`) > (?, ?) and (`tenant_id`, `id`) <= (?, ?) and not exists (select 1 from `_orders_gho` where `_orders_gho`.`tenant_id` = `orders`.`tenant_id` and `_orders_gho`.`id` = `orders`.`id`) lock in share mode",
+		},
+		{
+			name:          "last chunk",
+			hasLowerBound: true,
+			expected:      "insert into `_orders_gho` (`tenant_id`, `id`) select `tenant_id`, `id` from `orders` where (`tenant_id`, `id`) > (?, ?) and not exists (select 1 from `_orders_gho` where `_orders_gho`.`tenant_id` = `orders`.`tenant_id` and `_orders_gho`.`id` = `orders`.`id`) lock in share mode",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, shadowCopyStatement(s, columns, primaryKey, test.hasLowerBound, test.hasUpperBound))
+		})
+	}
+}
+
+func Test_shadowRowCountsStatement(t *testing.T) {
+	assert.Equal(t, "select (select count(1) from `orders`), (select count(1) from `_orders_gho`)", shadowRowCountsStatement(shadowTable{tableName: "orders"}))
+}
+
+func Test_primaryKeyArg(t *testing.T) {
+	assert.Equal(t, int64(-12), primaryKeyArg("BIGINT", []byte("-12")))
+	assert.Equal(t, uint64(18446744073709551615), primaryKeyArg("UNSIGNED BIGINT", []byte("18446744073709551615")))
+	assert.Equal(t, "abc", primaryKeyArg("VARCHAR", []byte("abc")))
+	assert.Equal(t, int64(7), primaryKeyArg("INT", int64(7)))
+}
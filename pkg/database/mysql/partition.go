@@ -0,0 +1,229 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// partitionStrategy returns the strategy in lower case with single spaces, such as "range columns"
+func partitionStrategy(strategy string) string {
+	return strings.ToLower(strings.Join(strings.Fields(strategy), " "))
+}
+
+// isHashStrategy returns true for the strategies that assign rows to a number of partitions
+func isHashStrategy(strategy string) bool {
+	strategy = partitionStrategy(strategy)
+	return strings.HasSuffix(strategy, "hash") || strings.HasSuffix(strategy, "key")
+}
+
+// ValidatePartitioning returns an error when the strategy isn't known, or when a partition doesn't have the
+// bound that the strategy needs
+func ValidatePartitioning(partitioning *schemasv1alpha4.MysqlTablePartitioning) error {
+	strategy := partitionStrategy(partitioning.Strategy)
+	switch strategy {
+	case "range", "range columns", "list", "list columns":
+		for _, partition := range partitioning.Partitions {
+			if strings.HasPrefix(strategy, "range") && partition.LessThan == "" {
+				return fmt.Errorf("range partition %q requires lessThan", partition.Name)
+			}
+			if strings.HasPrefix(strategy, "list") && len(partition.In) == 0 {
+				return fmt.Errorf("list partition %q requires in", partition.Name)
+			}
+		}
+		if len(partitioning.Partitions) == 0 {
+			return fmt.Errorf("%s partitioning requires partitions", strategy)
+		}
+	case "hash", "linear hash", "key", "linear key":
+		if len(partitioning.Partitions) > 0 {
+			return fmt.Errorf("%s partitioning has a count of partitions, and can't list them", strategy)
+		}
+	default:
+		return fmt.Errorf("partitioning strategy %q is not range, list, hash or key", partitioning.Strategy)
+	}
+
+	return nil
+}
+
+// partitionBound returns the bound of the partition, or an empty string for the partitions of hash and key
+// partitioned tables
+func partitionBound(strategy string, partition *schemasv1alpha4.MysqlTablePartition) string {
+	strategy = partitionStrategy(strategy)
+	if strings.HasPrefix(strategy, "range") {
+		if strings.EqualFold(partition.LessThan, "maxvalue") {
+			return "values less than maxvalue"
+		}
+		return fmt.Sprintf("values less than (%s)", partition.LessThan)
+	}
+	if strings.HasPrefix(strategy, "list") {
+		return fmt.Sprintf("values in (%s)", strings.Join(partition.In, ", "))
+	}
+
+	return ""
+}
+
+func partitionDefinition(partition *types.Partition) string {
+	return fmt.Sprintf("partition `%s` %s", partition.Name, partition.Bound)
+}
+
+// partitionCount returns the number of partitions of a hash or key partitioned table. Mysql creates one
+// partition when the count isn't set
+func partitionCount(partitioning *schemasv1alpha4.MysqlTablePartitioning) int {
+	if partitioning.Count < 1 {
+		return 1
+	}
+
+	return partitioning.Count
+}
+
+func mysqlSchemaPartitioningToPartitioning(partitioning *schemasv1alpha4.MysqlTablePartitioning) *types.Partitioning {
+	desired := types.Partitioning{
+		Strategy:   partitioning.Strategy,
+		Key:        partitioning.Key,
+		Partitions: []*types.Partition{},
+	}
+	for _, partition := range partitioning.Partitions {
+		desired.Partitions = append(desired.Partitions, &types.Partition{
+			Name:  partition.Name,
+			Bound: partitionBound(partitioning.Strategy, partition),
+		})
+	}
+
+	return &desired
+}
+
+func partitionByClause(partitioning *schemasv1alpha4.MysqlTablePartitioning) string {
+	clause := fmt.Sprintf("partition by %s (%s)", partitionStrategy(partitioning.Strategy), partitioning.Key)
+	if isHashStrategy(partitioning.Strategy) {
+		return fmt.Sprintf("%s partitions %d", clause, partitionCount(partitioning))
+	}
+
+	definitions := []string{}
+	for _, partition := range mysqlSchemaPartitioningToPartitioning(partitioning).Partitions {
+		definitions = append(definitions, partitionDefinition(partition))
+	}
+
+	return fmt.Sprintf("%s (%s)", clause, strings.Join(definitions, ", "))
+}
+
+// buildPartitionStatements returns the statements to change the partitions of the table. The partitions of a
+// table without partitioning in the spec aren't changed
+func buildPartitionStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
+	if mysqlTableSchema.Partitioning == nil {
+		return []string{}, nil
+	}
+
+	if err := ValidatePartitioning(mysqlTableSchema.Partitioning); err != nil {
+		return nil, errors.Wrap(err, "invalid partitioning")
+	}
+
+	currentPartitioning, err := m.GetTablePartitioning(m.databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get table partitioning")
+	}
+
+	return partitionChangeStatements(tableName, mysqlTableSchema.Partitioning, currentPartitioning), nil
+}
+
+// partitionChangeStatements returns the statements to make the partitions of the table match the spec. A
+// table that isn't partitioned the same way is partitioned again, which rebuilds it. Partitions with a changed
+// bound are reorganized so that their rows are kept
+func partitionChangeStatements(tableName string, partitioning *schemasv1alpha4.MysqlTablePartitioning, currentPartitioning *types.Partitioning) []string {
+	desired := mysqlSchemaPartitioningToPartitioning(partitioning)
+	if !currentPartitioning.KeyEquals(desired) {
+		return []string{
+			fmt.Sprintf("alter table `%s` %s", tableName, partitionByClause(partitioning)),
+		}
+	}
+
+	if isHashStrategy(partitioning.Strategy) {
+		difference := partitionCount(partitioning) - len(currentPartitioning.Partitions)
+		if difference > 0 {
+			return []string{fmt.Sprintf("alter table `%s` add partition partitions %d", tableName, difference)}
+		} else if difference < 0 {
+			return []string{fmt.Sprintf("alter table `%s` coalesce partition %d", tableName, -difference)}
+		}
+		return []string{}
+	}
+
+	statements := []string{}
+
+	removed := []string{}
+	for _, currentPartition := range currentPartitioning.Partitions {
+		if desired.FindPartition(currentPartition.Name) == nil {
+			removed = append(removed, fmt.Sprintf("`%s`", currentPartition.Name))
+		}
+	}
+	if len(removed) > 0 {
+		statements = append(statements, fmt.Sprintf("alter table `%s` drop partition %s", tableName, strings.Join(removed, ", ")))
+	}
+
+	added := []string{}
+	for _, desiredPartition := range desired.Partitions {
+		currentPartition := currentPartitioning.FindPartition(desiredPartition.Name)
+		if currentPartition == nil {
+			added = append(added, partitionDefinition(desiredPartition))
+			continue
+		}
+
+		if !currentPartition.Equals(desiredPartition) {
+			statements = append(statements, fmt.Sprintf("alter table `%s` reorganize partition `%s` into (%s)", tableName, desiredPartition.Name, partitionDefinition(desiredPartition)))
+		}
+	}
+	if len(added) > 0 {
+		statements = append(statements, fmt.Sprintf("alter table `%s` add partition (%s)", tableName, strings.Join(added, ", ")))
+	}
+
+	return statements
+}
+
+// GetTablePartitioning returns the partitioning of the table, or nil when it isn't partitioned
+func (m *MysqlConnection) GetTablePartitioning(databaseName string, tableName string) (*types.Partitioning, error) {
+	query := `select PARTITION_NAME, PARTITION_METHOD, PARTITION_EXPRESSION, PARTITION_DESCRIPTION
+	from information_schema.PARTITIONS
+	where TABLE_SCHEMA = ?
+	and TABLE_NAME = ?
+	and PARTITION_NAME is not null
+	order by PARTITION_ORDINAL_POSITION`
+	rows, err := m.db.Query(query, databaseName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query partitions")
+	}
+	defer rows.Close()
+
+	var partitioning *types.Partitioning
+	for rows.Next() {
+		var name, method, expression string
+		var description sql.NullString
+		if err := rows.Scan(&name, &method, &expression, &description); err != nil {
+			return nil, errors.Wrap(err, "failed to scan partition")
+		}
+
+		if partitioning == nil {
+			partitioning = &types.Partitioning{
+				Strategy:   partitionStrategy(method),
+				Key:        expression,
+				Partitions: []*types.Partition{},
+			}
+		}
+
+		// the description is the value of a range partition or the values of a list partition, as
+		// mysql prints them
+		partition := types.Partition{
+			Name: name,
+		}
+		if strings.HasPrefix(partitioning.Strategy, "range") {
+			partition.Bound = fmt.Sprintf("values less than (%s)", description.String)
+		} else if strings.HasPrefix(partitioning.Strategy, "list") {
+			partition.Bound = fmt.Sprintf("values in (%s)", description.String)
+		}
+
+		partitioning.Partitions = append(partitioning.Partitions, &partition)
+	}
+
+	return partitioning, nil
+}
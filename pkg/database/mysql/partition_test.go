@@ -0,0 +1,119 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_partitionChangeStatements(t *testing.T) {
+	tests := []struct {
+		name                string
+		partitioning        *schemasv1alpha4.MysqlTablePartitioning
+		currentPartitioning *types.Partitioning
+		expectedStatements  []string
+	}{
+		{
+			name: "unchanged partitions",
+			partitioning: &schemasv1alpha4.MysqlTablePartitioning{
+				Strategy: "range columns",
+				Key:      "created_at",
+				Partitions: []*schemasv1alpha4.MysqlTablePartition{
+					{Name: "p2024", LessThan: "'2025-01-01'"},
+					{Name: "pmax", LessThan: "MAXVALUE"},
+				},
+			},
+			currentPartitioning: &types.Partitioning{
+				Strategy: "range columns",
+				Key:      "`created_at`",
+				Partitions: []*types.Partition{
+					{Name: "p2024", Bound: "values less than ('2025-01-01')"},
+					{Name: "pmax", Bound: "values less than (MAXVALUE)"},
+				},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "table isn't partitioned",
+			partitioning: &schemasv1alpha4.MysqlTablePartitioning{
+				Strategy: "list",
+				Key:      "region_id",
+				Partitions: []*schemasv1alpha4.MysqlTablePartition{
+					{Name: "p_eu", In: []string{"1", "2"}},
+				},
+			},
+			expectedStatements: []string{
+				"alter table `orders` partition by list (region_id) (partition `p_eu` values in (1, 2))",
+			},
+		},
+		{
+			name: "added, removed and changed partitions",
+			partitioning: &schemasv1alpha4.MysqlTablePartitioning{
+				Strategy: "list",
+				Key:      "region_id",
+				Partitions: []*schemasv1alpha4.MysqlTablePartition{
+					{Name: "p_eu", In: []string{"1", "2", "3"}},
+					{Name: "p_apac", In: []string{"5"}},
+				},
+			},
+			currentPartitioning: &types.Partitioning{
+				Strategy: "list",
+				Key:      "`region_id`",
+				Partitions: []*types.Partition{
+					{Name: "p_eu", Bound: "values in (1,2)"},
+					{Name: "p_us", Bound: "values in (4)"},
+				},
+			},
+			expectedStatements: []string{
+				"alter table `orders` drop partition `p_us`",
+				"alter table `orders` reorganize partition `p_eu` into (partition `p_eu` values in (1, 2, 3))",
+				"alter table `orders` add partition (partition `p_apac` values in (5))",
+			},
+		},
+		{
+			name: "more hash partitions",
+			partitioning: &schemasv1alpha4.MysqlTablePartitioning{
+				Strategy: "hash",
+				Key:      "id",
+				Count:    4,
+			},
+			currentPartitioning: &types.Partitioning{
+				Strategy: "hash",
+				Key:      "`id`",
+				Partitions: []*types.Partition{
+					{Name: "p0"},
+					{Name: "p1"},
+				},
+			},
+			expectedStatements: []string{
+				"alter table `orders` add partition partitions 2",
+			},
+		},
+		{
+			name: "fewer hash partitions",
+			partitioning: &schemasv1alpha4.MysqlTablePartitioning{
+				Strategy: "hash",
+				Key:      "id",
+			},
+			currentPartitioning: &types.Partitioning{
+				Strategy: "hash",
+				Key:      "`id`",
+				Partitions: []*types.Partition{
+					{Name: "p0"},
+					{Name: "p1"},
+				},
+			},
+			expectedStatements: []string{
+				"alter table `orders` coalesce partition 1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatements, partitionChangeStatements("orders", test.partitioning, test.currentPartitioning))
+		})
+	}
+}
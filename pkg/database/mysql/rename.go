@@ -0,0 +1,34 @@
+package mysql
+
+import (
+	"fmt"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// RenameTableStatement returns the statement to rename an existing table to its desired name
+func RenameTableStatement(existingName string, desiredName string) string {
+	return fmt.Sprintf("alter table `%s` rename to `%s`", existingName, desiredName)
+}
+
+// withGeneratedNames returns a copy of the schema with the index and foreign key names that are generated from
+// the table name set explicitly. A renamed table is planned under its existing name, so this keeps the names
+// of its indexes and foreign keys the same as they will be when it's planned under the new name
+func withGeneratedNames(tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) *schemasv1alpha4.MysqlTableSchema {
+	named := mysqlTableSchema.DeepCopy()
+
+	for _, index := range named.Indexes {
+		if index.Name == "" {
+			index.Name = types.GenerateMysqlIndexName(tableName, index)
+		}
+	}
+
+	for _, foreignKey := range named.ForeignKeys {
+		if foreignKey.Name == "" {
+			foreignKey.Name = types.GenerateMysqlFKName(tableName, foreignKey)
+		}
+	}
+
+	return named
+}
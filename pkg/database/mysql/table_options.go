@@ -0,0 +1,164 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// tableOptions are the options of an existing table. RowFormat is default when the table was created without
+// one, and AutoIncrement is 0 when the table doesn't have an auto increment column
+type tableOptions struct {
+	Engine        string
+	RowFormat     string
+	KeyBlockSize  int
+	AutoIncrement int64
+}
+
+// tableEngines are the storage engines that a table can use
+var tableEngines = []string{"innodb", "myisam", "aria", "memory", "archive", "csv", "blackhole"}
+
+// tableRowFormats are the row formats that a table can use. Page is only supported by aria
+var tableRowFormats = []string{"default", "dynamic", "compact", "redundant", "compressed", "fixed", "page"}
+
+// ValidateTableOptions returns an error when the engine or the row format of the table isn't known. They're
+// written into the statements as they are, so they can't be anything else
+func ValidateTableOptions(tableSchema *schemasv1alpha4.MysqlTableSchema) error {
+	if err := ValidateEngine(tableSchema.Engine); err != nil {
+		return err
+	}
+
+	return ValidateRowFormat(tableSchema.RowFormat)
+}
+
+// ValidateEngine returns an error when the engine is set and isn't known
+func ValidateEngine(engine string) error {
+	if engine != "" && !containsString(tableEngines, strings.ToLower(engine)) {
+		return fmt.Errorf("engine %q is not %s", engine, strings.Join(tableEngines, ", "))
+	}
+
+	return nil
+}
+
+// ValidateRowFormat returns an error when the row format is set and isn't known
+func ValidateRowFormat(rowFormat string) error {
+	if rowFormat != "" && !containsString(tableRowFormats, strings.ToLower(rowFormat)) {
+		return fmt.Errorf("row format %q is not %s", rowFormat, strings.Join(tableRowFormats, ", "))
+	}
+
+	return nil
+}
+
+// createTableOptionClauses returns the clauses of the options that are set in the spec, for a new table
+func createTableOptionClauses(tableSchema *schemasv1alpha4.MysqlTableSchema) []string {
+	clauses := []string{}
+	if tableSchema.Engine != "" {
+		clauses = append(clauses, fmt.Sprintf("engine %s", tableSchema.Engine))
+	}
+	if tableSchema.RowFormat != "" {
+		clauses = append(clauses, fmt.Sprintf("row_format %s", strings.ToLower(tableSchema.RowFormat)))
+	}
+	if tableSchema.KeyBlockSize > 0 {
+		clauses = append(clauses, fmt.Sprintf("key_block_size %d", tableSchema.KeyBlockSize))
+	}
+	if tableSchema.AutoIncrementStart > 0 {
+		clauses = append(clauses, fmt.Sprintf("auto_increment %d", tableSchema.AutoIncrementStart))
+	}
+
+	return clauses
+}
+
+// buildTableOptionsStatements returns the statement to change the options of the table that are set in the spec
+// and don't match it. The charset, collation and comment are changed separately
+func buildTableOptionsStatements(m *MysqlConnection, tableName string, mysqlTableSchema *schemasv1alpha4.MysqlTableSchema) ([]string, error) {
+	if len(createTableOptionClauses(mysqlTableSchema)) == 0 {
+		return []string{}, nil
+	}
+
+	if err := ValidateTableOptions(mysqlTableSchema); err != nil {
+		return nil, errors.Wrap(err, "invalid table options")
+	}
+
+	currentOptions, err := m.getTableOptions(tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get table options")
+	}
+
+	return tableOptionsChangeStatements(tableName, mysqlTableSchema, currentOptions), nil
+}
+
+func tableOptionsChangeStatements(tableName string, tableSchema *schemasv1alpha4.MysqlTableSchema, currentOptions *tableOptions) []string {
+	clauses := []string{}
+	if tableSchema.Engine != "" && !strings.EqualFold(tableSchema.Engine, currentOptions.Engine) {
+		clauses = append(clauses, fmt.Sprintf("engine %s", tableSchema.Engine))
+	}
+	if tableSchema.RowFormat != "" && !strings.EqualFold(tableSchema.RowFormat, currentOptions.RowFormat) {
+		clauses = append(clauses, fmt.Sprintf("row_format %s", strings.ToLower(tableSchema.RowFormat)))
+	}
+	if tableSchema.KeyBlockSize > 0 && tableSchema.KeyBlockSize != currentOptions.KeyBlockSize {
+		clauses = append(clauses, fmt.Sprintf("key_block_size %d", tableSchema.KeyBlockSize))
+	}
+
+	// mysql doesn't lower the counter below the next value of the column, so it's only raised
+	if tableSchema.AutoIncrementStart > 0 && currentOptions.AutoIncrement > 0 && currentOptions.AutoIncrement < tableSchema.AutoIncrementStart {
+		clauses = append(clauses, fmt.Sprintf("auto_increment %d", tableSchema.AutoIncrementStart))
+	}
+
+	if len(clauses) == 0 {
+		return []string{}
+	}
+
+	return []string{
+		fmt.Sprintf("alter table `%s` %s", tableName, strings.Join(clauses, ", ")),
+	}
+}
+
+// parseCreateOptions returns the options in the CREATE_OPTIONS of a table, such as
+// "row_format=COMPRESSED KEY_BLOCK_SIZE=8", keyed by their names in lower case
+func parseCreateOptions(createOptions string) map[string]string {
+	options := map[string]string{}
+	for _, option := range strings.Fields(createOptions) {
+		nameAndValue := strings.SplitN(option, "=", 2)
+		if len(nameAndValue) != 2 {
+			continue
+		}
+		options[strings.ToLower(nameAndValue[0])] = nameAndValue[1]
+	}
+
+	return options
+}
+
+func (m *MysqlConnection) getTableOptions(tableName string) (*tableOptions, error) {
+	query := `select ENGINE, CREATE_OPTIONS, AUTO_INCREMENT from information_schema.TABLES where TABLE_SCHEMA = ? and TABLE_NAME = ?`
+	row := m.db.QueryRow(query, m.databaseName, tableName)
+
+	var engine, createOptions sql.NullString
+	var autoIncrement sql.NullInt64
+	if err := row.Scan(&engine, &createOptions, &autoIncrement); err != nil {
+		return nil, errors.Wrap(err, "failed to scan table options")
+	}
+
+	// the row format and key block size are in the create options when they were set on the table
+	options := parseCreateOptions(createOptions.String)
+	currentOptions := tableOptions{
+		Engine:        engine.String,
+		RowFormat:     "default",
+		AutoIncrement: autoIncrement.Int64,
+	}
+	if rowFormat, ok := options["row_format"]; ok {
+		currentOptions.RowFormat = strings.ToLower(rowFormat)
+	}
+	if keyBlockSize, ok := options["key_block_size"]; ok {
+		size, err := strconv.Atoi(keyBlockSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse key block size %q", keyBlockSize)
+		}
+		currentOptions.KeyBlockSize = size
+	}
+
+	return &currentOptions, nil
+}
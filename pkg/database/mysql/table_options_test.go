@@ -0,0 +1,128 @@
+package mysql
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tableOptionsChangeStatements(t *testing.T) {
+	tests := []struct {
+		name               string
+		tableSchema        *schemasv1alpha4.MysqlTableSchema
+		currentOptions     *tableOptions
+		expectedStatements []string
+	}{
+		{
+			name:               "no options in the spec",
+			tableSchema:        &schemasv1alpha4.MysqlTableSchema{},
+			currentOptions:     &tableOptions{Engine: "MyISAM", RowFormat: "default", AutoIncrement: 10},
+			expectedStatements: []string{},
+		},
+		{
+			name: "unchanged options",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				Engine:             "innodb",
+				RowFormat:          "Compressed",
+				KeyBlockSize:       8,
+				AutoIncrementStart: 100,
+			},
+			currentOptions:     &tableOptions{Engine: "InnoDB", RowFormat: "compressed", KeyBlockSize: 8, AutoIncrement: 250},
+			expectedStatements: []string{},
+		},
+		{
+			name: "myisam to innodb",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				Engine:    "InnoDB",
+				RowFormat: "dynamic",
+			},
+			currentOptions: &tableOptions{Engine: "MyISAM", RowFormat: "default"},
+			expectedStatements: []string{
+				"alter table `orders` engine InnoDB, row_format dynamic",
+			},
+		},
+		{
+			name: "compressed with a key block size",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				RowFormat:    "compressed",
+				KeyBlockSize: 4,
+			},
+			currentOptions: &tableOptions{Engine: "InnoDB", RowFormat: "compressed", KeyBlockSize: 8},
+			expectedStatements: []string{
+				"alter table `orders` key_block_size 4",
+			},
+		},
+		{
+			name: "auto increment is raised",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				AutoIncrementStart: 1000,
+			},
+			currentOptions: &tableOptions{Engine: "InnoDB", RowFormat: "default", AutoIncrement: 1},
+			expectedStatements: []string{
+				"alter table `orders` auto_increment 1000",
+			},
+		},
+		{
+			name: "auto increment isn't lowered",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{
+				AutoIncrementStart: 1000,
+			},
+			currentOptions:     &tableOptions{Engine: "InnoDB", RowFormat: "default", AutoIncrement: 5000},
+			expectedStatements: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatements, tableOptionsChangeStatements("orders", test.tableSchema, test.currentOptions))
+		})
+	}
+}
+
+func Test_ValidateTableOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		tableSchema *schemasv1alpha4.MysqlTableSchema
+		expectErr   bool
+	}{
+		{
+			name:        "no options",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{},
+		},
+		{
+			name:        "known engine and row format",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{Engine: "InnoDB", RowFormat: "COMPRESSED"},
+		},
+		{
+			name:        "unknown engine",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{Engine: "InnoDB; drop table users"},
+			expectErr:   true,
+		},
+		{
+			name:        "unknown row format",
+			tableSchema: &schemasv1alpha4.MysqlTableSchema{RowFormat: "tiny"},
+			expectErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateTableOptions(test.tableSchema)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_parseCreateOptions(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"row_format":     "COMPRESSED",
+		"key_block_size": "8",
+	}, parseCreateOptions("row_format=COMPRESSED KEY_BLOCK_SIZE=8 partitioned"))
+
+	assert.Equal(t, map[string]string{}, parseCreateOptions(""))
+}
@@ -21,6 +21,7 @@ func (m *MysqlConnection) ListTables() ([]*types.Table, error) {
 	query = `select
 t.table_name,
 t.TABLE_COLLATION,
+t.TABLE_COMMENT,
 c.character_set_name FROM information_schema.TABLES t,
 information_schema.COLLATION_CHARACTER_SET_APPLICABILITY c
 WHERE c.collation_name = t.table_collation
@@ -34,13 +35,14 @@ AND t.table_schema = ?`
 
 	tables := []*types.Table{}
 	for rows.Next() {
-		var tableName, tableCollation, tableCharset string
-		if err := rows.Scan(&tableName, &tableCollation, &tableCharset); err != nil {
+		var tableName, tableCollation, tableComment, tableCharset string
+		if err := rows.Scan(&tableName, &tableCollation, &tableComment, &tableCharset); err != nil {
 			return nil, err
 		}
 
 		table := types.Table{
-			Name: tableName,
+			Name:    tableName,
+			Comment: tableComment,
 		}
 
 		if tableCollation != databaseDefaultCollation {
@@ -104,7 +106,7 @@ func (m *MysqlConnection) ListTableIndexes(databaseName string, tableName string
 
 func (m *MysqlConnection) ListTableForeignKeys(databaseName string, tableName string) ([]*types.ForeignKey, error) {
 	query := `select
-	kcu.COLUMN_NAME, kcu.CONSTRAINT_NAME, kcu.REFERENCED_TABLE_NAME, kcu.REFERENCED_COLUMN_NAME, rc.DELETE_RULE
+	kcu.COLUMN_NAME, kcu.CONSTRAINT_NAME, kcu.REFERENCED_TABLE_NAME, kcu.REFERENCED_COLUMN_NAME, rc.DELETE_RULE, rc.UPDATE_RULE, rc.MATCH_OPTION
 	from information_schema.KEY_COLUMN_USAGE kcu
 	inner join information_schema.TABLE_CONSTRAINTS tc
   	  on tc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
@@ -122,9 +124,9 @@ func (m *MysqlConnection) ListTableForeignKeys(databaseName string, tableName st
 
 	foreignKeys := make([]*types.ForeignKey, 0)
 	for rows.Next() {
-		var childColumn, parentColumn, parentTable, name, deleteRule string
+		var childColumn, parentColumn, parentTable, name, deleteRule, updateRule, matchOption string
 
-		if err := rows.Scan(&childColumn, &name, &parentTable, &parentColumn, &deleteRule); err != nil {
+		if err := rows.Scan(&childColumn, &name, &parentTable, &parentColumn, &deleteRule, &updateRule, &matchOption); err != nil {
 			return nil, err
 		}
 
@@ -132,14 +134,16 @@ func (m *MysqlConnection) ListTableForeignKeys(databaseName string, tableName st
 			Name:          name,
 			ParentTable:   parentTable,
 			OnDelete:      deleteRule,
+			OnUpdate:      updateRule,
+			Match:         matchOption,
 			ChildColumns:  []string{childColumn},
 			ParentColumns: []string{parentColumn},
 		}
 
 		for _, foundFk := range foreignKeys {
 			if foundFk.Name == name {
-				foundFk.ChildColumns = append(foreignKey.ChildColumns, childColumn)
-				foundFk.ParentColumns = append(foreignKey.ParentColumns, parentColumn)
+				foundFk.ChildColumns = append(foundFk.ChildColumns, childColumn)
+				foundFk.ParentColumns = append(foundFk.ParentColumns, parentColumn)
 
 				goto Appended
 			}
@@ -195,7 +199,7 @@ order by kcu.ORDINAL_POSITION`
 }
 
 func (m *MysqlConnection) GetTableSchema(tableName string) ([]*types.Column, error) {
-	query := `select COLUMN_NAME, COLUMN_DEFAULT, IS_NULLABLE, EXTRA, DATA_TYPE, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE
+	query := `select COLUMN_NAME, COLUMN_DEFAULT, IS_NULLABLE, EXTRA, DATA_TYPE, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE, COLUMN_COMMENT
 from information_schema.COLUMNS
 where TABLE_NAME = ?
 and TABLE_SCHEMA = ?
@@ -220,7 +224,7 @@ order by ORDINAL_POSITION`
 		var numericPrecision sql.NullInt64
 		var numericScale sql.NullInt64
 
-		if err := rows.Scan(&column.Name, &columnDefault, &isNullable, &extra, &column.DataType, &maxLength, &numericPrecision, &numericScale); err != nil {
+		if err := rows.Scan(&column.Name, &columnDefault, &isNullable, &extra, &column.DataType, &maxLength, &numericPrecision, &numericScale, &column.Comment); err != nil {
 			return nil, err
 		}
 
@@ -9,7 +9,7 @@ import (
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func AlterColumnStatements(tableName string, primaryKeys []string, desiredColumns []*schemasv1alpha4.PostgresqlTableColumn, existingColumn *types.Column) ([]string, error) {
+func AlterColumnStatements(schemaName string, tableName string, primaryKeys []string, desiredColumns []*schemasv1alpha4.PostgresqlTableColumn, existingColumn *types.Column) ([]string, error) {
 	alterStatement := fmt.Sprintf("alter column %s", pgx.Identifier{existingColumn.Name}.Sanitize())
 
 	// this could be an alter or a drop column command
@@ -20,8 +20,29 @@ func AlterColumnStatements(tableName string, primaryKeys []string, desiredColumn
 				return nil, err
 			}
 
+			identityStatements := alterIdentityStatements(schemaName, tableName, existingColumn, column)
+
 			if columnsMatch(*existingColumn, *column) {
-				return []string{}, nil
+				return identityStatements, nil
+			}
+
+			// a column that's no longer generated keeps its values, and its other changes are made after that.
+			// The expression of a generated column can't be altered, so it's dropped and added again
+			statements := []string{}
+			if !types.GeneratedEqual(existingColumn.Generated, column.Generated) {
+				if column.Generated == nil {
+					statements = append(statements, fmt.Sprintf(`alter table %s %s drop expression`, qualifiedIdentifier(schemaName, tableName), alterStatement))
+				} else {
+					insertStatement, err := InsertColumnStatement(schemaName, tableName, desiredColumn)
+					if err != nil {
+						return nil, err
+					}
+
+					return []string{
+						fmt.Sprintf(`alter table %s drop column %s`, qualifiedIdentifier(schemaName, tableName), pgx.Identifier{existingColumn.Name}.Sanitize()),
+						insertStatement,
+					}, nil
+				}
 			}
 
 			// If the request is to modify a column to add a not null contraint to an existing column
@@ -42,13 +63,11 @@ func AlterColumnStatements(tableName string, primaryKeys []string, desiredColumn
 					//   2. update values with default
 					//   3. set not null
 
-					statements := []string{}
-
 					// add default
 					if column.ColumnDefault != nil {
 						if existingColumn.ColumnDefault == nil || *existingColumn.ColumnDefault != *column.ColumnDefault {
 							localStatement := fmt.Sprintf("alter table %s alter column %s set default '%s'",
-								pgx.Identifier{tableName}.Sanitize(),
+								qualifiedIdentifier(schemaName, tableName),
 								pgx.Identifier{existingColumn.Name}.Sanitize(),
 								*column.ColumnDefault)
 							statements = append(statements, localStatement)
@@ -58,7 +77,7 @@ func AlterColumnStatements(tableName string, primaryKeys []string, desiredColumn
 					// update existing values
 					if column.ColumnDefault != nil {
 						localStatement := fmt.Sprintf("update %s set %s='%s' where %s is null",
-							pgx.Identifier{tableName}.Sanitize(),
+							qualifiedIdentifier(schemaName, tableName),
 							pgx.Identifier{existingColumn.Name}.Sanitize(),
 							*column.ColumnDefault,
 							pgx.Identifier{existingColumn.Name}.Sanitize())
@@ -67,11 +86,11 @@ func AlterColumnStatements(tableName string, primaryKeys []string, desiredColumn
 
 					// set not null
 					localStatement := fmt.Sprintf("alter table %s alter column %s set not null",
-						pgx.Identifier{tableName}.Sanitize(),
+						qualifiedIdentifier(schemaName, tableName),
 						pgx.Identifier{existingColumn.Name}.Sanitize())
 					statements = append(statements, localStatement)
 
-					return statements, nil
+					return append(statements, identityStatements...), nil
 				}
 			}
 
@@ -114,14 +133,16 @@ func AlterColumnStatements(tableName string, primaryKeys []string, desiredColumn
 
 			if len(changes) == 0 {
 				// no changes
-				return []string{}, nil
+				return append(statements, identityStatements...), nil
 			}
 
-			return []string{fmt.Sprintf(`alter table %s %s`, pgx.Identifier{tableName}.Sanitize(), strings.Join(changes, ", "))}, nil
+			// the identity is changed after the default, so a serial column's sequence is no longer used
+			statements = append(statements, fmt.Sprintf(`alter table %s %s`, qualifiedIdentifier(schemaName, tableName), strings.Join(changes, ", ")))
+			return append(statements, identityStatements...), nil
 		}
 	}
 
-	return []string{fmt.Sprintf(`alter table %s drop column %s`, pgx.Identifier{tableName}.Sanitize(), pgx.Identifier{existingColumn.Name}.Sanitize())}, nil
+	return []string{fmt.Sprintf(`alter table %s drop column %s`, qualifiedIdentifier(schemaName, tableName), pgx.Identifier{existingColumn.Name}.Sanitize())}, nil
 }
 
 func columnsMatch(col1 types.Column, col2 types.Column) bool {
@@ -158,6 +179,10 @@ func columnsMatch(col1 types.Column, col2 types.Column) bool {
 		return false
 	}
 
+	if !types.GeneratedEqual(col1.Generated, col2.Generated) {
+		return false
+	}
+
 	col1Constraints, col2Constraints := col1.Constraints, col2.Constraints
 	if col1Constraints == nil {
 		col1Constraints = &types.ColumnConstraints{}
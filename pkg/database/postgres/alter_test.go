@@ -42,9 +42,11 @@ func Test_ColumnsMatch(t *testing.T) {
 func Test_AlterColumnStatments(t *testing.T) {
 	defaultEleven := "11"
 	defaultEmpty := ""
+	seqDefault := "t_id_seq"
 
 	tests := []struct {
 		name               string
+		schemaName         string
 		tableName          string
 		desiredColumns     []*schemasv1alpha4.PostgresqlTableColumn
 		existingColumn     *types.Column
@@ -319,13 +321,130 @@ func Test_AlterColumnStatments(t *testing.T) {
 				`alter table "t" alter column "a" set not null`,
 			},
 		},
+		{
+			name:      "no change generated",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.PostgresqlTableColumn{
+				{
+					Name: "total",
+					Type: "numeric",
+					Generated: &schemasv1alpha4.PostgresqlTableColumnGenerated{
+						Expression: "price * quantity",
+					},
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "total",
+				DataType: "numeric",
+				Generated: &types.ColumnGenerated{
+					Expression: "(price * (quantity)::numeric)",
+					Stored:     true,
+				},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name:      "change generated expression",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.PostgresqlTableColumn{
+				{
+					Name: "total",
+					Type: "numeric",
+					Generated: &schemasv1alpha4.PostgresqlTableColumnGenerated{
+						Expression: "price * quantity * 2",
+					},
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "total",
+				DataType: "numeric",
+				Generated: &types.ColumnGenerated{
+					Expression: "(price * (quantity)::numeric)",
+					Stored:     true,
+				},
+			},
+			expectedStatements: []string{
+				`alter table "t" drop column "total"`,
+				`alter table "t" add column "total" numeric generated always as (price * quantity * 2) stored`,
+			},
+		},
+		{
+			name:      "no longer generated",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.PostgresqlTableColumn{
+				{
+					Name: "total",
+					Type: "numeric",
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "total",
+				DataType: "numeric",
+				Generated: &types.ColumnGenerated{
+					Expression: "(price * (quantity)::numeric)",
+					Stored:     true,
+				},
+			},
+			expectedStatements: []string{`alter table "t" alter column "total" drop expression`},
+		},
+		{
+			name:      "no longer generated, with a new type and default",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.PostgresqlTableColumn{
+				{
+					Name:    "total",
+					Type:    "bigint",
+					Default: &defaultEleven,
+				},
+			},
+			existingColumn: &types.Column{
+				Name:     "total",
+				DataType: "numeric",
+				Generated: &types.ColumnGenerated{
+					Expression: "(price * (quantity)::numeric)",
+					Stored:     true,
+				},
+			},
+			expectedStatements: []string{
+				`alter table "t" alter column "total" drop expression`,
+				`alter table "t" alter column "total" type bigint, alter column "total" set default '11'`,
+			},
+		},
+		{
+			name:      "serial to identity",
+			tableName: "t",
+			desiredColumns: []*schemasv1alpha4.PostgresqlTableColumn{
+				{
+					Name: "id",
+					Type: "integer",
+					Identity: &schemasv1alpha4.PostgresqlTableColumnIdentity{
+						Generated: "by default",
+					},
+				},
+			},
+			existingColumn: &types.Column{
+				Name:          "id",
+				DataType:      "integer",
+				ColumnDefault: &seqDefault,
+				OwnedSequence: "public.t_id_seq",
+				Constraints: &types.ColumnConstraints{
+					NotNull: &trueValue,
+				},
+			},
+			expectedStatements: []string{
+				`alter table "t" alter column "id" drop default`,
+				`drop sequence if exists public.t_id_seq`,
+				`alter table "t" alter column "id" add generated by default as identity`,
+				`select setval(pg_get_serial_sequence('"t"', 'id'), coalesce(max("id"), 0) + 1, false) from "t"`,
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			req := require.New(t)
 
-			generatedStatements, err := AlterColumnStatements(test.tableName, []string{}, test.desiredColumns, test.existingColumn)
+			generatedStatements, err := AlterColumnStatements(test.schemaName, test.tableName, []string{}, test.desiredColumns, test.existingColumn)
 			req.NoError(err)
 			assert.Equal(t, test.expectedStatements, generatedStatements)
 		})
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+func checkConstraintClause(check *schemasv1alpha4.PostgresqlTableCheck) string {
+	return fmt.Sprintf("constraint %s check (%s)", pgx.Identifier{check.Name}.Sanitize(), check.Expression)
+}
+
+// AddCheckStatements returns the statements to add the check constraint. A check that is not valid is added
+// without checking the existing rows, and then validated with a lock that doesn't block writes
+func AddCheckStatements(schemaName string, tableName string, check *schemasv1alpha4.PostgresqlTableCheck) []string {
+	table := qualifiedIdentifier(schemaName, tableName)
+	if !check.NotValid {
+		return []string{
+			fmt.Sprintf("alter table %s add %s", table, checkConstraintClause(check)),
+		}
+	}
+
+	return []string{
+		fmt.Sprintf("alter table %s add %s not valid", table, checkConstraintClause(check)),
+		fmt.Sprintf("alter table %s validate constraint %s", table, pgx.Identifier{check.Name}.Sanitize()),
+	}
+}
+
+// RemoveCheckStatement returns the statement to drop the check constraint. The constraint is already gone when
+// a column that it uses was dropped
+func RemoveCheckStatement(schemaName string, tableName string, check *types.Check) string {
+	return fmt.Sprintf("alter table %s drop constraint if exists %s", qualifiedIdentifier(schemaName, tableName), pgx.Identifier{check.Name}.Sanitize())
+}
+
+// BuildCheckStatements returns the statements to make the check constraints of the table match the spec. The
+// checks of a table without checks in the spec aren't changed
+func BuildCheckStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
+	if postgresTableSchema.Checks == nil {
+		return []string{}, nil
+	}
+
+	currentChecks, err := p.listTableChecks(postgresTableSchema.Schema, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list table checks")
+	}
+
+	// renaming a column renames it in the checks too
+	renames, err := p.listColumnRenames(postgresTableSchema.Schema, tableName, postgresTableSchema.Columns)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list column renames")
+	}
+	types.RenameCheckColumns(currentChecks, renames)
+
+	return checkChangeStatements(postgresTableSchema.Schema, tableName, postgresTableSchema.Checks, currentChecks), nil
+}
+
+func checkChangeStatements(schemaName string, tableName string, desiredChecks []*schemasv1alpha4.PostgresqlTableCheck, currentChecks []*types.Check) []string {
+	statements := []string{}
+	if desiredChecks == nil {
+		return statements
+	}
+
+	// changed checks are dropped and added again
+NextCurrentCheck:
+	for _, currentCheck := range currentChecks {
+		for _, desiredCheck := range desiredChecks {
+			if currentCheck.Equals(types.PostgresqlSchemaCheckToCheck(desiredCheck)) {
+				continue NextCurrentCheck
+			}
+		}
+
+		statements = append(statements, RemoveCheckStatement(schemaName, tableName, currentCheck))
+	}
+
+NextDesiredCheck:
+	for _, desiredCheck := range desiredChecks {
+		for _, currentCheck := range currentChecks {
+			if currentCheck.Equals(types.PostgresqlSchemaCheckToCheck(desiredCheck)) {
+				continue NextDesiredCheck
+			}
+		}
+
+		statements = append(statements, AddCheckStatements(schemaName, tableName, desiredCheck)...)
+	}
+
+	return statements
+}
+
+// listTableChecks returns the check constraints on the table. The not null constraints that postgres lists as
+// checks in information_schema aren't in pg_constraint, so they are left out
+func (p *PostgresConnection) listTableChecks(schemaName string, tableName string) ([]*types.Check, error) {
+	query := `select cc.constraint_name, cc.check_clause
+from information_schema.check_constraints cc
+join information_schema.table_constraints tc
+  on tc.constraint_schema = cc.constraint_schema and tc.constraint_name = cc.constraint_name
+where tc.constraint_type = 'CHECK'
+and tc.table_name = $1
+and ` + schemaMatches("tc.table_schema", 2) + `
+and exists (
+  select 1 from pg_constraint c
+  join pg_namespace n on n.oid = c.connamespace
+  where c.conname = cc.constraint_name and n.nspname = cc.constraint_schema and c.contype = 'c'
+)`
+	rows, err := p.conn.Query(context.Background(), query, tableName, schemaName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query check constraints")
+	}
+	defer rows.Close()
+
+	checks := []*types.Check{}
+	for rows.Next() {
+		check := types.Check{}
+		if err := rows.Scan(&check.Name, &check.Expression); err != nil {
+			return nil, errors.Wrap(err, "failed to scan check constraint")
+		}
+
+		checks = append(checks, &check)
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkChangeStatements(t *testing.T) {
+	tests := []struct {
+		name               string
+		schemaName         string
+		desiredChecks      []*schemasv1alpha4.PostgresqlTableCheck
+		currentChecks      []*types.Check
+		expectedStatements []string
+	}{
+		{
+			name: "unchanged check",
+			desiredChecks: []*schemasv1alpha4.PostgresqlTableCheck{
+				{Name: "price_positive", Expression: "price > 0"},
+			},
+			currentChecks: []*types.Check{
+				{Name: "price_positive", Expression: "((price > (0)::numeric))"},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "added check",
+			desiredChecks: []*schemasv1alpha4.PostgresqlTableCheck{
+				{Name: "price_positive", Expression: "price > 0"},
+			},
+			currentChecks: []*types.Check{},
+			expectedStatements: []string{
+				`alter table "products" add constraint "price_positive" check (price > 0)`,
+			},
+		},
+		{
+			name:       "added check not valid",
+			schemaName: "store",
+			desiredChecks: []*schemasv1alpha4.PostgresqlTableCheck{
+				{Name: "price_positive", Expression: "price > 0", NotValid: true},
+			},
+			currentChecks: []*types.Check{},
+			expectedStatements: []string{
+				`alter table "store"."products" add constraint "price_positive" check (price > 0) not valid`,
+				`alter table "store"."products" validate constraint "price_positive"`,
+			},
+		},
+		{
+			name: "changed and removed checks",
+			desiredChecks: []*schemasv1alpha4.PostgresqlTableCheck{
+				{Name: "price_positive", Expression: "price >= 0"},
+			},
+			currentChecks: []*types.Check{
+				{Name: "price_positive", Expression: "((price > (0)::numeric))"},
+				{Name: "name_set", Expression: "((length(name) > 0))"},
+			},
+			expectedStatements: []string{
+				`alter table "products" drop constraint if exists "price_positive"`,
+				`alter table "products" drop constraint if exists "name_set"`,
+				`alter table "products" add constraint "price_positive" check (price >= 0)`,
+			},
+		},
+		{
+			name:          "checks that aren't in the spec are kept",
+			desiredChecks: nil,
+			currentChecks: []*types.Check{
+				{Name: "price_positive", Expression: "((price > (0)::numeric))"},
+			},
+			expectedStatements: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatements, checkChangeStatements(test.schemaName, "products", test.desiredChecks, test.currentChecks))
+		})
+	}
+}
@@ -22,6 +22,27 @@ func schemaColumnToColumn(schemaColumn *schemasv1alpha4.PostgresqlTableColumn) (
 		}
 	}
 
+	// postgres only supports stored generated columns
+	if schemaColumn.Generated != nil {
+		column.Generated = &types.ColumnGenerated{
+			Expression: schemaColumn.Generated.Expression,
+			Stored:     true,
+		}
+	}
+
+	// identity columns are always not null
+	if schemaColumn.Identity != nil {
+		column.Identity = &types.ColumnIdentity{
+			Generation: identityGeneration(schemaColumn.Identity.Generated),
+			Options:    types.PostgresqlSchemaSequenceOptionsToSequenceOptions(schemaColumn.Identity.Sequence),
+		}
+
+		if column.Constraints == nil {
+			column.Constraints = &types.ColumnConstraints{}
+		}
+		column.Constraints.NotNull = &trueValue
+	}
+
 	requestedType := schemaColumn.Type
 
 	// split on the "[" character, which is only present in arrays
@@ -76,6 +97,14 @@ func columnAsInsert(column *schemasv1alpha4.PostgresqlTableColumn) (string, erro
 
 	formatted := fmt.Sprintf("%s %s%s", pgx.Identifier{column.Name}.Sanitize(), postgresColumn.DataType, arraySpecifier)
 
+	if postgresColumn.Generated != nil {
+		formatted = fmt.Sprintf("%s generated always as (%s) stored", formatted, postgresColumn.Generated.Expression)
+	}
+
+	if postgresColumn.Identity != nil {
+		formatted = fmt.Sprintf("%s %s", formatted, identityClause(postgresColumn.Identity))
+	}
+
 	if postgresColumn.Constraints != nil && postgresColumn.Constraints.NotNull != nil {
 		if *postgresColumn.Constraints.NotNull {
 			formatted = fmt.Sprintf("%s not null", formatted)
@@ -84,7 +113,7 @@ func columnAsInsert(column *schemasv1alpha4.PostgresqlTableColumn) (string, erro
 		}
 	}
 
-	if postgresColumn.ColumnDefault != nil {
+	if postgresColumn.ColumnDefault != nil && postgresColumn.Generated == nil {
 		value := stripOIDClass(*postgresColumn.ColumnDefault)
 		formatted = fmt.Sprintf("%s default '%s'", formatted, value)
 	}
@@ -92,13 +121,57 @@ func columnAsInsert(column *schemasv1alpha4.PostgresqlTableColumn) (string, erro
 	return formatted, nil
 }
 
-func InsertColumnStatement(tableName string, desiredColumn *schemasv1alpha4.PostgresqlTableColumn) (string, error) {
+func InsertColumnStatement(schemaName string, tableName string, desiredColumn *schemasv1alpha4.PostgresqlTableColumn) (string, error) {
 	columnFields, err := columnAsInsert(desiredColumn)
 	if err != nil {
 		return "", err
 	}
 
-	statement := fmt.Sprintf(`alter table %s add column %s`, pgx.Identifier{tableName}.Sanitize(), columnFields)
+	statement := fmt.Sprintf(`alter table %s add column %s`, qualifiedIdentifier(schemaName, tableName), columnFields)
 
 	return statement, nil
 }
+
+// RenameColumnStatement returns the statement to rename an existing column to its desired name
+func RenameColumnStatement(schemaName string, tableName string, existingName string, desiredName string) string {
+	return fmt.Sprintf(`alter table %s rename column %s to %s`,
+		qualifiedIdentifier(schemaName, tableName), pgx.Identifier{existingName}.Sanitize(), pgx.Identifier{desiredName}.Sanitize())
+}
+
+// columnsRenamedFrom returns the previous name of each desired column that was renamed, keyed by the desired name
+func columnsRenamedFrom(desiredColumns []*schemasv1alpha4.PostgresqlTableColumn) map[string]string {
+	renamedFrom := map[string]string{}
+	for _, desiredColumn := range desiredColumns {
+		if desiredColumn.RenamedFrom != "" {
+			renamedFrom[desiredColumn.Name] = desiredColumn.RenamedFrom
+		}
+	}
+
+	return renamedFrom
+}
+
+// ValidateColumn returns an error when the type of the column is not one that can be deployed to postgres
+func ValidateColumn(column *schemasv1alpha4.PostgresqlTableColumn) error {
+	if column.Generated != nil && column.Default != nil {
+		return fmt.Errorf("generated column %q cannot have a default", column.Name)
+	}
+
+	postgresColumn, err := schemaColumnToColumn(column)
+	if err != nil {
+		return err
+	}
+
+	if column.Identity != nil {
+		if column.Default != nil || column.Generated != nil {
+			return fmt.Errorf("identity column %q cannot have a default or be generated", column.Name)
+		}
+
+		switch postgresColumn.DataType {
+		case "smallint", "integer", "bigint":
+		default:
+			return fmt.Errorf("identity column %q must be a smallint, integer or bigint, not %q", column.Name, column.Type)
+		}
+	}
+
+	return nil
+}
@@ -77,6 +77,20 @@ func Test_columnAsInsert(t *testing.T) {
 			},
 			expectedStatement: `"c" text[]`,
 		},
+		{
+			name: "generated",
+			column: &schemasv1alpha4.PostgresqlTableColumn{
+				Name: "total",
+				Type: "numeric",
+				Generated: &schemasv1alpha4.PostgresqlTableColumnGenerated{
+					Expression: "price * quantity",
+				},
+				Constraints: &schemasv1alpha4.PostgresqlTableColumnConstraints{
+					NotNull: &trueValue,
+				},
+			},
+			expectedStatement: `"total" numeric generated always as (price * quantity) stored not null`,
+		},
 	}
 
 	for _, test := range tests {
@@ -93,6 +107,7 @@ func Test_columnAsInsert(t *testing.T) {
 func Test_InsertColumnStatement(t *testing.T) {
 	tests := []struct {
 		name              string
+		schemaName        string
 		tableName         string
 		desiredColumn     *schemasv1alpha4.PostgresqlTableColumn
 		expectedStatement string
@@ -130,19 +145,54 @@ func Test_InsertColumnStatement(t *testing.T) {
 			},
 			expectedStatement: `alter table "t" add column "a" integer null`,
 		},
+		{
+			name:       "add column in schema",
+			schemaName: "app",
+			tableName:  "t",
+			desiredColumn: &schemasv1alpha4.PostgresqlTableColumn{
+				Name: "a",
+				Type: "integer",
+			},
+			expectedStatement: `alter table "app"."t" add column "a" integer`,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			req := require.New(t)
 
-			generatedStatement, err := InsertColumnStatement(test.tableName, test.desiredColumn)
+			generatedStatement, err := InsertColumnStatement(test.schemaName, test.tableName, test.desiredColumn)
 			req.NoError(err)
 			assert.Equal(t, test.expectedStatement, generatedStatement)
 		})
 	}
 }
 
+func Test_RenameColumnStatement(t *testing.T) {
+	tests := []struct {
+		name              string
+		schemaName        string
+		tableName         string
+		existingName      string
+		desiredName       string
+		expectedStatement string
+	}{
+		{
+			name:              "rename column",
+			tableName:         "t",
+			existingName:      "email_address",
+			desiredName:       "email",
+			expectedStatement: `alter table "t" rename column "email_address" to "email"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatement, RenameColumnStatement(test.schemaName, test.tableName, test.existingName, test.desiredName))
+		})
+	}
+}
+
 func Test_schemaColumnToPostgresColumn(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// commentLiteral returns the comment as a string literal, or null when it's empty so that the comment is removed
+func commentLiteral(comment string) string {
+	if comment == "" {
+		return "null"
+	}
+
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(comment, "'", "''"))
+}
+
+func TableCommentStatement(schemaName string, tableName string, comment string) string {
+	return fmt.Sprintf("comment on table %s is %s", qualifiedIdentifier(schemaName, tableName), commentLiteral(comment))
+}
+
+func ColumnCommentStatement(schemaName string, tableName string, columnName string, comment string) string {
+	column := pgx.Identifier{tableName, columnName}.Sanitize()
+	if schemaName != "" {
+		column = pgx.Identifier{schemaName, tableName, columnName}.Sanitize()
+	}
+
+	return fmt.Sprintf("comment on column %s is %s", column, commentLiteral(comment))
+}
+
+// createCommentStatements returns the statements to set the comments of a table that's being created
+func createCommentStatements(tableName string, tableSchema *schemasv1alpha4.PostgresqlTableSchema) []string {
+	return commentChangeStatements(tableSchema.Schema, tableName, tableSchema, "", map[string]string{})
+}
+
+// BuildCommentStatements returns the statements to change the comments on the table and its columns. Only the
+// comments that are set in the spec are changed
+func BuildCommentStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
+	hasComments := postgresTableSchema.Comment != nil
+	for _, column := range postgresTableSchema.Columns {
+		if column.Comment != nil {
+			hasComments = true
+		}
+	}
+	if !hasComments {
+		return []string{}, nil
+	}
+
+	currentTableComment, currentColumnComments, err := p.getTableComments(postgresTableSchema.Schema, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get table comments")
+	}
+
+	return commentChangeStatements(postgresTableSchema.Schema, tableName, postgresTableSchema, currentTableComment, currentColumnComments), nil
+}
+
+func commentChangeStatements(schemaName string, tableName string, tableSchema *schemasv1alpha4.PostgresqlTableSchema, currentTableComment string, currentColumnComments map[string]string) []string {
+	statements := []string{}
+
+	if tableSchema.Comment != nil && *tableSchema.Comment != currentTableComment {
+		statements = append(statements, TableCommentStatement(schemaName, tableName, *tableSchema.Comment))
+	}
+
+	for _, column := range tableSchema.Columns {
+		if column.Comment == nil || *column.Comment == currentColumnComments[column.Name] {
+			continue
+		}
+
+		statements = append(statements, ColumnCommentStatement(schemaName, tableName, column.Name, *column.Comment))
+	}
+
+	return statements
+}
+
+// getTableComments returns the comment on the table, and the comments on its columns keyed by the column name.
+// Columns without a comment aren't in the map
+func (p *PostgresConnection) getTableComments(schemaName string, tableName string) (string, map[string]string, error) {
+	table := qualifiedIdentifier(schemaName, tableName)
+
+	query := `select coalesce(obj_description($1::regclass, 'pg_class'), '')`
+	row := p.conn.QueryRow(context.Background(), query, table)
+
+	var tableComment string
+	if err := row.Scan(&tableComment); err != nil {
+		return "", nil, errors.Wrap(err, "failed to scan table comment")
+	}
+
+	query = `select attname, col_description(attrelid, attnum)
+from pg_attribute
+where attrelid = $1::regclass
+and attnum > 0
+and not attisdropped
+and col_description(attrelid, attnum) is not null`
+	rows, err := p.conn.Query(context.Background(), query, table)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to query column comments")
+	}
+	defer rows.Close()
+
+	columnComments := map[string]string{}
+	for rows.Next() {
+		var columnName, comment string
+		if err := rows.Scan(&columnName, &comment); err != nil {
+			return "", nil, errors.Wrap(err, "failed to scan column comment")
+		}
+
+		columnComments[columnName] = comment
+	}
+
+	return tableComment, columnComments, nil
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_commentChangeStatements(t *testing.T) {
+	ordersComment := "Customer orders"
+	idComment := "The order's number"
+	empty := ""
+
+	tests := []struct {
+		name                  string
+		schemaName            string
+		tableSchema           *schemasv1alpha4.PostgresqlTableSchema
+		currentTableComment   string
+		currentColumnComments map[string]string
+		expectedStatements    []string
+	}{
+		{
+			name: "unchanged comments",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				Comment: &ordersComment,
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{Name: "id", Type: "integer", Comment: &idComment},
+				},
+			},
+			currentTableComment:   "Customer orders",
+			currentColumnComments: map[string]string{"id": "The order's number"},
+			expectedStatements:    []string{},
+		},
+		{
+			name:       "added comments",
+			schemaName: "sales",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				Comment: &ordersComment,
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{Name: "id", Type: "integer", Comment: &idComment},
+				},
+			},
+			currentColumnComments: map[string]string{},
+			expectedStatements: []string{
+				`comment on table "sales"."orders" is 'Customer orders'`,
+				`comment on column "sales"."orders"."id" is 'The order''s number'`,
+			},
+		},
+		{
+			name: "comments that aren't in the spec are kept",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{Name: "id", Type: "integer"},
+				},
+			},
+			currentTableComment:   "Customer orders",
+			currentColumnComments: map[string]string{"id": "The order's number"},
+			expectedStatements:    []string{},
+		},
+		{
+			name: "removed comments",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				Comment: &empty,
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{Name: "id", Type: "integer", Comment: &empty},
+				},
+			},
+			currentTableComment:   "Customer orders",
+			currentColumnComments: map[string]string{"id": "The order's number"},
+			expectedStatements: []string{
+				`comment on table "orders" is null`,
+				`comment on column "orders"."id" is null`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatements, commentChangeStatements(test.schemaName, "orders", test.tableSchema, test.currentTableComment, test.currentColumnComments))
+		})
+	}
+}
@@ -8,21 +8,21 @@ import (
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func RemoveConstrantStatement(tableName string, constraint *types.KeyConstraint) string {
+func RemoveConstrantStatement(schemaName string, tableName string, constraint *types.KeyConstraint) string {
 	if constraint == nil {
 		return ""
 	}
-	return fmt.Sprintf("alter table %s drop constraint %s", tableName, pgx.Identifier{constraint.Name}.Sanitize())
+	return fmt.Sprintf("alter table %s drop constraint %s", qualifiedName(schemaName, tableName), pgx.Identifier{constraint.Name}.Sanitize())
 }
 
-func AddConstrantStatement(tableName string, constraint *types.KeyConstraint) string {
+func AddConstrantStatement(schemaName string, tableName string, constraint *types.KeyConstraint) string {
 	if constraint == nil {
 		return ""
 	}
 	// `ALTER TABLE table_name ADD CONSTRAINT constraint_name PRIMARY KEY (index_col1, index_col2, ... index_col_n);
 	return fmt.Sprintf(
 		"alter table %s add constraint %s%s %s",
-		tableName,
+		qualifiedName(schemaName, tableName),
 		constraint.GenerateName(tableName),
 		primaryKeyClause(constraint),
 		constraintColumnClause(constraint),
@@ -9,6 +9,7 @@ import (
 func TestAddConstrantStatement(t *testing.T) {
 	tests := []struct {
 		name       string
+		schemaName string
 		tableName  string
 		constraint *types.KeyConstraint
 		want       string
@@ -42,7 +43,7 @@ func TestAddConstrantStatement(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := AddConstrantStatement(tt.tableName, tt.constraint); got != tt.want {
+			if got := AddConstrantStatement(tt.schemaName, tt.tableName, tt.constraint); got != tt.want {
 				t.Errorf("AddConstrantStatement() = %v, want %v", got, tt.want)
 			}
 		})
@@ -52,6 +53,7 @@ func TestAddConstrantStatement(t *testing.T) {
 func TestRemoveConstrantStatement(t *testing.T) {
 	tests := []struct {
 		name       string
+		schemaName string
 		tableName  string
 		constraint *types.KeyConstraint
 		want       string
@@ -68,7 +70,7 @@ func TestRemoveConstrantStatement(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := RemoveConstrantStatement(tt.tableName, tt.constraint); got != tt.want {
+			if got := RemoveConstrantStatement(tt.schemaName, tt.tableName, tt.constraint); got != tt.want {
 				t.Errorf("RemoveConstrantStatement() = %v, want %v", got, tt.want)
 			}
 		})
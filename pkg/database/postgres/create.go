@@ -16,6 +16,7 @@ func SeedDataStatements(tableName string, tableSchema *schemasv1alpha4.Postgresq
 	statements := []string{}
 
 	conflictInferenceSpec := findConflictInferenceSpec(tableName, tableSchema)
+	qualifiedTableName := qualifiedName(tableSchema.Schema, tableName)
 	for _, row := range seedData.Rows {
 		cols := []string{}
 		vals := []string{}
@@ -32,9 +33,9 @@ func SeedDataStatements(tableName string, tableSchema *schemasv1alpha4.Postgresq
 
 		var statement string
 		if conflictInferenceSpec != "" {
-			statement = fmt.Sprintf(`insert into %s (%s) values (%s) on conflict (%s) do update set (%s) = (%s)`, tableName, strings.Join(cols, ", "), strings.Join(vals, ", "), conflictInferenceSpec, strings.Join(cols, ", "), strings.Join(updateVals, ", "))
+			statement = fmt.Sprintf(`insert into %s (%s) values (%s) on conflict (%s) do update set (%s) = (%s)`, qualifiedTableName, strings.Join(cols, ", "), strings.Join(vals, ", "), conflictInferenceSpec, strings.Join(cols, ", "), strings.Join(updateVals, ", "))
 		} else {
-			statement = fmt.Sprintf(`insert into %s (%s) values (%s)`, tableName, strings.Join(cols, ", "), strings.Join(vals, ", "))
+			statement = fmt.Sprintf(`insert into %s (%s) values (%s)`, qualifiedTableName, strings.Join(cols, ", "), strings.Join(vals, ", "))
 		}
 		statements = append(statements, statement)
 	}
@@ -61,31 +62,83 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.Postgr
 		columns = append(columns, fmt.Sprintf("primary key (%s)", strings.Join(primaryKeyColumns, ", ")))
 	}
 
-	if len(tableSchema.Indexes) > 0 {
-		for _, index := range tableSchema.Indexes {
-			if index.IsUnique {
-				uniqueColumns := []string{}
-				for _, indexColumn := range index.Columns {
-					uniqueColumns = append(uniqueColumns, pgx.Identifier{indexColumn}.Sanitize())
-				}
-				columns = append(columns, fmt.Sprintf("constraint %q unique (%s)", types.GeneratePostgresqlIndexName(tableName, index), strings.Join(uniqueColumns, ", ")))
-			}
+	// indexes that can't be unique constraints are created after the table
+	indexes := []*schemasv1alpha4.PostgresqlTableIndex{}
+	for _, index := range tableSchema.Indexes {
+		if !isConstraintIndex(index) {
+			indexes = append(indexes, index)
+			continue
 		}
+
+		uniqueColumns := []string{}
+		for _, indexColumn := range index.Columns {
+			uniqueColumns = append(uniqueColumns, pgx.Identifier{indexColumn}.Sanitize())
+		}
+		name := index.Name
+		if name == "" {
+			name = types.GeneratePostgresqlIndexName(tableName, index)
+		}
+		columns = append(columns, fmt.Sprintf("constraint %q unique (%s)", name, strings.Join(uniqueColumns, ", ")))
 	}
 
 	if tableSchema.ForeignKeys != nil {
 		for _, foreignKey := range tableSchema.ForeignKeys {
-			columns = append(columns, foreignKeyConstraintClause(tableName, foreignKey))
+			columns = append(columns, foreignKeyConstraintClause(tableSchema.Schema, tableName, foreignKey))
+		}
+	}
+
+	for _, check := range tableSchema.Checks {
+		columns = append(columns, checkConstraintClause(check))
+	}
+
+	// sequences are created before the table, and owned by its columns after it's created
+	queries := []string{}
+	for _, sequence := range tableSchema.Sequences {
+		queries = append(queries, CreateSequenceStatement(tableSchema.Schema, types.PostgresqlSchemaSequenceToSequence(sequence)))
+	}
+
+	createTable := fmt.Sprintf(`create table %s (%s)`, qualifiedIdentifier(tableSchema.Schema, tableName), strings.Join(columns, ", "))
+	if tableSchema.Partitioning != nil {
+		if err := ValidatePartitioning(tableSchema.Partitioning); err != nil {
+			return nil, errors.Wrap(err, "invalid partitioning")
+		}
+		createTable = fmt.Sprintf("%s %s", createTable, partitionByClause(tableSchema.Partitioning))
+	}
+	queries = append(queries, createTable)
+
+	if tableSchema.Partitioning != nil {
+		for _, partition := range postgresqlSchemaPartitioningToPartitioning(tableSchema.Partitioning).Partitions {
+			queries = append(queries, CreatePartitionStatement(tableSchema.Schema, tableName, partition))
+		}
+	}
+
+	queries = append(queries, createCommentStatements(tableName, tableSchema)...)
+
+	grantStatements, err := grantChangeStatements(tableSchema.Schema, tableName, tableSchema.Grants, map[string][]string{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create grant statements")
+	}
+	queries = append(queries, grantStatements...)
+
+	policyStatements, err := createPolicyStatements(tableName, tableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create policy statements")
+	}
+	queries = append(queries, policyStatements...)
+
+	for _, sequence := range tableSchema.Sequences {
+		if sequence.OwnedBy != "" {
+			queries = append(queries, SequenceOwnedByStatement(tableSchema.Schema, sequence.Name, tableName, sequence.OwnedBy))
 		}
 	}
 
-	queries := []string{
-		fmt.Sprintf(`create table %s (%s)`, pgx.Identifier{tableName}.Sanitize(), strings.Join(columns, ", ")),
+	for _, index := range indexes {
+		queries = append(queries, indexStatement(tableSchema.Schema, tableName, index, false))
 	}
 
 	// Add any triggers that are defined
 	for _, trigger := range tableSchema.Triggers {
-		statement, err := triggerCreateStatement(trigger, tableName)
+		statement, err := triggerCreateStatement(trigger, tableSchema.Schema, tableName)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create trigger statement")
 		}
@@ -93,6 +93,73 @@ func Test_CreateTableStatement(t *testing.T) {
 				`create table "composite_unique_index" ("one" integer, "two" integer, "three" character varying (255), primary key ("one"), constraint "idx_composite_unique_index_two_three" unique ("two", "three"))`,
 			},
 		},
+		{
+			name: "table with indexes",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				PrimaryKey: []string{
+					"id",
+				},
+				Indexes: []*schemasv1alpha4.PostgresqlTableIndex{
+					{
+						Columns:  []string{"email"},
+						IsUnique: true,
+						Name:     "users_email_key",
+					},
+					{
+						Columns:      []string{"name"},
+						Concurrently: true,
+					},
+					{
+						Keys: []schemasv1alpha4.PostgresqlTableIndexKey{
+							{Column: "email"},
+						},
+						IsUnique: true,
+						Where:    "deleted_at is null",
+					},
+				},
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{
+						Name: "id",
+						Type: "integer",
+					},
+					{
+						Name: "email",
+						Type: "text",
+					},
+					{
+						Name: "name",
+						Type: "text",
+					},
+				},
+			},
+			tableName: "users",
+			expectedStatements: []string{
+				`create table "users" ("id" integer, "email" text, "name" text, primary key ("id"), constraint "users_email_key" unique ("email"))`,
+				`create index idx_users_name on users (name)`,
+				`create unique index idx_users_email on users ("email") where deleted_at is null`,
+			},
+		},
+		{
+			name: "table with checks",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				Checks: []*schemasv1alpha4.PostgresqlTableCheck{
+					{
+						Name:       "price_positive",
+						Expression: "price > 0",
+					},
+				},
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{
+						Name: "price",
+						Type: "numeric",
+					},
+				},
+			},
+			tableName: "products",
+			expectedStatements: []string{
+				`create table "products" ("price" numeric, constraint "price_positive" check (price > 0))`,
+			},
+		},
 		{
 			name: "simple with trigger",
 			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
@@ -122,6 +189,132 @@ func Test_CreateTableStatement(t *testing.T) {
 				`create trigger "tgr" after insert on "simple" for each row execute procedure test()`,
 			},
 		},
+		{
+			name: "in schema",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				Schema: "app",
+				PrimaryKey: []string{
+					"id",
+				},
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{
+						Name: "id",
+						Type: "integer",
+					},
+				},
+			},
+			tableName: "simple",
+			expectedStatements: []string{
+				`create table "app"."simple" ("id" integer, primary key ("id"))`,
+			},
+		},
+		{
+			name: "identity and owned sequence",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				PrimaryKey: []string{"id"},
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{
+						Name: "id",
+						Type: "bigint",
+						Identity: &schemasv1alpha4.PostgresqlTableColumnIdentity{
+							Generated: "always",
+						},
+					},
+					{
+						Name: "invoice_number",
+						Type: "bigint",
+					},
+				},
+				Sequences: []*schemasv1alpha4.PostgresqlSequence{
+					{
+						Name:    "invoice_number_seq",
+						OwnedBy: "invoice_number",
+					},
+				},
+			},
+			tableName: "invoices",
+			expectedStatements: []string{
+				`create sequence if not exists "invoice_number_seq"`,
+				`create table "invoices" ("id" bigint generated always as identity not null, "invoice_number" bigint, primary key ("id"))`,
+				`alter sequence "invoice_number_seq" owned by "invoices"."invoice_number"`,
+			},
+		},
+		{
+			name: "range partitioned",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				PrimaryKey: []string{"id", "created_at"},
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{
+						Name: "id",
+						Type: "bigint",
+					},
+					{
+						Name: "created_at",
+						Type: "date",
+					},
+				},
+				Partitioning: &schemasv1alpha4.PostgresqlTablePartitioning{
+					Strategy: "range",
+					Key:      "created_at",
+					Partitions: []*schemasv1alpha4.PostgresqlTablePartition{
+						{
+							Name: "events_2024",
+							From: []string{"'2024-01-01'"},
+							To:   []string{"'2025-01-01'"},
+						},
+						{
+							Name:      "events_default",
+							IsDefault: true,
+						},
+					},
+				},
+			},
+			tableName: "events",
+			expectedStatements: []string{
+				`create table "events" ("id" bigint, "created_at" date, primary key ("id", "created_at")) partition by range (created_at)`,
+				`create table "events_2024" partition of "events" for values from ('2024-01-01') to ('2025-01-01')`,
+				`create table "events_default" partition of "events" default`,
+			},
+		},
+		{
+			name: "grants and policies",
+			tableSchema: &schemasv1alpha4.PostgresqlTableSchema{
+				PrimaryKey: []string{"id"},
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{
+						Name: "id",
+						Type: "integer",
+					},
+					{
+						Name: "owner",
+						Type: "text",
+					},
+				},
+				Grants: []*schemasv1alpha4.PostgresqlTableGrant{
+					{
+						Role:       "app",
+						Privileges: []string{"select", "insert"},
+					},
+				},
+				Policies: []*schemasv1alpha4.PostgresqlTablePolicy{
+					{
+						Name:  "own_rows",
+						Roles: []string{"app"},
+						Using: "owner = current_user",
+					},
+				},
+				RowLevelSecurity: &schemasv1alpha4.PostgresqlTableRowLevelSecurity{
+					Enable: true,
+				},
+			},
+			tableName: "documents",
+			expectedStatements: []string{
+				`create table "documents" ("id" integer, "owner" text, primary key ("id"))`,
+				`grant select, insert on table "documents" to "app"`,
+				`create policy "own_rows" on "documents" for all to "app" using (owner = current_user)`,
+				`alter table "documents" enable row level security`,
+			},
+		},
 	}
 
 	for _, test := range tests {
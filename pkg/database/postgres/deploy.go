@@ -4,8 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
-	"github.com/jackc/pgx/v4"
 	"github.com/pkg/errors"
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database/types"
@@ -15,26 +15,57 @@ func PlanPostgresView(uri string, viewName string, postgresViewSchema *schemasv1
 	return nil, errors.New("not implemented")
 }
 
-func PlanPostgresTable(uri string, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+func PlanPostgresTable(uri string, tableName string, renamedFrom string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
 	p, err := Connect(uri)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to postgres")
 	}
 	defer p.Close()
 
+	schemaName := postgresTableSchema.Schema
+
+	// a table can only be in a schema that exists
+	schemaStatements := []string{}
+	if schemaName != "" {
+		schemaExists, err := p.schemaExists(schemaName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to check if schema exists")
+		}
+		if !schemaExists {
+			if postgresTableSchema.IsDeleted {
+				return []string{}, nil
+			}
+			schemaStatements = append(schemaStatements, CreateSchemaStatement(schemaName))
+		}
+	}
+
 	// determine if the table exists
-	query := `select count(1) from information_schema.tables where table_name = $1`
-	row := p.conn.QueryRow(context.Background(), query, tableName)
-	tableExists := 0
-	if err := row.Scan(&tableExists); err != nil {
-		return nil, errors.Wrap(err, "failed to scan")
+	tableExists, err := p.tableExists(schemaName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check if table exists")
+	}
+
+	// a renamed table is changed to match the spec under its existing name, and then renamed
+	renameTo := ""
+	if !tableExists && renamedFrom != "" && renamedFrom != tableName && !postgresTableSchema.IsDeleted {
+		existingTableExists, err := p.tableExists(schemaName, renamedFrom)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to check if renamed table exists")
+		}
+
+		if existingTableExists {
+			renameTo = tableName
+			tableName = renamedFrom
+			tableExists = existingTableExists
+			postgresTableSchema = withGeneratedNames(renameTo, postgresTableSchema)
+		}
 	}
 
-	if tableExists == 0 && postgresTableSchema.IsDeleted {
+	if !tableExists && postgresTableSchema.IsDeleted {
 		return []string{}, nil
-	} else if tableExists > 0 && postgresTableSchema.IsDeleted {
+	} else if tableExists && postgresTableSchema.IsDeleted {
 		return []string{
-			fmt.Sprintf(`drop table %s`, pgx.Identifier{tableName}.Sanitize()),
+			fmt.Sprintf(`drop table %s`, qualifiedIdentifier(schemaName, tableName)),
 		}, nil
 	}
 
@@ -46,17 +77,23 @@ func PlanPostgresTable(uri string, tableName string, postgresTableSchema *schema
 		}
 	}
 
-	if tableExists == 0 {
+	if !tableExists {
 		// shortcut to just create it
 		queries, err := CreateTableStatements(tableName, postgresTableSchema)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create table statement")
 		}
 
+		queries = append(schemaStatements, queries...)
 		return append(queries, seedDataStatements...), nil
 	}
 
-	statements := []string{}
+	// sequences are created before the columns that use them, and owned by columns after they are created
+	sequenceStatements, sequenceOwnerStatements, err := BuildSequenceStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build sequence statements")
+	}
+	statements := append([]string{}, sequenceStatements...)
 
 	// table needs to be altered?
 	columnStatements, err := BuildColumnStatements(p, tableName, postgresTableSchema)
@@ -64,6 +101,7 @@ func PlanPostgresTable(uri string, tableName string, postgresTableSchema *schema
 		return nil, errors.Wrap(err, "failed to build column statement")
 	}
 	statements = append(statements, columnStatements...)
+	statements = append(statements, sequenceOwnerStatements...)
 
 	// primary key changes
 	primaryKeyStatements, err := BuildPrimaryKeyStatements(p, tableName, postgresTableSchema)
@@ -86,8 +124,47 @@ func PlanPostgresTable(uri string, tableName string, postgresTableSchema *schema
 	}
 	statements = append(statements, indexStatements...)
 
+	// check constraint changes
+	checkStatements, err := BuildCheckStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build check statements")
+	}
+	statements = append(statements, checkStatements...)
+
+	// partition changes
+	partitionStatements, err := BuildPartitionStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build partition statements")
+	}
+	statements = append(statements, partitionStatements...)
+
+	// comment changes
+	commentStatements, err := BuildCommentStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build comment statements")
+	}
+	statements = append(statements, commentStatements...)
+
+	// grant changes
+	grantStatements, err := BuildGrantStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build grant statements")
+	}
+	statements = append(statements, grantStatements...)
+
+	// policies are created before row level security is turned on, so that rows aren't hidden in between
+	policyStatements, err := BuildPolicyStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build policy statements")
+	}
+	statements = append(statements, policyStatements...)
+
 	statements = append(statements, seedDataStatements...)
 
+	if renameTo != "" {
+		statements = append(statements, RenameTableStatement(schemaName, tableName, renameTo))
+	}
+
 	return statements, nil
 }
 
@@ -122,32 +199,47 @@ func executeStatements(p *PostgresConnection, statements []string) error {
 
 func BuildColumnStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
 	query := `select
-column_name, column_default, is_nullable, data_type, udt_name, character_maximum_length
+column_name, column_default, is_nullable, data_type, udt_name, character_maximum_length, is_generated, generation_expression,
+is_identity, identity_generation, identity_start, identity_increment, identity_minimum, identity_maximum, identity_cycle,
+coalesce(pg_get_serial_sequence(quote_ident(table_schema) || '.' || quote_ident(table_name), column_name), '')
 from information_schema.columns
-where table_name = $1`
-	rows, err := p.conn.Query(context.Background(), query, tableName)
+where table_name = $1 and ` + schemaMatches("table_schema", 2)
+	rows, err := p.conn.Query(context.Background(), query, tableName, postgresTableSchema.Schema)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to select from information_schema")
 	}
 	defer rows.Close()
 
-	alterAndDropStatements := []string{}
-	foundColumnNames := []string{}
+	existingColumns := []types.Column{}
 	for rows.Next() {
-		var columnName, dataType, udtName, isNullable string
-		var columnDefault sql.NullString
+		var columnName, dataType, udtName, isNullable, isGenerated, isIdentity, ownedSequence string
+		var columnDefault, generationExpression sql.NullString
+		var identityGeneration, identityStart, identityIncrement, identityMinimum, identityMaximum, identityCycle sql.NullString
 		var charMaxLength sql.NullInt64
 
-		if err := rows.Scan(&columnName, &columnDefault, &isNullable, &dataType, &udtName, &charMaxLength); err != nil {
+		if err := rows.Scan(&columnName, &columnDefault, &isNullable, &dataType, &udtName, &charMaxLength, &isGenerated, &generationExpression,
+			&isIdentity, &identityGeneration, &identityStart, &identityIncrement, &identityMinimum, &identityMaximum, &identityCycle, &ownedSequence); err != nil {
 			return nil, errors.Wrap(err, "failed to scan")
 		}
 
-		foundColumnNames = append(foundColumnNames, columnName)
-
 		existingColumn := types.Column{
-			Name:        columnName,
-			DataType:    dataType,
-			Constraints: &types.ColumnConstraints{},
+			Name:          columnName,
+			DataType:      dataType,
+			Constraints:   &types.ColumnConstraints{},
+			OwnedSequence: ownedSequence,
+		}
+
+		if isIdentity == "YES" {
+			existingColumn.Identity = &types.ColumnIdentity{
+				Generation: strings.ToLower(identityGeneration.String),
+				Options: types.SequenceOptions{
+					Start:     parseSequenceValue(identityStart),
+					Increment: parseSequenceValue(identityIncrement),
+					MinValue:  parseSequenceValue(identityMinimum),
+					MaxValue:  parseSequenceValue(identityMaximum),
+					Cycle:     identityCycle.String == "YES",
+				},
+			}
 		}
 
 		if dataType == "ARRAY" {
@@ -169,7 +261,34 @@ where table_name = $1`
 			existingColumn.DataType = fmt.Sprintf("%s (%d)", existingColumn.DataType, charMaxLength.Int64)
 		}
 
-		columnStatement, err := AlterColumnStatements(tableName, postgresTableSchema.PrimaryKey, postgresTableSchema.Columns, &existingColumn)
+		if isGenerated == "ALWAYS" && generationExpression.Valid {
+			existingColumn.Generated = &types.ColumnGenerated{
+				Expression: generationExpression.String,
+				Stored:     true,
+			}
+		}
+
+		existingColumns = append(existingColumns, existingColumn)
+	}
+
+	existingColumnNames := []string{}
+	for _, existingColumn := range existingColumns {
+		existingColumnNames = append(existingColumnNames, existingColumn.Name)
+	}
+	renames := types.ColumnRenames(existingColumnNames, columnsRenamedFrom(postgresTableSchema.Columns))
+
+	alterAndDropStatements := []string{}
+	foundColumnNames := []string{}
+	for _, existingColumn := range existingColumns {
+		// renamed columns are compared to the desired column by their new name
+		if newName, ok := renames[existingColumn.Name]; ok {
+			alterAndDropStatements = append(alterAndDropStatements, RenameColumnStatement(postgresTableSchema.Schema, tableName, existingColumn.Name, newName))
+			existingColumn.Name = newName
+		}
+
+		foundColumnNames = append(foundColumnNames, existingColumn.Name)
+
+		columnStatement, err := AlterColumnStatements(postgresTableSchema.Schema, tableName, postgresTableSchema.PrimaryKey, postgresTableSchema.Columns, &existingColumn)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create alter column statement")
 		}
@@ -186,7 +305,7 @@ where table_name = $1`
 		}
 
 		if !isColumnPresent {
-			statement, err := InsertColumnStatement(tableName, desiredColumn)
+			statement, err := InsertColumnStatement(postgresTableSchema.Schema, tableName, desiredColumn)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to create insert column statement")
 			}
@@ -199,10 +318,18 @@ where table_name = $1`
 }
 
 func BuildPrimaryKeyStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
-	currentPrimaryKey, err := p.GetTablePrimaryKey(tableName)
+	currentPrimaryKey, err := p.getTablePrimaryKey(postgresTableSchema.Schema, tableName)
 	if err != nil {
 		return nil, err
 	}
+	if currentPrimaryKey != nil {
+		// renaming a column renames it in the primary key too
+		renames, err := p.listColumnRenames(postgresTableSchema.Schema, tableName, postgresTableSchema.Columns)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list column renames")
+		}
+		currentPrimaryKey.Columns = types.RenameColumnNames(currentPrimaryKey.Columns, renames)
+	}
 	var postgresTableSchemaPrimaryKey *types.KeyConstraint
 	if len(postgresTableSchema.PrimaryKey) > 0 {
 		postgresTableSchemaPrimaryKey = &types.KeyConstraint{
@@ -217,11 +344,11 @@ func BuildPrimaryKeyStatements(p *PostgresConnection, tableName string, postgres
 
 	var statements []string
 	if currentPrimaryKey != nil {
-		statements = append(statements, RemoveConstrantStatement(tableName, currentPrimaryKey))
+		statements = append(statements, RemoveConstrantStatement(postgresTableSchema.Schema, tableName, currentPrimaryKey))
 	}
 
 	if postgresTableSchemaPrimaryKey != nil {
-		statements = append(statements, AddConstrantStatement(tableName, postgresTableSchemaPrimaryKey))
+		statements = append(statements, AddConstrantStatement(postgresTableSchema.Schema, tableName, postgresTableSchemaPrimaryKey))
 	}
 
 	return statements, nil
@@ -230,12 +357,27 @@ func BuildPrimaryKeyStatements(p *PostgresConnection, tableName string, postgres
 func BuildForeignKeyStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
 	foreignKeyStatements := []string{}
 	droppedKeys := []string{}
-	currentForeignKeys, err := p.ListTableForeignKeys(p.databaseName, tableName)
+	currentForeignKeys, err := p.listTableForeignKeys(postgresTableSchema.Schema, tableName)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, foreignKey := range postgresTableSchema.ForeignKeys {
+	// renaming a column renames it in the foreign keys too
+	renames, err := p.listColumnRenames(postgresTableSchema.Schema, tableName, postgresTableSchema.Columns)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list column renames")
+	}
+	types.RenameForeignKeyColumns(currentForeignKeys, renames)
+
+	// the generated names are set on copies, so that the spec isn't changed
+	desiredForeignKeys := []*schemasv1alpha4.PostgresqlTableForeignKey{}
+	for _, schemaForeignKey := range postgresTableSchema.ForeignKeys {
+		foreignKey := *schemaForeignKey
+		foreignKey.Name = types.GeneratePostgresqlFKName(tableName, schemaForeignKey)
+		desiredForeignKeys = append(desiredForeignKeys, &foreignKey)
+	}
+
+	for _, foreignKey := range desiredForeignKeys {
 		var statement string
 		var matchedForeignKey *types.ForeignKey
 		for _, currentForeignKey := range currentForeignKeys {
@@ -243,26 +385,27 @@ func BuildForeignKeyStatements(p *PostgresConnection, tableName string, postgres
 				goto Next
 			}
 
-			matchedForeignKey = currentForeignKey
+			if currentForeignKey.Name == foreignKey.Name {
+				matchedForeignKey = currentForeignKey
+			}
 		}
 
 		// drop and readd?  is this always ok
 		// TODO can we alter
 		if matchedForeignKey != nil {
-			statement = RemoveForeignKeyStatement(tableName, matchedForeignKey)
+			statement = RemoveForeignKeyStatement(postgresTableSchema.Schema, tableName, matchedForeignKey)
 			droppedKeys = append(droppedKeys, matchedForeignKey.Name)
 			foreignKeyStatements = append(foreignKeyStatements, statement)
 		}
 
-		statement = AddForeignKeyStatement(tableName, foreignKey)
-		foreignKeyStatements = append(foreignKeyStatements, statement)
+		foreignKeyStatements = append(foreignKeyStatements, AddForeignKeyStatements(postgresTableSchema.Schema, tableName, foreignKey)...)
 
 	Next:
 	}
 
 	for _, currentForeignKey := range currentForeignKeys {
 		var statement string
-		for _, foreignKey := range postgresTableSchema.ForeignKeys {
+		for _, foreignKey := range desiredForeignKeys {
 			if currentForeignKey.Equals(types.PostgresqlSchemaForeignKeyToForeignKey(foreignKey)) {
 				goto NextCurrentFK
 			}
@@ -274,7 +417,7 @@ func BuildForeignKeyStatements(p *PostgresConnection, tableName string, postgres
 			}
 		}
 
-		statement = RemoveForeignKeyStatement(tableName, currentForeignKey)
+		statement = RemoveForeignKeyStatement(postgresTableSchema.Schema, tableName, currentForeignKey)
 		foreignKeyStatements = append(foreignKeyStatements, statement)
 
 	NextCurrentFK:
@@ -284,17 +427,29 @@ func BuildForeignKeyStatements(p *PostgresConnection, tableName string, postgres
 }
 
 func BuildIndexStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
-	indexStatements := []string{}
-	droppedIndexes := []string{}
-	currentIndexes, err := p.ListTableIndexes(p.databaseName, tableName)
+	currentIndexes, err := p.listTableIndexes(postgresTableSchema.Schema, tableName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list table indexes")
 	}
-	currentConstraints, err := p.ListTableConstraints(p.databaseName, tableName)
+	currentConstraints, err := p.listTableConstraints(p.databaseName, postgresTableSchema.Schema, tableName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list table constraints")
 	}
 
+	// renaming a column renames it in the indexes too
+	renames, err := p.listColumnRenames(postgresTableSchema.Schema, tableName, postgresTableSchema.Columns)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list column renames")
+	}
+	types.RenameIndexColumns(currentIndexes, renames)
+
+	return indexChangeStatements(tableName, postgresTableSchema, currentIndexes, currentConstraints), nil
+}
+
+func indexChangeStatements(tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema, currentIndexes []*types.Index, currentConstraints []string) []string {
+	indexStatements := []string{}
+	droppedIndexes := []string{}
+
 DesiredIndexLoop:
 	for _, index := range postgresTableSchema.Indexes {
 		if index.Name == "" {
@@ -323,15 +478,15 @@ DesiredIndexLoop:
 			}
 
 			if isConstraint {
-				statement = RemoveConstraintStatement(tableName, matchedIndex)
+				statement = RemoveConstraintStatement(postgresTableSchema.Schema, tableName, matchedIndex)
 			} else {
-				statement = RemoveIndexStatement(tableName, matchedIndex)
+				statement = RemoveIndexStatement(postgresTableSchema.Schema, tableName, matchedIndex)
 			}
 			droppedIndexes = append(droppedIndexes, matchedIndex.Name)
 			indexStatements = append(indexStatements, statement)
 		}
 
-		statement = AddIndexStatement(tableName, index)
+		statement = AddIndexStatement(postgresTableSchema.Schema, tableName, index)
 		indexStatements = append(indexStatements, statement)
 	}
 
@@ -359,13 +514,13 @@ ExistingIndexLoop:
 		}
 
 		if isConstraint {
-			statement = RemoveConstraintStatement(tableName, currentIndex)
+			statement = RemoveConstraintStatement(postgresTableSchema.Schema, tableName, currentIndex)
 		} else {
-			statement = RemoveIndexStatement(tableName, currentIndex)
+			statement = RemoveIndexStatement(postgresTableSchema.Schema, tableName, currentIndex)
 		}
 
 		indexStatements = append(indexStatements, statement)
 	}
 
-	return indexStatements, nil
+	return indexStatements
 }
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+)
+
+// installedExtension is the version and schema of an extension that is installed in the database
+type installedExtension struct {
+	Version string
+	Schema  string
+}
+
+// PlanPostgresExtensions returns the statements to install the extensions that are missing from the database,
+// and to update the installed extensions to their desired version and schema. Extensions that are installed
+// but not desired are left in place
+func PlanPostgresExtensions(uri string, extensions []databasesv1alpha4.PostgresExtension) ([]string, error) {
+	p, err := Connect(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to postgres")
+	}
+	defer p.Close()
+
+	installedExtensions, err := p.listExtensions()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list extensions")
+	}
+
+	return extensionStatements(extensions, installedExtensions), nil
+}
+
+func (p *PostgresConnection) listExtensions() (map[string]installedExtension, error) {
+	query := `select e.extname, e.extversion, n.nspname
+from pg_extension e
+join pg_namespace n on n.oid = e.extnamespace`
+	rows, err := p.conn.Query(context.Background(), query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query extensions")
+	}
+	defer rows.Close()
+
+	installedExtensions := map[string]installedExtension{}
+	for rows.Next() {
+		var name string
+		var extension installedExtension
+		if err := rows.Scan(&name, &extension.Version, &extension.Schema); err != nil {
+			return nil, errors.Wrap(err, "failed to scan extension")
+		}
+
+		installedExtensions[name] = extension
+	}
+
+	return installedExtensions, nil
+}
+
+func extensionStatements(extensions []databasesv1alpha4.PostgresExtension, installedExtensions map[string]installedExtension) []string {
+	statements := []string{}
+	for _, extension := range extensions {
+		installed, ok := installedExtensions[extension.Name]
+		if !ok {
+			statements = append(statements, CreateExtensionStatement(extension))
+			continue
+		}
+
+		if extension.Version != "" && extension.Version != installed.Version {
+			statements = append(statements, UpdateExtensionStatement(extension.Name, extension.Version))
+		}
+
+		if extension.Schema != "" && extension.Schema != installed.Schema {
+			statements = append(statements, SetExtensionSchemaStatement(extension.Name, extension.Schema))
+		}
+	}
+
+	return statements
+}
+
+// CreateExtensionStatement returns the statement to install the extension
+func CreateExtensionStatement(extension databasesv1alpha4.PostgresExtension) string {
+	statement := fmt.Sprintf(`create extension if not exists %s`, pgx.Identifier{extension.Name}.Sanitize())
+
+	if extension.Schema != "" {
+		statement = fmt.Sprintf("%s with schema %s", statement, pgx.Identifier{extension.Schema}.Sanitize())
+	}
+	if extension.Version != "" {
+		statement = fmt.Sprintf("%s version %s", statement, quoteLiteral(extension.Version))
+	}
+
+	return statement
+}
+
+// UpdateExtensionStatement returns the statement to update an installed extension to the version
+func UpdateExtensionStatement(name string, version string) string {
+	return fmt.Sprintf(`alter extension %s update to %s`, pgx.Identifier{name}.Sanitize(), quoteLiteral(version))
+}
+
+// SetExtensionSchemaStatement returns the statement to move the objects of an installed extension to the schema
+func SetExtensionSchemaStatement(name string, schemaName string) string {
+	return fmt.Sprintf(`alter extension %s set schema %s`, pgx.Identifier{name}.Sanitize(), pgx.Identifier{schemaName}.Sanitize())
+}
+
+func quoteLiteral(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+}
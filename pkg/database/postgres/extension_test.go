@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"testing"
+
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_extensionStatements(t *testing.T) {
+	tests := []struct {
+		name                string
+		extensions          []databasesv1alpha4.PostgresExtension
+		installedExtensions map[string]installedExtension
+		expectedStatements  []string
+	}{
+		{
+			name: "missing extension",
+			extensions: []databasesv1alpha4.PostgresExtension{
+				{Name: "uuid-ossp"},
+			},
+			installedExtensions: map[string]installedExtension{
+				"plpgsql": {Version: "1.0", Schema: "pg_catalog"},
+			},
+			expectedStatements: []string{
+				`create extension if not exists "uuid-ossp"`,
+			},
+		},
+		{
+			name: "missing extension with version and schema",
+			extensions: []databasesv1alpha4.PostgresExtension{
+				{Name: "postgis", Version: "3.1.4", Schema: "gis"},
+			},
+			installedExtensions: map[string]installedExtension{},
+			expectedStatements: []string{
+				`create extension if not exists "postgis" with schema "gis" version '3.1.4'`,
+			},
+		},
+		{
+			name: "installed extension without version",
+			extensions: []databasesv1alpha4.PostgresExtension{
+				{Name: "pgcrypto"},
+			},
+			installedExtensions: map[string]installedExtension{
+				"pgcrypto": {Version: "1.3", Schema: "public"},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name: "installed extension at another version and schema",
+			extensions: []databasesv1alpha4.PostgresExtension{
+				{Name: "timescaledb", Version: "2.5.0", Schema: "ts"},
+			},
+			installedExtensions: map[string]installedExtension{
+				"timescaledb": {Version: "2.4.2", Schema: "public"},
+			},
+			expectedStatements: []string{
+				`alter extension "timescaledb" update to '2.5.0'`,
+				`alter extension "timescaledb" set schema "ts"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatements, extensionStatements(test.extensions, test.installedExtensions))
+		})
+	}
+}
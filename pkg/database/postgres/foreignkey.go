@@ -9,24 +9,58 @@ import (
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func RemoveForeignKeyStatement(tableName string, foreignKey *types.ForeignKey) string {
-	return fmt.Sprintf("alter table %s drop constraint %s", tableName, pgx.Identifier{foreignKey.Name}.Sanitize())
+func RemoveForeignKeyStatement(schemaName string, tableName string, foreignKey *types.ForeignKey) string {
+	return fmt.Sprintf("alter table %s drop constraint %s", qualifiedName(schemaName, tableName), pgx.Identifier{foreignKey.Name}.Sanitize())
 }
 
-func AddForeignKeyStatement(tableName string, schemaForeignKey *schemasv1alpha4.PostgresqlTableForeignKey) string {
-	return fmt.Sprintf("alter table %s add %s", tableName, foreignKeyConstraintClause(tableName, schemaForeignKey))
+// AddForeignKeyStatements returns the statements to add the foreign key. A foreign key that is not valid is added
+// without checking the existing rows, and then validated with a lock that doesn't block writes
+func AddForeignKeyStatements(schemaName string, tableName string, schemaForeignKey *schemasv1alpha4.PostgresqlTableForeignKey) []string {
+	if !schemaForeignKey.NotValid {
+		return []string{
+			AddForeignKeyStatement(schemaName, tableName, schemaForeignKey),
+		}
+	}
+
+	return []string{
+		fmt.Sprintf("%s not valid", AddForeignKeyStatement(schemaName, tableName, schemaForeignKey)),
+		ValidateConstraintStatement(schemaName, tableName, types.GeneratePostgresqlFKName(tableName, schemaForeignKey)),
+	}
+}
+
+func AddForeignKeyStatement(schemaName string, tableName string, schemaForeignKey *schemasv1alpha4.PostgresqlTableForeignKey) string {
+	return fmt.Sprintf("alter table %s add %s", qualifiedName(schemaName, tableName), foreignKeyConstraintClause(schemaName, tableName, schemaForeignKey))
 }
 
-func foreignKeyConstraintClause(tableName string, schemaForeignKey *schemasv1alpha4.PostgresqlTableForeignKey) string {
-	onDelete := ""
+// ValidateConstraintStatement returns the statement to check the existing rows against a constraint that was
+// added not valid
+func ValidateConstraintStatement(schemaName string, tableName string, constraintName string) string {
+	return fmt.Sprintf("alter table %s validate constraint %s", qualifiedName(schemaName, tableName), pgx.Identifier{constraintName}.Sanitize())
+}
+
+// foreignKeyConstraintClause returns the constraint for the foreign key. The referenced table is in the same schema
+// as the table
+func foreignKeyConstraintClause(schemaName string, tableName string, schemaForeignKey *schemasv1alpha4.PostgresqlTableForeignKey) string {
+	options := ""
+	if schemaForeignKey.Match != "" {
+		options = fmt.Sprintf("%s match %s", options, schemaForeignKey.Match)
+	}
 	if schemaForeignKey.OnDelete != "" {
-		onDelete = fmt.Sprintf(" on delete %s", schemaForeignKey.OnDelete)
+		options = fmt.Sprintf("%s on delete %s", options, schemaForeignKey.OnDelete)
+	}
+	if schemaForeignKey.OnUpdate != "" {
+		options = fmt.Sprintf("%s on update %s", options, schemaForeignKey.OnUpdate)
+	}
+	if schemaForeignKey.InitiallyDeferred {
+		options = fmt.Sprintf("%s deferrable initially deferred", options)
+	} else if schemaForeignKey.Deferrable {
+		options = fmt.Sprintf("%s deferrable", options)
 	}
 
 	return fmt.Sprintf("constraint %s foreign key (%s) references %s (%s)%s",
 		types.GeneratePostgresqlFKName(tableName, schemaForeignKey),
 		strings.Join(SanitizeArray(schemaForeignKey.Columns), ", "),
-		pgx.Identifier{schemaForeignKey.References.Table}.Sanitize(),
+		qualifiedIdentifier(schemaName, schemaForeignKey.References.Table),
 		strings.Join(SanitizeArray(schemaForeignKey.References.Columns), ", "),
-		onDelete)
+		options)
 }
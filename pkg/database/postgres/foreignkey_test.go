@@ -11,6 +11,7 @@ import (
 func Test_AddForeignKeyStatement(t *testing.T) {
 	tests := []struct {
 		name              string
+		schemaName        string
 		tableName         string
 		schemaForeignKey  *schemasv1alpha4.PostgresqlTableForeignKey
 		expectedStatement string
@@ -83,13 +84,71 @@ func Test_AddForeignKeyStatement(t *testing.T) {
 			},
 			expectedStatement: `alter table t2 add constraint t2_c2_fkey foreign key ("c2") references "t1" ("c1") on delete cascade`,
 		},
+		{
+			name:       "no name, one column, in schema",
+			schemaName: "app",
+			tableName:  "t2",
+			schemaForeignKey: &schemasv1alpha4.PostgresqlTableForeignKey{
+				Columns: []string{
+					"c2",
+				},
+				References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{
+					Table: "t1",
+					Columns: []string{
+						"c1",
+					},
+				},
+			},
+			expectedStatement: `alter table "app"."t2" add constraint t2_c2_fkey foreign key ("c2") references "app"."t1" ("c1")`,
+		},
+		{
+			name:      "no name, one column, all options",
+			tableName: "t2",
+			schemaForeignKey: &schemasv1alpha4.PostgresqlTableForeignKey{
+				Match:             "full",
+				OnDelete:          "set null",
+				OnUpdate:          "cascade",
+				InitiallyDeferred: true,
+				Columns: []string{
+					"c2",
+				},
+				References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{
+					Table: "t1",
+					Columns: []string{
+						"c1",
+					},
+				},
+			},
+			expectedStatement: `alter table t2 add constraint t2_c2_fkey foreign key ("c2") references "t1" ("c1") match full on delete set null on update cascade deferrable initially deferred`,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			addForeignKeyStatement := AddForeignKeyStatement(test.tableName, test.schemaForeignKey)
+			addForeignKeyStatement := AddForeignKeyStatement(test.schemaName, test.tableName, test.schemaForeignKey)
 
 			assert.Equal(t, test.expectedStatement, addForeignKeyStatement)
 		})
 	}
 }
+
+func Test_AddForeignKeyStatements(t *testing.T) {
+	schemaForeignKey := &schemasv1alpha4.PostgresqlTableForeignKey{
+		Columns: []string{
+			"c2",
+		},
+		References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{
+			Table: "t1",
+			Columns: []string{
+				"c1",
+			},
+		},
+		Deferrable: true,
+		NotValid:   true,
+	}
+
+	assert.Equal(t, []string{
+		`alter table t2 add constraint t2_c2_fkey foreign key ("c2") references "t1" ("c1") deferrable not valid`,
+		`alter table t2 validate constraint "t2_c2_fkey"`,
+	}, AddForeignKeyStatements("", "t2", schemaForeignKey))
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// tablePrivileges are the privileges that can be granted on a table, in the order that they are granted
+var tablePrivileges = []string{"select", "insert", "update", "delete", "truncate", "references", "trigger"}
+
+// roleIdentifier returns the quoted name of the role. Public is a keyword for all roles, and isn't quoted
+func roleIdentifier(role string) string {
+	if strings.EqualFold(role, "public") {
+		return "public"
+	}
+
+	return pgx.Identifier{role}.Sanitize()
+}
+
+// roleKey returns the name of the role that grants are compared by. information_schema lists the grants to
+// public as grants to PUBLIC
+func roleKey(role string) string {
+	if strings.EqualFold(role, "public") {
+		return "public"
+	}
+
+	return role
+}
+
+// grantPrivileges returns the privileges in lower case and in the order of tablePrivileges, with all replaced
+// by each of the privileges
+func grantPrivileges(privileges []string) ([]string, error) {
+	granted := map[string]bool{}
+	for _, privilege := range privileges {
+		privilege = strings.ToLower(strings.TrimSpace(privilege))
+		if privilege == "all" || privilege == "all privileges" {
+			for _, tablePrivilege := range tablePrivileges {
+				granted[tablePrivilege] = true
+			}
+			continue
+		}
+
+		isTablePrivilege := false
+		for _, tablePrivilege := range tablePrivileges {
+			if privilege == tablePrivilege {
+				isTablePrivilege = true
+			}
+		}
+		if !isTablePrivilege {
+			return nil, fmt.Errorf("unknown table privilege %q", privilege)
+		}
+
+		granted[privilege] = true
+	}
+
+	ordered := []string{}
+	for _, tablePrivilege := range tablePrivileges {
+		if granted[tablePrivilege] {
+			ordered = append(ordered, tablePrivilege)
+		}
+	}
+
+	return ordered, nil
+}
+
+func GrantStatement(schemaName string, tableName string, role string, privileges []string) string {
+	return fmt.Sprintf("grant %s on table %s to %s", strings.Join(privileges, ", "), qualifiedIdentifier(schemaName, tableName), roleIdentifier(role))
+}
+
+func RevokeStatement(schemaName string, tableName string, role string, privileges []string) string {
+	return fmt.Sprintf("revoke %s on table %s from %s", strings.Join(privileges, ", "), qualifiedIdentifier(schemaName, tableName), roleIdentifier(role))
+}
+
+// BuildGrantStatements returns the statements to make the privileges of the roles in the spec match the spec
+func BuildGrantStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
+	if len(postgresTableSchema.Grants) == 0 {
+		return []string{}, nil
+	}
+
+	currentGrants, err := p.listTableGrants(postgresTableSchema.Schema, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list table grants")
+	}
+
+	return grantChangeStatements(postgresTableSchema.Schema, tableName, postgresTableSchema.Grants, currentGrants)
+}
+
+// grantChangeStatements returns the statements to revoke the privileges that a role has and that aren't in its
+// grant, and to grant the privileges that it doesn't have. currentGrants are the privileges of each role
+func grantChangeStatements(schemaName string, tableName string, grants []*schemasv1alpha4.PostgresqlTableGrant, currentGrants map[string][]string) ([]string, error) {
+	statements := []string{}
+
+	for _, grant := range grants {
+		desiredPrivileges, err := grantPrivileges(grant.Privileges)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid grant to %s", grant.Role)
+		}
+		currentPrivileges, err := grantPrivileges(currentGrants[roleKey(grant.Role)])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid privilege of %s", grant.Role)
+		}
+
+		revoked := []string{}
+		for _, privilege := range currentPrivileges {
+			if !containsString(desiredPrivileges, privilege) {
+				revoked = append(revoked, privilege)
+			}
+		}
+		if len(revoked) > 0 {
+			statements = append(statements, RevokeStatement(schemaName, tableName, grant.Role, revoked))
+		}
+
+		granted := []string{}
+		for _, privilege := range desiredPrivileges {
+			if !containsString(currentPrivileges, privilege) {
+				granted = append(granted, privilege)
+			}
+		}
+		if len(granted) > 0 {
+			statements = append(statements, GrantStatement(schemaName, tableName, grant.Role, granted))
+		}
+	}
+
+	return statements, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listTableGrants returns the privileges on the table of each role that they are granted to. The privileges
+// that postgres doesn't grant on tables, such as maintain, are left out
+func (p *PostgresConnection) listTableGrants(schemaName string, tableName string) (map[string][]string, error) {
+	query := `select grantee, lower(privilege_type)
+from information_schema.role_table_grants
+where table_name = $1
+and ` + schemaMatches("table_schema", 2)
+	rows, err := p.conn.Query(context.Background(), query, tableName, schemaName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query table grants")
+	}
+	defer rows.Close()
+
+	grants := map[string][]string{}
+	for rows.Next() {
+		var grantee, privilege string
+		if err := rows.Scan(&grantee, &privilege); err != nil {
+			return nil, errors.Wrap(err, "failed to scan table grant")
+		}
+
+		if !containsString(tablePrivileges, privilege) {
+			continue
+		}
+
+		grants[roleKey(grantee)] = append(grants[roleKey(grantee)], privilege)
+	}
+
+	return grants, nil
+}
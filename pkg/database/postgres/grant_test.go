@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_grantChangeStatements(t *testing.T) {
+	tests := []struct {
+		name               string
+		schemaName         string
+		grants             []*schemasv1alpha4.PostgresqlTableGrant
+		currentGrants      map[string][]string
+		expectedStatements []string
+		expectError        bool
+	}{
+		{
+			name: "unchanged grants",
+			grants: []*schemasv1alpha4.PostgresqlTableGrant{
+				{Role: "reporting", Privileges: []string{"SELECT"}},
+			},
+			currentGrants: map[string][]string{
+				"reporting": {"select"},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name:       "added grants",
+			schemaName: "sales",
+			grants: []*schemasv1alpha4.PostgresqlTableGrant{
+				{Role: "app", Privileges: []string{"update", "select", "insert"}},
+				{Role: "PUBLIC", Privileges: []string{"select"}},
+			},
+			currentGrants: map[string][]string{},
+			expectedStatements: []string{
+				`grant select, insert, update on table "sales"."orders" to "app"`,
+				`grant select on table "sales"."orders" to public`,
+			},
+		},
+		{
+			name: "changed grants",
+			grants: []*schemasv1alpha4.PostgresqlTableGrant{
+				{Role: "app", Privileges: []string{"select", "delete"}},
+			},
+			currentGrants: map[string][]string{
+				"app": {"select", "insert", "update"},
+			},
+			expectedStatements: []string{
+				`revoke insert, update on table "orders" from "app"`,
+				`grant delete on table "orders" to "app"`,
+			},
+		},
+		{
+			name: "all privileges",
+			grants: []*schemasv1alpha4.PostgresqlTableGrant{
+				{Role: "owner", Privileges: []string{"all"}},
+			},
+			currentGrants: map[string][]string{
+				"owner": {"select", "insert", "update", "delete"},
+			},
+			expectedStatements: []string{
+				`grant truncate, references, trigger on table "orders" to "owner"`,
+			},
+		},
+		{
+			name: "roles that aren't in the spec are kept",
+			grants: []*schemasv1alpha4.PostgresqlTableGrant{
+				{Role: "app", Privileges: []string{}},
+			},
+			currentGrants: map[string][]string{
+				"app":       {"select"},
+				"reporting": {"select"},
+			},
+			expectedStatements: []string{
+				`revoke select on table "orders" from "app"`,
+			},
+		},
+		{
+			name: "unknown privilege",
+			grants: []*schemasv1alpha4.PostgresqlTableGrant{
+				{Role: "app", Privileges: []string{"usage"}},
+			},
+			currentGrants: map[string][]string{},
+			expectError:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statements, err := grantChangeStatements(test.schemaName, "orders", test.grants, test.currentGrants)
+			if test.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
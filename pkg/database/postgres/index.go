@@ -9,35 +9,98 @@ import (
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func RemoveConstraintStatement(tableName string, index *types.Index) string {
-	return fmt.Sprintf("alter table %s drop constraint %s", pgx.Identifier{tableName}.Sanitize(), pgx.Identifier{index.Name}.Sanitize())
+func RemoveConstraintStatement(schemaName string, tableName string, index *types.Index) string {
+	return fmt.Sprintf("alter table %s drop constraint %s", qualifiedIdentifier(schemaName, tableName), pgx.Identifier{index.Name}.Sanitize())
 }
 
-func RemoveIndexStatement(tableName string, index *types.Index) string {
+func RemoveIndexStatement(schemaName string, tableName string, index *types.Index) string {
 	if index.IsUnique {
-		return fmt.Sprintf("drop index if exists %s", pgx.Identifier{index.Name}.Sanitize())
+		return fmt.Sprintf("drop index if exists %s", qualifiedIdentifier(schemaName, index.Name))
 	}
-	return fmt.Sprintf("drop index %s", pgx.Identifier{index.Name}.Sanitize())
+	return fmt.Sprintf("drop index %s", qualifiedIdentifier(schemaName, index.Name))
 }
 
-func AddIndexStatement(tableName string, schemaIndex *schemasv1alpha4.PostgresqlTableIndex) string {
+func AddIndexStatement(schemaName string, tableName string, schemaIndex *schemasv1alpha4.PostgresqlTableIndex) string {
+	return indexStatement(schemaName, tableName, schemaIndex, schemaIndex.Concurrently)
+}
+
+// indexStatement returns the create index statement. Indexes on a table that is being created are never built
+// concurrently, because there are no writes to the table to block
+func indexStatement(schemaName string, tableName string, schemaIndex *schemasv1alpha4.PostgresqlTableIndex, concurrently bool) string {
 	unique := ""
 	if schemaIndex.IsUnique {
 		unique = "unique "
 	}
 
+	concurrent := ""
+	if concurrently {
+		concurrent = "concurrently "
+	}
+
 	name := schemaIndex.Name
 	if name == "" {
 		name = types.GeneratePostgresqlIndexName(tableName, schemaIndex)
 	}
 
-	return fmt.Sprintf("create %sindex %s on %s (%s)",
-		unique,
-		name,
-		tableName,
-		strings.Join(schemaIndex.Columns, ", "))
+	statement := fmt.Sprintf("create %sindex %s%s on %s", unique, concurrent, name, qualifiedName(schemaName, tableName))
+	if schemaIndex.Type != "" {
+		statement = fmt.Sprintf("%s using %s", statement, strings.ToLower(schemaIndex.Type))
+	}
+	statement = fmt.Sprintf("%s (%s)", statement, strings.Join(indexKeys(schemaIndex), ", "))
+
+	if len(schemaIndex.Include) > 0 {
+		includeColumns := []string{}
+		for _, includeColumn := range schemaIndex.Include {
+			includeColumns = append(includeColumns, pgx.Identifier{includeColumn}.Sanitize())
+		}
+		statement = fmt.Sprintf("%s include (%s)", statement, strings.Join(includeColumns, ", "))
+	}
+
+	if schemaIndex.Where != "" {
+		statement = fmt.Sprintf("%s where %s", statement, schemaIndex.Where)
+	}
+
+	return statement
+}
+
+// indexKeys returns the columns of the index, or its keys with their operator class and ordering
+func indexKeys(schemaIndex *schemasv1alpha4.PostgresqlTableIndex) []string {
+	if len(schemaIndex.Keys) == 0 {
+		return schemaIndex.Columns
+	}
+
+	keys := []string{}
+	for _, key := range schemaIndex.Keys {
+		var definition string
+		if key.Column != "" {
+			definition = pgx.Identifier{key.Column}.Sanitize()
+		} else {
+			definition = fmt.Sprintf("(%s)", key.Expression)
+		}
+
+		if options := types.PostgresqlIndexKeyOptions(key); options != "" {
+			definition = fmt.Sprintf("%s %s", definition, options)
+		}
+		keys = append(keys, definition)
+	}
+
+	return keys
+}
+
+// isConstraintIndex returns true if the index can be created as a unique constraint in the create table
+// statement. Constraints can only be plain lists of columns
+func isConstraintIndex(schemaIndex *schemasv1alpha4.PostgresqlTableIndex) bool {
+	if !schemaIndex.IsUnique {
+		return false
+	}
+
+	if len(schemaIndex.Keys) > 0 || len(schemaIndex.Include) > 0 || schemaIndex.Where != "" {
+		return false
+	}
+
+	return schemaIndex.Type == "" || strings.EqualFold(schemaIndex.Type, "btree")
 }
 
-func RenameIndexStatement(tableName string, index *types.Index, schemaIndex *schemasv1alpha4.PostgresqlTableIndex) string {
-	return fmt.Sprintf("alter index %s rename to %s", pgx.Identifier{index.Name}.Sanitize(), pgx.Identifier{schemaIndex.Name}.Sanitize())
+func RenameIndexStatement(schemaName string, tableName string, index *types.Index, schemaIndex *schemasv1alpha4.PostgresqlTableIndex) string {
+	return fmt.Sprintf("alter index %s rename to %s", qualifiedIdentifier(schemaName, index.Name), pgx.Identifier{schemaIndex.Name}.Sanitize())
 }
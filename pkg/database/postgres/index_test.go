@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -11,6 +12,7 @@ import (
 func Test_AddIndexStatement(t *testing.T) {
 	tests := []struct {
 		name              string
+		schemaName        string
 		tableName         string
 		schemaIndex       *schemasv1alpha4.PostgresqlTableIndex
 		expectedStatement string
@@ -58,13 +60,126 @@ func Test_AddIndexStatement(t *testing.T) {
 			},
 			expectedStatement: `create unique index idx_t2_c1 on t2 (c1)`,
 		},
+		{
+			name:       "no name, one column, in schema",
+			schemaName: "app",
+			tableName:  "t2",
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Columns: []string{
+					"c1",
+				},
+			},
+			expectedStatement: `create index idx_t2_c1 on "app"."t2" (c1)`,
+		},
+		{
+			name:      "gin index, concurrently",
+			tableName: "t2",
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Columns: []string{
+					"tags",
+				},
+				Type:         "gin",
+				Concurrently: true,
+			},
+			expectedStatement: `create index concurrently idx_t2_tags on t2 using gin (tags)`,
+		},
+		{
+			name:      "expression key with opclass and ordering",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Keys: []schemasv1alpha4.PostgresqlTableIndexKey{
+					{Expression: "lower(email)", OpClass: "text_pattern_ops"},
+					{Column: "created_at", Order: "desc", Nulls: "last"},
+				},
+			},
+			expectedStatement: `create index idx_users_lower_email_created_at on users ((lower(email)) text_pattern_ops, "created_at" desc nulls last)`,
+		},
+		{
+			name:      "partial unique index with included columns",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Columns: []string{
+					"email",
+				},
+				IsUnique: true,
+				Include:  []string{"id", "name"},
+				Where:    "deleted_at is null",
+			},
+			expectedStatement: `create unique index idx_users_email on users (email) include ("id", "name") where deleted_at is null`,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			addIndexStatement := AddIndexStatement(test.tableName, test.schemaIndex)
+			addIndexStatement := AddIndexStatement(test.schemaName, test.tableName, test.schemaIndex)
 
 			assert.Equal(t, test.expectedStatement, addIndexStatement)
 		})
 	}
 }
+
+func Test_indexKeyOptions(t *testing.T) {
+	tests := []struct {
+		name               string
+		opClasses          []string
+		options            []int16
+		expectedKeyOptions []string
+	}{
+		{
+			name:               "defaults",
+			opClasses:          []string{"", ""},
+			options:            []int16{0, 0},
+			expectedKeyOptions: []string{"", ""},
+		},
+		{
+			name:               "desc with default nulls first",
+			opClasses:          []string{""},
+			options:            []int16{3},
+			expectedKeyOptions: []string{"desc"},
+		},
+		{
+			name:               "asc nulls first with opclass",
+			opClasses:          []string{"text_pattern_ops"},
+			options:            []int16{2},
+			expectedKeyOptions: []string{"text_pattern_ops nulls first"},
+		},
+		{
+			name:               "desc nulls last",
+			opClasses:          []string{""},
+			options:            []int16{1},
+			expectedKeyOptions: []string{"desc nulls last"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedKeyOptions, indexKeyOptions(len(test.options), test.opClasses, test.options))
+		})
+	}
+}
+
+func Test_indexChangeStatementsRenamedColumn(t *testing.T) {
+	tableSchema := &schemasv1alpha4.PostgresqlTableSchema{
+		Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+			{Name: "id", Type: "integer"},
+			{Name: "email", Type: "text", RenamedFrom: "email_address"},
+		},
+		Indexes: []*schemasv1alpha4.PostgresqlTableIndex{
+			{Name: "idx_users_email", Columns: []string{"email"}},
+		},
+	}
+	currentIndexes := []*types.Index{
+		{Name: "idx_users_email", Columns: []string{"email_address"}},
+	}
+
+	renames := types.ColumnRenames([]string{"id", "email_address"}, columnsRenamedFrom(tableSchema.Columns))
+	statements := []string{}
+	for existingName, newName := range renames {
+		statements = append(statements, RenameColumnStatement("", "users", existingName, newName))
+	}
+
+	types.RenameIndexColumns(currentIndexes, renames)
+	statements = append(statements, indexChangeStatements("users", tableSchema, currentIndexes, []string{})...)
+
+	assert.Equal(t, []string{`alter table "users" rename column "email_address" to "email"`}, statements)
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+func createLedgerTableStatement() string {
+	return fmt.Sprintf(`create table if not exists %s (migration_id text, table_name text, spec_sha text, ddl_hash text not null, applied_at timestamptz not null, duration_ms bigint not null)`, types.LedgerTableName)
+}
+
+// RecordLedgerEntry writes the entry to the migration ledger table, creating the table if it doesn't exist
+func RecordLedgerEntry(uri string, entry types.LedgerEntry) error {
+	p, err := Connect(uri)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect")
+	}
+	defer p.Close()
+
+	if _, err := p.conn.Exec(context.Background(), createLedgerTableStatement()); err != nil {
+		return errors.Wrap(err, "failed to create ledger table")
+	}
+
+	query := fmt.Sprintf(`insert into %s (migration_id, table_name, spec_sha, ddl_hash, applied_at, duration_ms) values ($1, $2, $3, $4, $5, $6)`, types.LedgerTableName)
+	if _, err := p.conn.Exec(context.Background(), query, entry.MigrationID, entry.TableName, entry.SpecSHA, entry.DDLHash, entry.AppliedAt.UTC(), entry.Duration.Milliseconds()); err != nil {
+		return errors.Wrap(err, "failed to insert ledger entry")
+	}
+
+	return nil
+}
+
+// LatestLedgerEntry returns the most recently applied ledger entry for the table, or nil if there is none
+func LatestLedgerEntry(uri string, tableName string) (*types.LedgerEntry, error) {
+	p, err := Connect(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect")
+	}
+	defer p.Close()
+
+	// the ledger table is created in the current schema, which is where the unqualified name below is read from
+	query := `select count(1) from information_schema.tables where table_name = $1 and ` + schemaMatches("table_schema", 2)
+	row := p.conn.QueryRow(context.Background(), query, types.LedgerTableName, "")
+	ledgerExists := 0
+	if err := row.Scan(&ledgerExists); err != nil {
+		return nil, errors.Wrap(err, "failed to scan")
+	}
+	if ledgerExists == 0 {
+		return nil, nil
+	}
+
+	query = fmt.Sprintf(`select coalesce(migration_id, ''), coalesce(table_name, ''), coalesce(spec_sha, ''), ddl_hash, applied_at, duration_ms from %s where table_name = $1 order by applied_at desc limit 1`, types.LedgerTableName)
+	row = p.conn.QueryRow(context.Background(), query, tableName)
+
+	entry := types.LedgerEntry{}
+	var durationMS int64
+	if err := row.Scan(&entry.MigrationID, &entry.TableName, &entry.SpecSHA, &entry.DDLHash, &entry.AppliedAt, &durationMS); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to scan ledger entry")
+	}
+
+	entry.Duration = time.Duration(durationMS) * time.Millisecond
+
+	return &entry, nil
+}
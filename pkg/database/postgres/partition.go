@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// ValidatePartitioning returns an error when the strategy isn't known, or when a partition doesn't have the
+// bounds that the strategy needs
+func ValidatePartitioning(partitioning *schemasv1alpha4.PostgresqlTablePartitioning) error {
+	strategy := strings.ToLower(partitioning.Strategy)
+	if strategy != "range" && strategy != "list" && strategy != "hash" {
+		return fmt.Errorf("partitioning strategy %q is not range, list or hash", partitioning.Strategy)
+	}
+	if strings.TrimSpace(partitioning.Key) == "" {
+		return errors.New("partitioning key is required")
+	}
+
+	for _, partition := range partitioning.Partitions {
+		if partition.IsDefault {
+			if strategy == "hash" {
+				return fmt.Errorf("hash partitioned tables can't have a default partition %q", partition.Name)
+			}
+			continue
+		}
+
+		switch strategy {
+		case "range":
+			if len(partition.From) == 0 || len(partition.To) == 0 {
+				return fmt.Errorf("range partition %q requires from and to", partition.Name)
+			}
+		case "list":
+			if len(partition.In) == 0 {
+				return fmt.Errorf("list partition %q requires in", partition.Name)
+			}
+		case "hash":
+			if partition.Modulus <= 0 || partition.Remainder < 0 || partition.Remainder >= partition.Modulus {
+				return fmt.Errorf("hash partition %q requires a modulus and a remainder that is less than it", partition.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func partitionByClause(partitioning *schemasv1alpha4.PostgresqlTablePartitioning) string {
+	return fmt.Sprintf("partition by %s (%s)", strings.ToLower(partitioning.Strategy), partitioning.Key)
+}
+
+// partitionBoundClause returns the bound of the partition in the form that pg_get_expr prints it
+func partitionBoundClause(strategy string, partition *schemasv1alpha4.PostgresqlTablePartition) string {
+	if partition.IsDefault {
+		return "default"
+	}
+
+	switch strings.ToLower(strategy) {
+	case "range":
+		return fmt.Sprintf("for values from (%s) to (%s)", strings.Join(partition.From, ", "), strings.Join(partition.To, ", "))
+	case "list":
+		return fmt.Sprintf("for values in (%s)", strings.Join(partition.In, ", "))
+	case "hash":
+		return fmt.Sprintf("for values with (modulus %d, remainder %d)", partition.Modulus, partition.Remainder)
+	}
+
+	return ""
+}
+
+func postgresqlSchemaPartitioningToPartitioning(partitioning *schemasv1alpha4.PostgresqlTablePartitioning) *types.Partitioning {
+	desired := types.Partitioning{
+		Strategy:   partitioning.Strategy,
+		Key:        partitioning.Key,
+		Partitions: []*types.Partition{},
+	}
+	for _, partition := range partitioning.Partitions {
+		desired.Partitions = append(desired.Partitions, &types.Partition{
+			Name:  partition.Name,
+			Bound: partitionBoundClause(partitioning.Strategy, partition),
+		})
+	}
+
+	return &desired
+}
+
+// CreatePartitionStatement returns the statement to create the partition. Partitions are in the schema of
+// the table
+func CreatePartitionStatement(schemaName string, tableName string, partition *types.Partition) string {
+	return fmt.Sprintf("create table %s partition of %s %s", qualifiedIdentifier(schemaName, partition.Name), qualifiedIdentifier(schemaName, tableName), partition.Bound)
+}
+
+// AttachPartitionStatement returns the statement to attach an existing table as a partition. Postgres checks
+// that the rows of the table are in the bound before it's attached
+func AttachPartitionStatement(schemaName string, tableName string, partition *types.Partition) string {
+	return fmt.Sprintf("alter table %s attach partition %s %s", qualifiedIdentifier(schemaName, tableName), qualifiedIdentifier(schemaName, partition.Name), partition.Bound)
+}
+
+// DetachPartitionStatement returns the statement to detach the partition. The partition is kept as a table,
+// and its rows are no longer in the partitioned table
+func DetachPartitionStatement(schemaName string, tableName string, partitionName string) string {
+	return fmt.Sprintf("alter table %s detach partition %s", qualifiedIdentifier(schemaName, tableName), qualifiedIdentifier(schemaName, partitionName))
+}
+
+// BuildPartitionStatements returns the statements to change the partitions of the table. The partitions of a
+// table without partitioning in the spec aren't changed
+func BuildPartitionStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
+	if postgresTableSchema.Partitioning == nil {
+		return []string{}, nil
+	}
+
+	if err := ValidatePartitioning(postgresTableSchema.Partitioning); err != nil {
+		return nil, errors.Wrap(err, "invalid partitioning")
+	}
+
+	currentPartitioning, err := p.getTablePartitioning(postgresTableSchema.Schema, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get table partitioning")
+	}
+
+	return partitionChangeStatements(postgresTableSchema.Schema, tableName, postgresTableSchema.Partitioning, currentPartitioning)
+}
+
+// partitionChangeStatements returns the statements to make the partitions of the table match the spec.
+// Partitions with a changed bound are detached and attached again, so their rows are kept. The table can't
+// be partitioned after it's created, so a different strategy or key is an error
+func partitionChangeStatements(schemaName string, tableName string, partitioning *schemasv1alpha4.PostgresqlTablePartitioning, currentPartitioning *types.Partitioning) ([]string, error) {
+	desired := postgresqlSchemaPartitioningToPartitioning(partitioning)
+	if !currentPartitioning.KeyEquals(desired) {
+		if currentPartitioning == nil {
+			return nil, fmt.Errorf("table %s isn't partitioned, and can't be partitioned after it's created", tableName)
+		}
+		return nil, fmt.Errorf("table %s is partitioned by %s (%s), and its partitioning can't be changed", tableName, strings.ToLower(currentPartitioning.Strategy), currentPartitioning.Key)
+	}
+
+	statements := []string{}
+
+	for _, currentPartition := range currentPartitioning.Partitions {
+		if desired.FindPartition(currentPartition.Name) != nil {
+			continue
+		}
+
+		statements = append(statements, DetachPartitionStatement(schemaName, tableName, currentPartition.Name))
+		if partitioning.DropRemovedPartitions {
+			statements = append(statements, fmt.Sprintf("drop table %s", qualifiedIdentifier(schemaName, currentPartition.Name)))
+		}
+	}
+
+	for _, desiredPartition := range desired.Partitions {
+		currentPartition := currentPartitioning.FindPartition(desiredPartition.Name)
+		if currentPartition == nil {
+			statements = append(statements, CreatePartitionStatement(schemaName, tableName, desiredPartition))
+			continue
+		}
+
+		if !currentPartition.Equals(desiredPartition) {
+			statements = append(statements, DetachPartitionStatement(schemaName, tableName, desiredPartition.Name))
+			statements = append(statements, AttachPartitionStatement(schemaName, tableName, desiredPartition))
+		}
+	}
+
+	return statements, nil
+}
+
+// getTablePartitioning returns the partitioning of the table, or nil when it isn't partitioned
+func (p *PostgresConnection) getTablePartitioning(schemaName string, tableName string) (*types.Partitioning, error) {
+	query := `select coalesce(pg_get_partkeydef(c.oid), '')
+from pg_class c
+join pg_namespace n on n.oid = c.relnamespace
+where c.relname = $1
+and ` + schemaMatches("n.nspname", 2)
+	row := p.conn.QueryRow(context.Background(), query, tableName, schemaName)
+
+	var keyDefinition string
+	if err := row.Scan(&keyDefinition); err != nil {
+		return nil, errors.Wrap(err, "failed to scan partition key")
+	}
+	if keyDefinition == "" {
+		return nil, nil
+	}
+
+	// the key is printed as the strategy followed by the key in parentheses, such as "RANGE (created_at)"
+	strategyAndKey := strings.SplitN(keyDefinition, " ", 2)
+	partitioning := types.Partitioning{
+		Strategy:   strings.ToLower(strategyAndKey[0]),
+		Partitions: []*types.Partition{},
+	}
+	if len(strategyAndKey) > 1 {
+		partitioning.Key = strings.TrimSpace(strategyAndKey[1])
+		partitioning.Key = strings.TrimSuffix(strings.TrimPrefix(partitioning.Key, "("), ")")
+	}
+
+	query = `select c.relname, pg_get_expr(c.relpartbound, c.oid)
+from pg_inherits i
+join pg_class c on c.oid = i.inhrelid
+join pg_class parent on parent.oid = i.inhparent
+join pg_namespace n on n.oid = parent.relnamespace
+where parent.relname = $1
+and ` + schemaMatches("n.nspname", 2) + `
+and c.relispartition
+order by c.relname`
+	rows, err := p.conn.Query(context.Background(), query, tableName, schemaName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query partitions")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		partition := types.Partition{}
+		if err := rows.Scan(&partition.Name, &partition.Bound); err != nil {
+			return nil, errors.Wrap(err, "failed to scan partition")
+		}
+
+		partitioning.Partitions = append(partitioning.Partitions, &partition)
+	}
+
+	return &partitioning, nil
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_partitionChangeStatements(t *testing.T) {
+	tests := []struct {
+		name                string
+		schemaName          string
+		partitioning        *schemasv1alpha4.PostgresqlTablePartitioning
+		currentPartitioning *types.Partitioning
+		expectedStatements  []string
+		expectError         bool
+	}{
+		{
+			name: "unchanged partitions",
+			partitioning: &schemasv1alpha4.PostgresqlTablePartitioning{
+				Strategy: "list",
+				Key:      "region",
+				Partitions: []*schemasv1alpha4.PostgresqlTablePartition{
+					{Name: "orders_eu", In: []string{"'de'", "'fr'"}},
+				},
+			},
+			currentPartitioning: &types.Partitioning{
+				Strategy: "list",
+				Key:      "region",
+				Partitions: []*types.Partition{
+					{Name: "orders_eu", Bound: "FOR VALUES IN ('de', 'fr')"},
+				},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name:       "added and removed partitions",
+			schemaName: "sales",
+			partitioning: &schemasv1alpha4.PostgresqlTablePartitioning{
+				Strategy: "range",
+				Key:      "created_at",
+				Partitions: []*schemasv1alpha4.PostgresqlTablePartition{
+					{Name: "orders_2024", From: []string{"'2024-01-01'"}, To: []string{"'2025-01-01'"}},
+					{Name: "orders_2025", From: []string{"'2025-01-01'"}, To: []string{"'2026-01-01'"}},
+				},
+			},
+			currentPartitioning: &types.Partitioning{
+				Strategy: "range",
+				Key:      "created_at",
+				Partitions: []*types.Partition{
+					{Name: "orders_2023", Bound: "FOR VALUES FROM ('2023-01-01') TO ('2024-01-01')"},
+					{Name: "orders_2024", Bound: "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')"},
+				},
+			},
+			expectedStatements: []string{
+				`alter table "sales"."orders" detach partition "sales"."orders_2023"`,
+				`create table "sales"."orders_2025" partition of "sales"."orders" for values from ('2025-01-01') to ('2026-01-01')`,
+			},
+		},
+		{
+			name: "dropped partition",
+			partitioning: &schemasv1alpha4.PostgresqlTablePartitioning{
+				Strategy:              "hash",
+				Key:                   "id",
+				DropRemovedPartitions: true,
+				Partitions: []*schemasv1alpha4.PostgresqlTablePartition{
+					{Name: "orders_0", Modulus: 2, Remainder: 0},
+				},
+			},
+			currentPartitioning: &types.Partitioning{
+				Strategy: "hash",
+				Key:      "id",
+				Partitions: []*types.Partition{
+					{Name: "orders_0", Bound: "FOR VALUES WITH (modulus 2, remainder 0)"},
+					{Name: "orders_1", Bound: "FOR VALUES WITH (modulus 2, remainder 1)"},
+				},
+			},
+			expectedStatements: []string{
+				`alter table "orders" detach partition "orders_1"`,
+				`drop table "orders_1"`,
+			},
+		},
+		{
+			name: "changed bound",
+			partitioning: &schemasv1alpha4.PostgresqlTablePartitioning{
+				Strategy: "list",
+				Key:      "region",
+				Partitions: []*schemasv1alpha4.PostgresqlTablePartition{
+					{Name: "orders_eu", In: []string{"'de'", "'fr'", "'it'"}},
+				},
+			},
+			currentPartitioning: &types.Partitioning{
+				Strategy: "list",
+				Key:      "region",
+				Partitions: []*types.Partition{
+					{Name: "orders_eu", Bound: "FOR VALUES IN ('de', 'fr')"},
+				},
+			},
+			expectedStatements: []string{
+				`alter table "orders" detach partition "orders_eu"`,
+				`alter table "orders" attach partition "orders_eu" for values in ('de', 'fr', 'it')`,
+			},
+		},
+		{
+			name: "table isn't partitioned",
+			partitioning: &schemasv1alpha4.PostgresqlTablePartitioning{
+				Strategy: "range",
+				Key:      "created_at",
+			},
+			expectError: true,
+		},
+		{
+			name: "changed key",
+			partitioning: &schemasv1alpha4.PostgresqlTablePartitioning{
+				Strategy: "range",
+				Key:      "updated_at",
+			},
+			currentPartitioning: &types.Partitioning{
+				Strategy:   "range",
+				Key:        "created_at",
+				Partitions: []*types.Partition{},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			statements, err := partitionChangeStatements(test.schemaName, "orders", test.partitioning, test.currentPartitioning)
+			if test.expectError {
+				req.Error(err)
+				return
+			}
+			req.NoError(err)
+
+			assert.Equal(t, test.expectedStatements, statements)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// ValidatePolicy returns an error when the command isn't known, or when the policy has an expression that
+// postgres doesn't allow for the command
+func ValidatePolicy(schemaPolicy *schemasv1alpha4.PostgresqlTablePolicy) error {
+	policy := types.PostgresqlSchemaPolicyToPolicy(schemaPolicy)
+	switch policy.Command {
+	case "all", "update":
+	case "select", "delete":
+		if policy.WithCheck != "" {
+			return fmt.Errorf("%s policy %q can't have a with check expression", policy.Command, policy.Name)
+		}
+	case "insert":
+		if policy.Using != "" {
+			return fmt.Errorf("insert policy %q can't have a using expression", policy.Name)
+		}
+	default:
+		return fmt.Errorf("policy %q has command %q, which is not all, select, insert, update or delete", policy.Name, schemaPolicy.Command)
+	}
+
+	return nil
+}
+
+func CreatePolicyStatement(schemaName string, tableName string, policy *types.Policy) string {
+	roles := []string{}
+	for _, role := range policy.Roles {
+		roles = append(roles, roleIdentifier(role))
+	}
+
+	statement := fmt.Sprintf("create policy %s on %s for %s to %s", pgx.Identifier{policy.Name}.Sanitize(), qualifiedIdentifier(schemaName, tableName), policy.Command, strings.Join(roles, ", "))
+	if policy.Using != "" {
+		statement = fmt.Sprintf("%s using (%s)", statement, policy.Using)
+	}
+	if policy.WithCheck != "" {
+		statement = fmt.Sprintf("%s with check (%s)", statement, policy.WithCheck)
+	}
+
+	return statement
+}
+
+func DropPolicyStatement(schemaName string, tableName string, policyName string) string {
+	return fmt.Sprintf("drop policy if exists %s on %s", pgx.Identifier{policyName}.Sanitize(), qualifiedIdentifier(schemaName, tableName))
+}
+
+// createPolicyStatements returns the statements to create the policies of a new table, and then to turn on
+// row level security so that the table isn't readable without the policies
+func createPolicyStatements(tableName string, tableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
+	statements := []string{}
+	for _, policy := range tableSchema.Policies {
+		if err := ValidatePolicy(policy); err != nil {
+			return nil, errors.Wrap(err, "invalid policy")
+		}
+
+		statements = append(statements, CreatePolicyStatement(tableSchema.Schema, tableName, types.PostgresqlSchemaPolicyToPolicy(policy)))
+	}
+
+	return append(statements, rowLevelSecurityStatements(tableSchema.Schema, tableName, tableSchema.RowLevelSecurity, false, false)...), nil
+}
+
+// BuildPolicyStatements returns the statements to change the policies and the row level security of the table.
+// Tables without policies or row level security in the spec aren't changed
+func BuildPolicyStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, error) {
+	if len(postgresTableSchema.Policies) == 0 && postgresTableSchema.RowLevelSecurity == nil {
+		return []string{}, nil
+	}
+
+	for _, policy := range postgresTableSchema.Policies {
+		if err := ValidatePolicy(policy); err != nil {
+			return nil, errors.Wrap(err, "invalid policy")
+		}
+	}
+
+	currentPolicies, err := p.listTablePolicies(postgresTableSchema.Schema, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list table policies")
+	}
+
+	isEnabled, isForced, err := p.getRowLevelSecurity(postgresTableSchema.Schema, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get row level security")
+	}
+
+	statements := policyChangeStatements(postgresTableSchema.Schema, tableName, postgresTableSchema.Policies, currentPolicies)
+	statements = append(statements, rowLevelSecurityStatements(postgresTableSchema.Schema, tableName, postgresTableSchema.RowLevelSecurity, isEnabled, isForced)...)
+
+	return statements, nil
+}
+
+func policyChangeStatements(schemaName string, tableName string, desiredPolicies []*schemasv1alpha4.PostgresqlTablePolicy, currentPolicies []*types.Policy) []string {
+	statements := []string{}
+
+	// changed policies are dropped and created again
+NextCurrentPolicy:
+	for _, currentPolicy := range currentPolicies {
+		for _, desiredPolicy := range desiredPolicies {
+			if currentPolicy.Equals(types.PostgresqlSchemaPolicyToPolicy(desiredPolicy)) {
+				continue NextCurrentPolicy
+			}
+		}
+
+		statements = append(statements, DropPolicyStatement(schemaName, tableName, currentPolicy.Name))
+	}
+
+NextDesiredPolicy:
+	for _, desiredPolicy := range desiredPolicies {
+		policy := types.PostgresqlSchemaPolicyToPolicy(desiredPolicy)
+		for _, currentPolicy := range currentPolicies {
+			if currentPolicy.Equals(policy) {
+				continue NextDesiredPolicy
+			}
+		}
+
+		statements = append(statements, CreatePolicyStatement(schemaName, tableName, policy))
+	}
+
+	return statements
+}
+
+// rowLevelSecurityStatements returns the statements to turn row level security on or off, and to force it on
+// the owner of the table. Row level security isn't changed when it's not in the spec
+func rowLevelSecurityStatements(schemaName string, tableName string, rowLevelSecurity *schemasv1alpha4.PostgresqlTableRowLevelSecurity, isEnabled bool, isForced bool) []string {
+	if rowLevelSecurity == nil {
+		return []string{}
+	}
+
+	table := qualifiedIdentifier(schemaName, tableName)
+	statements := []string{}
+	if rowLevelSecurity.Enable != isEnabled {
+		if rowLevelSecurity.Enable {
+			statements = append(statements, fmt.Sprintf("alter table %s enable row level security", table))
+		} else {
+			statements = append(statements, fmt.Sprintf("alter table %s disable row level security", table))
+		}
+	}
+	if rowLevelSecurity.Force != isForced {
+		if rowLevelSecurity.Force {
+			statements = append(statements, fmt.Sprintf("alter table %s force row level security", table))
+		} else {
+			statements = append(statements, fmt.Sprintf("alter table %s no force row level security", table))
+		}
+	}
+
+	return statements
+}
+
+func (p *PostgresConnection) getRowLevelSecurity(schemaName string, tableName string) (bool, bool, error) {
+	query := `select relrowsecurity, relforcerowsecurity from pg_class where oid = $1::regclass`
+	row := p.conn.QueryRow(context.Background(), query, qualifiedIdentifier(schemaName, tableName))
+
+	var isEnabled, isForced bool
+	if err := row.Scan(&isEnabled, &isForced); err != nil {
+		return false, false, errors.Wrap(err, "failed to scan row level security")
+	}
+
+	return isEnabled, isForced, nil
+}
+
+// listTablePolicies returns the row level security policies on the table
+func (p *PostgresConnection) listTablePolicies(schemaName string, tableName string) ([]*types.Policy, error) {
+	query := `select policyname, lower(cmd), roles::text[], coalesce(qual, ''), coalesce(with_check, '')
+from pg_policies
+where tablename = $1
+and ` + schemaMatches("schemaname", 2)
+	rows, err := p.conn.Query(context.Background(), query, tableName, schemaName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query policies")
+	}
+	defer rows.Close()
+
+	policies := []*types.Policy{}
+	for rows.Next() {
+		policy := types.Policy{}
+		if err := rows.Scan(&policy.Name, &policy.Command, &policy.Roles, &policy.Using, &policy.WithCheck); err != nil {
+			return nil, errors.Wrap(err, "failed to scan policy")
+		}
+
+		sort.Strings(policy.Roles)
+		policies = append(policies, &policy)
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      *schemasv1alpha4.PostgresqlTablePolicy
+		expectError bool
+	}{
+		{
+			name:   "all with both expressions",
+			policy: &schemasv1alpha4.PostgresqlTablePolicy{Name: "owner", Using: "owner = current_user", WithCheck: "owner = current_user"},
+		},
+		{
+			name:        "unknown command",
+			policy:      &schemasv1alpha4.PostgresqlTablePolicy{Name: "owner", Command: "merge"},
+			expectError: true,
+		},
+		{
+			name:        "insert with using",
+			policy:      &schemasv1alpha4.PostgresqlTablePolicy{Name: "owner", Command: "insert", Using: "owner = current_user"},
+			expectError: true,
+		},
+		{
+			name:        "select with check",
+			policy:      &schemasv1alpha4.PostgresqlTablePolicy{Name: "owner", Command: "SELECT", WithCheck: "owner = current_user"},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidatePolicy(test.policy)
+			if test.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_policyChangeStatements(t *testing.T) {
+	tests := []struct {
+		name               string
+		schemaName         string
+		desiredPolicies    []*schemasv1alpha4.PostgresqlTablePolicy
+		currentPolicies    []*types.Policy
+		expectedStatements []string
+	}{
+		{
+			name: "unchanged policy",
+			desiredPolicies: []*schemasv1alpha4.PostgresqlTablePolicy{
+				{Name: "owner", Roles: []string{"app"}, Using: "owner = current_user"},
+			},
+			currentPolicies: []*types.Policy{
+				{Name: "owner", Command: "all", Roles: []string{"app"}, Using: "(owner = CURRENT_USER)"},
+			},
+			expectedStatements: []string{},
+		},
+		{
+			name:       "added policy",
+			schemaName: "sales",
+			desiredPolicies: []*schemasv1alpha4.PostgresqlTablePolicy{
+				{Name: "insert_own", Command: "insert", Roles: []string{"app", "reporting"}, WithCheck: "owner = current_user"},
+			},
+			currentPolicies: []*types.Policy{},
+			expectedStatements: []string{
+				`create policy "insert_own" on "sales"."orders" for insert to "app", "reporting" with check (owner = current_user)`,
+			},
+		},
+		{
+			name: "changed policy",
+			desiredPolicies: []*schemasv1alpha4.PostgresqlTablePolicy{
+				{Name: "owner", Command: "select", Using: "owner = session_user"},
+			},
+			currentPolicies: []*types.Policy{
+				{Name: "owner", Command: "select", Roles: []string{"public"}, Using: "(owner = CURRENT_USER)"},
+			},
+			expectedStatements: []string{
+				`drop policy if exists "owner" on "orders"`,
+				`create policy "owner" on "orders" for select to public using (owner = session_user)`,
+			},
+		},
+		{
+			name:            "removed policy",
+			desiredPolicies: []*schemasv1alpha4.PostgresqlTablePolicy{},
+			currentPolicies: []*types.Policy{
+				{Name: "owner", Command: "all", Roles: []string{"public"}, Using: "(owner = CURRENT_USER)"},
+			},
+			expectedStatements: []string{
+				`drop policy if exists "owner" on "orders"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatements, policyChangeStatements(test.schemaName, "orders", test.desiredPolicies, test.currentPolicies))
+		})
+	}
+}
+
+func Test_rowLevelSecurityStatements(t *testing.T) {
+	tests := []struct {
+		name               string
+		rowLevelSecurity   *schemasv1alpha4.PostgresqlTableRowLevelSecurity
+		isEnabled          bool
+		isForced           bool
+		expectedStatements []string
+	}{
+		{
+			name:               "not in the spec",
+			isEnabled:          true,
+			expectedStatements: []string{},
+		},
+		{
+			name:             "enable and force",
+			rowLevelSecurity: &schemasv1alpha4.PostgresqlTableRowLevelSecurity{Enable: true, Force: true},
+			expectedStatements: []string{
+				`alter table "orders" enable row level security`,
+				`alter table "orders" force row level security`,
+			},
+		},
+		{
+			name:             "disable",
+			rowLevelSecurity: &schemasv1alpha4.PostgresqlTableRowLevelSecurity{},
+			isEnabled:        true,
+			isForced:         true,
+			expectedStatements: []string{
+				`alter table "orders" disable row level security`,
+				`alter table "orders" no force row level security`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedStatements, rowLevelSecurityStatements("", "orders", test.rowLevelSecurity, test.isEnabled, test.isForced))
+		})
+	}
+}
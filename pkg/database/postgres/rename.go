@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// RenameTableStatement returns the statement to rename an existing table to its desired name. The table stays
+// in the same schema
+func RenameTableStatement(schemaName string, existingName string, desiredName string) string {
+	return fmt.Sprintf(`alter table %s rename to %s`, qualifiedIdentifier(schemaName, existingName), pgx.Identifier{desiredName}.Sanitize())
+}
+
+// withGeneratedNames returns a copy of the schema with the index and foreign key names that are generated from
+// the table name set explicitly. A renamed table is planned under its existing name, so this keeps the names
+// of its indexes and foreign keys the same as they will be when it's planned under the new name
+func withGeneratedNames(tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) *schemasv1alpha4.PostgresqlTableSchema {
+	named := postgresTableSchema.DeepCopy()
+
+	for _, index := range named.Indexes {
+		if index.Name == "" {
+			index.Name = types.GeneratePostgresqlIndexName(tableName, index)
+		}
+	}
+
+	for _, foreignKey := range named.ForeignKeys {
+		if foreignKey.Name == "" {
+			foreignKey.Name = types.GeneratePostgresqlFKName(tableName, foreignKey)
+		}
+	}
+
+	return named
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RenameTableStatement(t *testing.T) {
+	assert.Equal(t, `alter table "users" rename to "accounts"`, RenameTableStatement("", "users", "accounts"))
+	assert.Equal(t, `alter table "app"."users" rename to "accounts"`, RenameTableStatement("app", "users", "accounts"))
+}
+
+func Test_withGeneratedNames(t *testing.T) {
+	schema := &schemasv1alpha4.PostgresqlTableSchema{
+		Indexes: []*schemasv1alpha4.PostgresqlTableIndex{
+			{
+				Columns: []string{"email"},
+			},
+			{
+				Columns: []string{"name"},
+				Name:    "users_name",
+			},
+		},
+		ForeignKeys: []*schemasv1alpha4.PostgresqlTableForeignKey{
+			{
+				Columns: []string{"org_id"},
+				References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{
+					Table:   "orgs",
+					Columns: []string{"id"},
+				},
+			},
+		},
+	}
+
+	named := withGeneratedNames("accounts", schema)
+	assert.Equal(t, "idx_accounts_email", named.Indexes[0].Name)
+	assert.Equal(t, "users_name", named.Indexes[1].Name)
+	assert.Equal(t, "accounts_org_id_fkey", named.ForeignKeys[0].Name)
+
+	// the schema from the spec isn't changed
+	assert.Equal(t, "", schema.Indexes[0].Name)
+	assert.Equal(t, "", schema.ForeignKeys[0].Name)
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+)
+
+// schemaMatches returns a condition that the column is the schema passed in the numbered parameter, or the
+// current schema when the parameter is empty. Tables that aren't in a named schema are created in the current
+// schema, so that's where they are looked up
+func schemaMatches(column string, param int) string {
+	return fmt.Sprintf("%s = coalesce(nullif($%d::text, ''), current_schema())", column, param)
+}
+
+// qualifiedIdentifier returns the quoted name of a table or index, qualified with the schema when one is set
+func qualifiedIdentifier(schemaName string, name string) string {
+	if schemaName == "" {
+		return pgx.Identifier{name}.Sanitize()
+	}
+
+	return pgx.Identifier{schemaName, name}.Sanitize()
+}
+
+// qualifiedName returns the name of a table for the statements that don't quote it. Names in a schema are
+// qualified and quoted, and names in the current schema are returned as they are
+func qualifiedName(schemaName string, name string) string {
+	if schemaName == "" {
+		return name
+	}
+
+	return pgx.Identifier{schemaName, name}.Sanitize()
+}
+
+// CreateSchemaStatement returns the statement to create the schema if it doesn't exist
+func CreateSchemaStatement(schemaName string) string {
+	return fmt.Sprintf(`create schema if not exists %s`, pgx.Identifier{schemaName}.Sanitize())
+}
+
+func (p *PostgresConnection) schemaExists(schemaName string) (bool, error) {
+	query := `select count(1) from information_schema.schemata where schema_name = $1`
+	row := p.conn.QueryRow(context.Background(), query, schemaName)
+	schemaExists := 0
+	if err := row.Scan(&schemaExists); err != nil {
+		return false, errors.Wrap(err, "failed to scan")
+	}
+
+	return schemaExists > 0, nil
+}
+
+func (p *PostgresConnection) tableExists(schemaName string, tableName string) (bool, error) {
+	query := `select count(1) from information_schema.tables where table_name = $1 and ` + schemaMatches("table_schema", 2)
+	row := p.conn.QueryRow(context.Background(), query, tableName, schemaName)
+	tableExists := 0
+	if err := row.Scan(&tableExists); err != nil {
+		return false, errors.Wrap(err, "failed to scan")
+	}
+
+	return tableExists > 0, nil
+}
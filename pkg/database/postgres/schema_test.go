@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_qualifiedIdentifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaName string
+		tableName  string
+		identifier string
+		qualified  string
+	}{
+		{
+			name:       "current schema",
+			tableName:  "users",
+			identifier: `"users"`,
+			qualified:  `users`,
+		},
+		{
+			name:       "named schema",
+			schemaName: "app",
+			tableName:  "users",
+			identifier: `"app"."users"`,
+			qualified:  `"app"."users"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.identifier, qualifiedIdentifier(test.schemaName, test.tableName))
+			assert.Equal(t, test.qualified, qualifiedName(test.schemaName, test.tableName))
+		})
+	}
+}
+
+func Test_CreateSchemaStatement(t *testing.T) {
+	assert.Equal(t, `create schema if not exists "app"`, CreateSchemaStatement("app"))
+}
@@ -0,0 +1,259 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// identityGeneration returns how the values of an identity column are generated, "always" or "by default"
+func identityGeneration(generated string) string {
+	if strings.EqualFold(strings.TrimSpace(generated), "always") {
+		return "always"
+	}
+
+	return "by default"
+}
+
+// sequenceOptionsClause returns the options of a sequence, leaving out the ones that aren't set
+func sequenceOptionsClause(options types.SequenceOptions) string {
+	clauses := []string{}
+	if options.Increment != nil {
+		clauses = append(clauses, fmt.Sprintf("increment by %d", *options.Increment))
+	}
+	if options.MinValue != nil {
+		clauses = append(clauses, fmt.Sprintf("minvalue %d", *options.MinValue))
+	}
+	if options.MaxValue != nil {
+		clauses = append(clauses, fmt.Sprintf("maxvalue %d", *options.MaxValue))
+	}
+	if options.Start != nil {
+		clauses = append(clauses, fmt.Sprintf("start with %d", *options.Start))
+	}
+	if options.Cycle {
+		clauses = append(clauses, "cycle")
+	}
+
+	return strings.Join(clauses, " ")
+}
+
+// changedSequenceOptions returns the options that are set in desired and are different in current
+func changedSequenceOptions(current types.SequenceOptions, desired types.SequenceOptions) []string {
+	changes := []string{}
+	if desired.Increment != nil && (current.Increment == nil || *current.Increment != *desired.Increment) {
+		changes = append(changes, fmt.Sprintf("increment by %d", *desired.Increment))
+	}
+	if desired.MinValue != nil && (current.MinValue == nil || *current.MinValue != *desired.MinValue) {
+		changes = append(changes, fmt.Sprintf("minvalue %d", *desired.MinValue))
+	}
+	if desired.MaxValue != nil && (current.MaxValue == nil || *current.MaxValue != *desired.MaxValue) {
+		changes = append(changes, fmt.Sprintf("maxvalue %d", *desired.MaxValue))
+	}
+	if desired.Start != nil && (current.Start == nil || *current.Start != *desired.Start) {
+		changes = append(changes, fmt.Sprintf("start with %d", *desired.Start))
+	}
+	if current.Cycle != desired.Cycle {
+		if desired.Cycle {
+			changes = append(changes, "cycle")
+		} else {
+			changes = append(changes, "no cycle")
+		}
+	}
+
+	return changes
+}
+
+// identityClause returns the clause that makes a column an identity column
+func identityClause(identity *types.ColumnIdentity) string {
+	clause := fmt.Sprintf("generated %s as identity", identity.Generation)
+
+	options := sequenceOptionsClause(identity.Options)
+	if options != "" {
+		clause = fmt.Sprintf("%s (%s)", clause, options)
+	}
+
+	return clause
+}
+
+// alterIdentityStatements returns the statements to make the identity of the existing column match the desired
+// column. A serial column that becomes an identity column has its sequence replaced, and the identity continues
+// after the existing values unless it has a start
+func alterIdentityStatements(schemaName string, tableName string, existingColumn *types.Column, column *types.Column) []string {
+	if types.IdentitiesMatch(existingColumn.Identity, column.Identity) {
+		return []string{}
+	}
+
+	table := qualifiedIdentifier(schemaName, tableName)
+	alterColumn := fmt.Sprintf("alter table %s alter column %s", table, pgx.Identifier{column.Name}.Sanitize())
+
+	if column.Identity == nil {
+		return []string{fmt.Sprintf("%s drop identity if exists", alterColumn)}
+	}
+
+	if existingColumn.Identity == nil {
+		statements := []string{}
+		if existingColumn.OwnedSequence != "" {
+			statements = append(statements, fmt.Sprintf("drop sequence if exists %s", existingColumn.OwnedSequence))
+		}
+
+		statements = append(statements, fmt.Sprintf("%s add %s", alterColumn, identityClause(column.Identity)))
+
+		if column.Identity.Options.Start == nil {
+			statements = append(statements, fmt.Sprintf("select setval(pg_get_serial_sequence('%s', '%s'), coalesce(max(%s), 0) + 1, false) from %s",
+				table, column.Name, pgx.Identifier{column.Name}.Sanitize(), table))
+		}
+
+		return statements
+	}
+
+	changes := []string{}
+	if existingColumn.Identity.Generation != column.Identity.Generation {
+		changes = append(changes, fmt.Sprintf("set generated %s", column.Identity.Generation))
+	}
+	for _, change := range changedSequenceOptions(existingColumn.Identity.Options, column.Identity.Options) {
+		changes = append(changes, fmt.Sprintf("set %s", change))
+	}
+
+	return []string{fmt.Sprintf("%s %s", alterColumn, strings.Join(changes, " "))}
+}
+
+// parseSequenceValue returns the value of a sequence option that information_schema reports as text
+func parseSequenceValue(value sql.NullString) *int64 {
+	if !value.Valid {
+		return nil
+	}
+
+	parsed, err := strconv.ParseInt(value.String, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &parsed
+}
+
+// CreateSequenceStatement returns the statement to create the sequence. The owner is set separately, after the
+// column that owns it is created
+func CreateSequenceStatement(schemaName string, sequence *types.Sequence) string {
+	statement := fmt.Sprintf("create sequence if not exists %s", qualifiedIdentifier(schemaName, sequence.Name))
+
+	options := sequenceOptionsClause(sequence.Options)
+	if options != "" {
+		statement = fmt.Sprintf("%s %s", statement, options)
+	}
+
+	return statement
+}
+
+// SequenceOwnedByStatement returns the statement to make the column of the table own the sequence, or to remove
+// the owner when column is empty
+func SequenceOwnedByStatement(schemaName string, sequenceName string, tableName string, column string) string {
+	ownedBy := "none"
+	if column != "" {
+		if schemaName == "" {
+			ownedBy = pgx.Identifier{tableName, column}.Sanitize()
+		} else {
+			ownedBy = pgx.Identifier{schemaName, tableName, column}.Sanitize()
+		}
+	}
+
+	return fmt.Sprintf("alter sequence %s owned by %s", qualifiedIdentifier(schemaName, sequenceName), ownedBy)
+}
+
+// BuildSequenceStatements returns the statements to create and alter the sequences of the table, and the
+// statements to set their owners, which have to run after the columns are created. Sequences that are removed
+// from the spec aren't dropped, because the serial and identity columns of the table have sequences too
+func BuildSequenceStatements(p *PostgresConnection, tableName string, postgresTableSchema *schemasv1alpha4.PostgresqlTableSchema) ([]string, []string, error) {
+	if len(postgresTableSchema.Sequences) == 0 {
+		return []string{}, []string{}, nil
+	}
+
+	currentSequences, err := p.listSequences(postgresTableSchema.Schema, tableName)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list sequences")
+	}
+
+	statements, ownerStatements := sequenceChangeStatements(postgresTableSchema.Schema, tableName, postgresTableSchema.Sequences, currentSequences)
+	return statements, ownerStatements, nil
+}
+
+func sequenceChangeStatements(schemaName string, tableName string, desiredSequences []*schemasv1alpha4.PostgresqlSequence, currentSequences []*types.Sequence) ([]string, []string) {
+	statements := []string{}
+	ownerStatements := []string{}
+
+	for _, desiredSequence := range desiredSequences {
+		desired := types.PostgresqlSchemaSequenceToSequence(desiredSequence)
+
+		var current *types.Sequence
+		for _, currentSequence := range currentSequences {
+			if currentSequence.Name == desired.Name {
+				current = currentSequence
+			}
+		}
+
+		if current == nil {
+			statements = append(statements, CreateSequenceStatement(schemaName, desired))
+			if desired.OwnedBy != "" {
+				ownerStatements = append(ownerStatements, SequenceOwnedByStatement(schemaName, desired.Name, tableName, desired.OwnedBy))
+			}
+			continue
+		}
+
+		changes := changedSequenceOptions(current.Options, desired.Options)
+		if len(changes) > 0 {
+			statements = append(statements, fmt.Sprintf("alter sequence %s %s", qualifiedIdentifier(schemaName, desired.Name), strings.Join(changes, " ")))
+		}
+
+		if current.OwnedBy != desired.OwnedBy {
+			ownerStatements = append(ownerStatements, SequenceOwnedByStatement(schemaName, desired.Name, tableName, desired.OwnedBy))
+		}
+	}
+
+	return statements, ownerStatements
+}
+
+// listSequences returns the sequences in the schema, or in the current schema when schemaName is empty. The
+// owner of a sequence is only set when it's a column of the table
+func (p *PostgresConnection) listSequences(schemaName string, tableName string) ([]*types.Sequence, error) {
+	query := `select s.sequencename, s.start_value, s.increment_by, s.min_value, s.max_value, s.cycle,
+coalesce((
+  select a.attname from pg_depend d
+  join pg_class t on t.oid = d.refobjid
+  join pg_attribute a on a.attrelid = d.refobjid and a.attnum = d.refobjsubid
+  where d.classid = 'pg_class'::regclass
+  and d.objid = (quote_ident(s.schemaname) || '.' || quote_ident(s.sequencename))::regclass
+  and d.deptype = 'a'
+  and t.relname = $2
+), '')
+from pg_sequences s
+where ` + schemaMatches("s.schemaname", 1)
+	rows, err := p.conn.Query(context.Background(), query, schemaName, tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query sequences")
+	}
+	defer rows.Close()
+
+	sequences := []*types.Sequence{}
+	for rows.Next() {
+		var start, increment, minValue, maxValue int64
+		sequence := types.Sequence{}
+		if err := rows.Scan(&sequence.Name, &start, &increment, &minValue, &maxValue, &sequence.Options.Cycle, &sequence.OwnedBy); err != nil {
+			return nil, errors.Wrap(err, "failed to scan sequence")
+		}
+
+		sequence.Options.Start = &start
+		sequence.Options.Increment = &increment
+		sequence.Options.MinValue = &minValue
+		sequence.Options.MaxValue = &maxValue
+
+		sequences = append(sequences, &sequence)
+	}
+
+	return sequences, nil
+}
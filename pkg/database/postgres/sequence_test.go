@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_alterIdentityStatements(t *testing.T) {
+	one := int64(1)
+	ten := int64(10)
+
+	tests := []struct {
+		name           string
+		schemaName     string
+		existingColumn *types.Column
+		column         *types.Column
+		want           []string
+	}{
+		{
+			name:           "serial to identity",
+			existingColumn: &types.Column{Name: "id", DataType: "integer", OwnedSequence: "public.users_id_seq"},
+			column:         &types.Column{Name: "id", DataType: "integer", Identity: &types.ColumnIdentity{Generation: "by default"}},
+			want: []string{
+				`drop sequence if exists public.users_id_seq`,
+				`alter table "users" alter column "id" add generated by default as identity`,
+				`select setval(pg_get_serial_sequence('"users"', 'id'), coalesce(max("id"), 0) + 1, false) from "users"`,
+			},
+		},
+		{
+			name:           "add identity with start",
+			schemaName:     "app",
+			existingColumn: &types.Column{Name: "id", DataType: "bigint"},
+			column: &types.Column{Name: "id", DataType: "bigint", Identity: &types.ColumnIdentity{
+				Generation: "always",
+				Options:    types.SequenceOptions{Start: &ten},
+			}},
+			want: []string{
+				`alter table "app"."users" alter column "id" add generated always as identity (start with 10)`,
+			},
+		},
+		{
+			name: "change identity",
+			existingColumn: &types.Column{Name: "id", DataType: "bigint", Identity: &types.ColumnIdentity{
+				Generation: "by default",
+				Options:    types.SequenceOptions{Start: &one, Increment: &one},
+			}},
+			column: &types.Column{Name: "id", DataType: "bigint", Identity: &types.ColumnIdentity{
+				Generation: "always",
+				Options:    types.SequenceOptions{Increment: &ten, Cycle: true},
+			}},
+			want: []string{
+				`alter table "users" alter column "id" set generated always set increment by 10 set cycle`,
+			},
+		},
+		{
+			name: "unchanged identity",
+			existingColumn: &types.Column{Name: "id", DataType: "bigint", Identity: &types.ColumnIdentity{
+				Generation: "always",
+				Options:    types.SequenceOptions{Start: &one, Increment: &one},
+			}},
+			column: &types.Column{Name: "id", DataType: "bigint", Identity: &types.ColumnIdentity{Generation: "always"}},
+			want:   []string{},
+		},
+		{
+			name:           "drop identity",
+			existingColumn: &types.Column{Name: "id", DataType: "bigint", Identity: &types.ColumnIdentity{Generation: "always"}},
+			column:         &types.Column{Name: "id", DataType: "bigint"},
+			want: []string{
+				`alter table "users" alter column "id" drop identity if exists`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, alterIdentityStatements(tt.schemaName, "users", tt.existingColumn, tt.column))
+		})
+	}
+}
+
+func Test_sequenceChangeStatements(t *testing.T) {
+	one := int64(1)
+	hundred := int64(100)
+
+	tests := []struct {
+		name                  string
+		desiredSequences      []*schemasv1alpha4.PostgresqlSequence
+		currentSequences      []*types.Sequence
+		wantStatements        []string
+		wantOwnedByStatements []string
+	}{
+		{
+			name: "create sequence",
+			desiredSequences: []*schemasv1alpha4.PostgresqlSequence{
+				{
+					Name:    "order_number_seq",
+					Options: &schemasv1alpha4.PostgresqlSequenceOptions{Start: &hundred},
+					OwnedBy: "order_number",
+				},
+			},
+			currentSequences: []*types.Sequence{},
+			wantStatements: []string{
+				`create sequence if not exists "order_number_seq" start with 100`,
+			},
+			wantOwnedByStatements: []string{
+				`alter sequence "order_number_seq" owned by "orders"."order_number"`,
+			},
+		},
+		{
+			name: "alter sequence",
+			desiredSequences: []*schemasv1alpha4.PostgresqlSequence{
+				{
+					Name:    "order_number_seq",
+					Options: &schemasv1alpha4.PostgresqlSequenceOptions{MaxValue: &hundred, Cycle: true},
+				},
+			},
+			currentSequences: []*types.Sequence{
+				{
+					Name:    "order_number_seq",
+					Options: types.SequenceOptions{Start: &one, Increment: &one},
+					OwnedBy: "order_number",
+				},
+			},
+			wantStatements: []string{
+				`alter sequence "order_number_seq" maxvalue 100 cycle`,
+			},
+			wantOwnedByStatements: []string{
+				`alter sequence "order_number_seq" owned by none`,
+			},
+		},
+		{
+			name: "unchanged sequence",
+			desiredSequences: []*schemasv1alpha4.PostgresqlSequence{
+				{
+					Name:    "order_number_seq",
+					OwnedBy: "order_number",
+				},
+			},
+			currentSequences: []*types.Sequence{
+				{
+					Name:    "order_number_seq",
+					Options: types.SequenceOptions{Start: &one, Increment: &one},
+					OwnedBy: "order_number",
+				},
+			},
+			wantStatements:        []string{},
+			wantOwnedByStatements: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, ownedByStatements := sequenceChangeStatements("", "orders", tt.desiredSequences, tt.currentSequences)
+			assert.Equal(t, tt.wantStatements, statements)
+			assert.Equal(t, tt.wantOwnedByStatements, ownedByStatements)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
@@ -15,7 +16,8 @@ var (
 )
 
 func (p *PostgresConnection) ListTables() ([]*types.Table, error) {
-	query := "select table_name from information_schema.tables where table_catalog = $1 and table_schema = $2"
+	query := `select table_name, coalesce(obj_description((quote_ident(table_schema) || '.' || quote_ident(table_name))::regclass, 'pg_class'), '')
+from information_schema.tables where table_catalog = $1 and table_schema = $2`
 
 	rows, err := p.conn.Query(context.Background(), query, p.databaseName, "public")
 	if err != nil {
@@ -25,13 +27,14 @@ func (p *PostgresConnection) ListTables() ([]*types.Table, error) {
 
 	tables := []*types.Table{}
 	for rows.Next() {
-		tableName := ""
-		if err := rows.Scan(&tableName); err != nil {
+		tableName, comment := "", ""
+		if err := rows.Scan(&tableName, &comment); err != nil {
 			return nil, errors.Wrap(err, "failed to scan row")
 		}
 
 		tables = append(tables, &types.Table{
-			Name: tableName,
+			Name:    tableName,
+			Comment: comment,
 		})
 	}
 
@@ -39,9 +42,15 @@ func (p *PostgresConnection) ListTables() ([]*types.Table, error) {
 }
 
 func (p *PostgresConnection) ListTableConstraints(databaseName string, tableName string) ([]string, error) {
+	return p.listTableConstraints(databaseName, "", tableName)
+}
+
+// listTableConstraints returns the names of the constraints on the table in the schema, or in the current schema
+// when schemaName is empty
+func (p *PostgresConnection) listTableConstraints(databaseName string, schemaName string, tableName string) ([]string, error) {
 	query := `select constraint_name from information_schema.table_constraints
-		where table_catalog = $1 and table_name = $2`
-	rows, err := p.conn.Query(context.Background(), query, databaseName, tableName)
+		where table_catalog = $1 and table_name = $2 and ` + schemaMatches("table_schema", 3)
+	rows, err := p.conn.Query(context.Background(), query, databaseName, tableName, schemaName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list constraints")
 	}
@@ -61,7 +70,14 @@ func (p *PostgresConnection) ListTableConstraints(databaseName string, tableName
 }
 
 func (p *PostgresConnection) ListTableIndexes(databaseName string, tableName string) ([]*types.Index, error) {
+	return p.listTableIndexes("", tableName)
+}
+
+// listTableIndexes returns the indexes on the table in the schema, or in the current schema when schemaName
+// is empty
+func (p *PostgresConnection) listTableIndexes(schemaName string, tableName string) ([]*types.Index, error) {
 	// started with this: https://stackoverflow.com/questions/6777456/list-all-index-names-column-names-and-its-table-name-of-a-postgresql-database
+	// the key columns come before the included columns, and only the key columns have operator classes and options
 	query := `select
 	i.relname as indname,
 	am.amname as indam,
@@ -69,14 +85,33 @@ func (p *PostgresConnection) ListTableIndexes(databaseName string, tableName str
 	array(
 	  select pg_get_indexdef(idx.indexrelid, k + 1, true)
 	  from generate_subscripts(idx.indkey, 1) as k
+	  where k < idx.indnkeyatts
+	  order by k
+	) as indkey_names,
+	array(
+	  select case when opc.oid is null or opc.opcdefault then '' else opc.opcname end
+	  from generate_subscripts(idx.indclass, 1) as k
+	  left join pg_opclass as opc on opc.oid = idx.indclass[k]
+	  order by k
+	) as indkey_opclasses,
+	array(
+	  select idx.indoption[k]
+	  from generate_subscripts(idx.indoption, 1) as k
 	  order by k
-	) as indkey_names
+	) as indkey_options,
+	array(
+	  select pg_get_indexdef(idx.indexrelid, k + 1, true)
+	  from generate_subscripts(idx.indkey, 1) as k
+	  where k >= idx.indnkeyatts
+	  order by k
+	) as include_names,
+	coalesce(pg_get_expr(idx.indpred, idx.indrelid, true), '') as indpred
 	from pg_index as idx
 	join pg_class as i on i.oid = idx.indexrelid
 	join pg_am as am on i.relam = am.oid
 	where idx.indrelid = $1::regclass
 	and idx.indisprimary = false`
-	rows, err := p.conn.Query(context.Background(), query, tableName)
+	rows, err := p.conn.Query(context.Background(), query, qualifiedIdentifier(schemaName, tableName))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query indexes")
 	}
@@ -85,13 +120,19 @@ func (p *PostgresConnection) ListTableIndexes(databaseName string, tableName str
 	indexes := make([]*types.Index, 0)
 	for rows.Next() {
 		var index types.Index
-		var method string
 		var columns []string
-		if err := rows.Scan(&index.Name, &method, &index.IsUnique, &columns); err != nil {
+		var opClasses []string
+		var options []int16
+		var includeColumns []string
+		if err := rows.Scan(&index.Name, &index.Method, &index.IsUnique, &columns, &opClasses, &options, &includeColumns, &index.Where); err != nil {
 			return nil, err
 		}
 
 		index.Columns = columns
+		index.KeyOptions = indexKeyOptions(len(columns), opClasses, options)
+		if len(includeColumns) > 0 {
+			index.Include = includeColumns
+		}
 
 		indexes = append(indexes, &index)
 	}
@@ -99,15 +140,55 @@ func (p *PostgresConnection) ListTableIndexes(databaseName string, tableName str
 	return indexes, nil
 }
 
+// indexKeyOptions returns the options of each key column from its operator class and the indoption flags,
+// leaving out the defaults so they compare equal to keys that don't set them
+func indexKeyOptions(columnCount int, opClasses []string, options []int16) []string {
+	const (
+		indoptionDesc       = 0x0001
+		indoptionNullsFirst = 0x0002
+	)
+
+	keyOptions := []string{}
+	for i := 0; i < columnCount; i++ {
+		key := schemasv1alpha4.PostgresqlTableIndexKey{}
+		if i < len(opClasses) {
+			key.OpClass = opClasses[i]
+		}
+		if i < len(options) {
+			if options[i]&indoptionDesc != 0 {
+				key.Order = "desc"
+			}
+			if options[i]&indoptionNullsFirst != 0 {
+				key.Nulls = "first"
+			} else {
+				key.Nulls = "last"
+			}
+		}
+
+		keyOptions = append(keyOptions, types.PostgresqlIndexKeyOptions(key))
+	}
+
+	return keyOptions
+}
+
 func (p *PostgresConnection) ListTableForeignKeys(databaseName string, tableName string) ([]*types.ForeignKey, error) {
+	return p.listTableForeignKeys("", tableName)
+}
+
+// listTableForeignKeys returns the foreign keys on the table in the schema, or in the current schema when
+// schemaName is empty
+func (p *PostgresConnection) listTableForeignKeys(schemaName string, tableName string) ([]*types.ForeignKey, error) {
 	// Starting with a query here: https://stackoverflow.com/questions/1152260/postgres-sql-to-list-table-foreign-keys
-	// TODO SchemaHero implementation needs to include a schema (database) here
 	// this is pg specific because composite fks need to be handled and this might be the only way?
 	query := `select
 	att2.attname as "child_column",
 	cl.relname as "parent_table",
 	att.attname as "parent_column",
   	rc.delete_rule,
+	rc.update_rule,
+	rc.match_option,
+	con.condeferrable,
+	con.condeferred,
 	conname
     from
        (select
@@ -115,13 +196,17 @@ func (p *PostgresConnection) ListTableForeignKeys(databaseName string, tableName
 	    unnest(con1.confkey) as "child",
 	    con1.confrelid,
 	    con1.conrelid,
-	    con1.conname
+	    con1.conname,
+	    con1.condeferrable,
+	    con1.condeferred,
+	    ns.nspname
 	from
 	    pg_class cl
 	    join pg_namespace ns on cl.relnamespace = ns.oid
 	    join pg_constraint con1 on con1.conrelid = cl.oid
 	where
 	    cl.relname = $1
+	    and ` + schemaMatches("ns.nspname", 2) + `
 	    and con1.contype = 'f'
        ) con
        join pg_attribute att on
@@ -131,9 +216,9 @@ func (p *PostgresConnection) ListTableForeignKeys(databaseName string, tableName
        join pg_attribute att2 on
 	   att2.attrelid = con.conrelid and att2.attnum = con.parent
        join information_schema.referential_constraints rc on
-       rc.constraint_name = conname`
+       rc.constraint_name = conname and rc.constraint_schema = con.nspname`
 
-	rows, err := p.conn.Query(context.Background(), query, tableName)
+	rows, err := p.conn.Query(context.Background(), query, tableName, schemaName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query foreign keys")
 	}
@@ -141,24 +226,29 @@ func (p *PostgresConnection) ListTableForeignKeys(databaseName string, tableName
 
 	foreignKeys := make([]*types.ForeignKey, 0)
 	for rows.Next() {
-		var childColumn, parentColumn, parentTable, name, deleteRule string
+		var childColumn, parentColumn, parentTable, name, deleteRule, updateRule, matchOption string
+		var deferrable, initiallyDeferred bool
 
-		if err := rows.Scan(&childColumn, &parentTable, &parentColumn, &deleteRule, &name); err != nil {
+		if err := rows.Scan(&childColumn, &parentTable, &parentColumn, &deleteRule, &updateRule, &matchOption, &deferrable, &initiallyDeferred, &name); err != nil {
 			return nil, err
 		}
 
 		foreignKey := types.ForeignKey{
-			Name:          name,
-			ParentTable:   parentTable,
-			OnDelete:      deleteRule,
-			ChildColumns:  []string{childColumn},
-			ParentColumns: []string{parentColumn},
+			Name:              name,
+			ParentTable:       parentTable,
+			OnDelete:          deleteRule,
+			OnUpdate:          updateRule,
+			Match:             matchOption,
+			Deferrable:        deferrable,
+			InitiallyDeferred: initiallyDeferred,
+			ChildColumns:      []string{childColumn},
+			ParentColumns:     []string{parentColumn},
 		}
 
 		for _, foundFk := range foreignKeys {
 			if foundFk.Name == name {
-				foundFk.ChildColumns = append(foreignKey.ChildColumns, childColumn)
-				foundFk.ParentColumns = append(foreignKey.ParentColumns, parentColumn)
+				foundFk.ChildColumns = append(foundFk.ChildColumns, childColumn)
+				foundFk.ParentColumns = append(foundFk.ParentColumns, parentColumn)
 
 				goto Appended
 			}
@@ -172,17 +262,48 @@ func (p *PostgresConnection) ListTableForeignKeys(databaseName string, tableName
 	return foreignKeys, nil
 }
 
+func (p *PostgresConnection) ListColumnRenames(tableName string, desiredColumns []*schemasv1alpha4.PostgresqlTableColumn) (map[string]string, error) {
+	return p.listColumnRenames("", tableName, desiredColumns)
+}
+
+// listColumnRenames returns the columns of the table that the desired columns rename, keyed by their current
+// names. The existing indexes, foreign keys and checks still use the current names until the renames are made
+func (p *PostgresConnection) listColumnRenames(schemaName string, tableName string, desiredColumns []*schemasv1alpha4.PostgresqlTableColumn) (map[string]string, error) {
+	query := `select column_name from information_schema.columns where table_name = $1 and ` + schemaMatches("table_schema", 2)
+	rows, err := p.conn.Query(context.Background(), query, tableName, schemaName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query columns")
+	}
+	defer rows.Close()
+
+	columnNames := []string{}
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, errors.Wrap(err, "failed to scan column")
+		}
+		columnNames = append(columnNames, columnName)
+	}
+
+	return types.ColumnRenames(columnNames, columnsRenamedFrom(desiredColumns)), nil
+}
+
 func (p *PostgresConnection) GetTablePrimaryKey(tableName string) (*types.KeyConstraint, error) {
-	// TODO we should be adding a database name on this select
+	return p.getTablePrimaryKey("", tableName)
+}
+
+// getTablePrimaryKey returns the primary key of the table in the schema, or in the current schema when schemaName
+// is empty
+func (p *PostgresConnection) getTablePrimaryKey(schemaName string, tableName string) (*types.KeyConstraint, error) {
 	query := `select tc.constraint_name, c.column_name
 from information_schema.table_constraints tc
 join information_schema.constraint_column_usage as ccu using (constraint_schema, constraint_name)
 join information_schema.columns as c on c.table_schema = tc.constraint_schema
   and tc.table_name = c.table_name and ccu.column_name = c.column_name
-where constraint_type = 'PRIMARY KEY' and tc.table_name = $1
+where constraint_type = 'PRIMARY KEY' and tc.table_name = $1 and ` + schemaMatches("tc.table_schema", 2) + `
 order by c.ordinal_position`
 
-	rows, err := p.conn.Query(context.Background(), query, tableName)
+	rows, err := p.conn.Query(context.Background(), query, tableName, schemaName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query primary keys")
 	}
@@ -213,7 +334,9 @@ order by c.ordinal_position`
 }
 
 func (p *PostgresConnection) GetTableSchema(tableName string) ([]*types.Column, error) {
-	query := "select column_name, data_type, character_maximum_length, column_default, is_nullable from information_schema.columns where table_name = $1 and table_schema = $2"
+	query := `select column_name, data_type, character_maximum_length, column_default, is_nullable,
+coalesce(col_description((quote_ident(table_schema) || '.' || quote_ident(table_name))::regclass, ordinal_position), '')
+from information_schema.columns where table_name = $1 and table_schema = $2`
 
 	rows, err := p.conn.Query(context.Background(), query, tableName, p.databaseName)
 	if err != nil {
@@ -229,7 +352,7 @@ func (p *PostgresConnection) GetTableSchema(tableName string) ([]*types.Column,
 		var isNullable string
 		var columnDefault sql.NullString
 
-		if err := rows.Scan(&column.Name, &column.DataType, &maxLength, &columnDefault, &isNullable); err != nil {
+		if err := rows.Scan(&column.Name, &column.DataType, &maxLength, &columnDefault, &isNullable, &column.Comment); err != nil {
 			return nil, err
 		}
 
@@ -8,7 +8,7 @@ import (
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 )
 
-func triggerCreateStatement(trigger *schemasv1alpha4.PostgresqlTableTrigger, tableName string) (string, error) {
+func triggerCreateStatement(trigger *schemasv1alpha4.PostgresqlTableTrigger, schemaName string, tableName string) (string, error) {
 	triggerEventSyntax, err := triggerEvent(trigger)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create trigger event syntax")
@@ -19,7 +19,7 @@ func triggerCreateStatement(trigger *schemasv1alpha4.PostgresqlTableTrigger, tab
 		o = "constraint trigger"
 	}
 
-	stmt := fmt.Sprintf(`create %s %q %s on %q`, o, trigger.Name, triggerEventSyntax, tableName)
+	stmt := fmt.Sprintf(`create %s %q %s on %s`, o, trigger.Name, triggerEventSyntax, qualifiedIdentifier(schemaName, tableName))
 
 	forEachStatement := true // pg default
 	if trigger.ForEachRow != nil && *trigger.ForEachRow {
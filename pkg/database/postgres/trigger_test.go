@@ -14,6 +14,7 @@ func Test_triggerCreateStatement(t *testing.T) {
 
 	tests := []struct {
 		name              string
+		schemaName        string
 		trigger           *schemasv1alpha4.PostgresqlTableTrigger
 		tableName         string
 		expectedStatement string
@@ -73,13 +74,27 @@ func Test_triggerCreateStatement(t *testing.T) {
 			tableName:         "a",
 			expectedStatement: `create constraint trigger "tt" before insert on "a" for each statement execute procedure fn()`,
 		},
+		{
+			name:       "after insert in schema",
+			schemaName: "app",
+			trigger: &schemasv1alpha4.PostgresqlTableTrigger{
+				Name: "tt",
+				Events: []string{
+					"after insert",
+				},
+				ForEachRow:       &trueValue,
+				ExecuteProcedure: "fn()",
+			},
+			tableName:         "a",
+			expectedStatement: `create trigger "tt" after insert on "app"."a" for each row execute procedure fn()`,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			req := require.New(t)
 
-			actual, err := triggerCreateStatement(test.trigger, test.tableName)
+			actual, err := triggerCreateStatement(test.trigger, test.schemaName, test.tableName)
 			req.NoError(err)
 
 			assert.Equal(t, test.expectedStatement, actual)
@@ -11,7 +11,10 @@ import (
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func RecreateTableStatements(tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema) ([]string, error) {
+// RecreateTableStatements returns the statements that rebuild the table by renaming it, creating it again and
+// copying the rows back. The existing columns that aren't copied back unchanged are noted on the statement that
+// drops the renamed table, so that the rebuild is classified as data loss
+func RecreateTableStatements(tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema, existingColumns []types.Column) ([]string, error) {
 	statements := []string{}
 
 	// to make this deterministic (and testable) generate a hash of the new schema
@@ -39,18 +42,64 @@ func RecreateTableStatements(tableName string, rqliteTableSchema *schemasv1alpha
 		fmt.Sprintf("insert into %s (%s) select %s from %s", tableName, strings.Join(columnNames, ", "), strings.Join(columnNames, ", "), tempTableName),
 	)
 
-	statements = append(statements, fmt.Sprintf("drop table %s", tempTableName))
+	lostColumns, err := uncopiedColumns(existingColumns, rqliteTableSchema.Columns)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compare columns")
+	}
+	if len(lostColumns) > 0 {
+		statements = append(statements, fmt.Sprintf("drop table %s -- columns %s aren't copied unchanged", tempTableName, strings.Join(lostColumns, ", ")))
+	} else {
+		statements = append(statements, fmt.Sprintf("drop table %s", tempTableName))
+	}
 
 	return statements, nil
 }
 
-func BuildAlterIndexStatements(r *RqliteConnection, tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema) ([]string, error) {
-	indexStatements := []string{}
+// uncopiedColumns returns the existing columns that a rebuild of the table doesn't copy back, or copies into a
+// column with another type. Generated columns are computed, so they aren't copied
+func uncopiedColumns(existingColumns []types.Column, desiredColumns []*schemasv1alpha4.RqliteTableColumn) ([]string, error) {
+	lostColumns := []string{}
+NextExistingColumn:
+	for _, existingColumn := range existingColumns {
+		if existingColumn.Generated != nil {
+			continue
+		}
 
+		for _, desiredColumn := range desiredColumns {
+			if desiredColumn.Name != existingColumn.Name {
+				continue
+			}
+
+			column, err := schemaColumnToColumn(desiredColumn)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to convert desired column")
+			}
+			if column.Generated == nil && strings.EqualFold(column.DataType, existingColumn.DataType) {
+				continue NextExistingColumn
+			}
+			break
+		}
+
+		lostColumns = append(lostColumns, existingColumn.Name)
+	}
+
+	return lostColumns, nil
+}
+
+// BuildAlterIndexStatements returns the statements to make the indexes of the table match the spec. The current
+// indexes are compared by the new names of the columns in renames, which are renamed before the indexes are changed
+func BuildAlterIndexStatements(r *RqliteConnection, tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema, renames map[string]string) ([]string, error) {
 	currentIndexes, err := r.ListTableIndexes("", tableName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list table indexes")
 	}
+	types.RenameIndexColumns(currentIndexes, renames)
+
+	return alterIndexStatements(tableName, rqliteTableSchema, currentIndexes), nil
+}
+
+func alterIndexStatements(tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema, currentIndexes []*types.Index) []string {
+	indexStatements := []string{}
 
 desiredIndexesLoop:
 	for _, desiredIndex := range rqliteTableSchema.Indexes {
@@ -81,7 +130,7 @@ currentIndexesLoop:
 		indexStatements = append(indexStatements, RemoveIndexStatement(tableName, currentIndex))
 	}
 
-	return indexStatements, nil
+	return indexStatements
 }
 
 func columnsMatch(col1 types.Column, col2 types.Column) bool {
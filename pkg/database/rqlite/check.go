@@ -0,0 +1,33 @@
+package rqlite
+
+import (
+	"fmt"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+func checkConstraintClause(check *schemasv1alpha4.RqliteTableCheck) string {
+	return fmt.Sprintf(`constraint "%s" check (%s)`, check.Name, check.Expression)
+}
+
+// checksMatch returns true if the table has the same named checks as the spec. Checks can't be added or dropped
+// in rqlite, so the table is recreated when they don't match
+func checksMatch(desiredChecks []*schemasv1alpha4.RqliteTableCheck, currentChecks []*types.Check) bool {
+	if len(desiredChecks) != len(currentChecks) {
+		return false
+	}
+
+NextDesiredCheck:
+	for _, desiredCheck := range desiredChecks {
+		for _, currentCheck := range currentChecks {
+			if currentCheck.Equals(types.RqliteSchemaCheckToCheck(desiredCheck)) {
+				continue NextDesiredCheck
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
@@ -71,6 +71,23 @@ func InsertColumnStatement(tableName string, desiredColumn *schemasv1alpha4.Rqli
 	return statement, nil
 }
 
+// RenameColumnStatement returns the statement to rename an existing column to its desired name
+func RenameColumnStatement(tableName string, existingName string, desiredName string) string {
+	return fmt.Sprintf(`alter table "%s" rename column "%s" to "%s"`, tableName, existingName, desiredName)
+}
+
+// columnsRenamedFrom returns the previous name of each desired column that was renamed, keyed by the desired name
+func columnsRenamedFrom(desiredColumns []*schemasv1alpha4.RqliteTableColumn) map[string]string {
+	renamedFrom := map[string]string{}
+	for _, desiredColumn := range desiredColumns {
+		if desiredColumn.RenamedFrom != "" {
+			renamedFrom[desiredColumn.Name] = desiredColumn.RenamedFrom
+		}
+	}
+
+	return renamedFrom
+}
+
 func DropColumnStatement(tableName string, existingColumn types.Column) (string, error) {
 	statement := fmt.Sprintf(`alter table "%s" drop column "%s"`, tableName, existingColumn.Name)
 	return statement, nil
@@ -55,6 +55,10 @@ func CreateTableStatements(tableName string, tableSchema *schemasv1alpha4.Rqlite
 		}
 	}
 
+	for _, check := range tableSchema.Checks {
+		columns = append(columns, checkConstraintClause(check))
+	}
+
 	query := fmt.Sprintf(`create table "%s" (%s)`, tableName, strings.Join(columns, ", "))
 	if tableSchema.Strict {
 		query = fmt.Sprintf("%s strict", query)
@@ -14,7 +14,7 @@ func PlanRQLiteView(url string, viewName string, rqliteViewSchema *schemasv1alph
 	return nil, errors.New("not implemented")
 }
 
-func PlanRqliteTable(url string, tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+func PlanRqliteTable(url string, tableName string, renamedFrom string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
 	r, err := Connect(url)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to rqlite")
@@ -35,6 +35,31 @@ func PlanRqliteTable(url string, tableName string, rqliteTableSchema *schemasv1a
 		return nil, errors.Wrap(err, "failed to scan")
 	}
 
+	// a renamed table is changed to match the spec under its existing name, and then renamed
+	renameTo := ""
+	if tableExists == 0 && renamedFrom != "" && renamedFrom != tableName && !rqliteTableSchema.IsDeleted {
+		row, err := r.db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+			Query:     "select count(1) from sqlite_master where type=? and name=?",
+			Arguments: []interface{}{"table", renamedFrom},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query from sqlite_master")
+		}
+		row.Next()
+
+		existingTableExists := 0
+		if err := row.Scan(&existingTableExists); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		if existingTableExists > 0 {
+			renameTo = tableName
+			tableName = renamedFrom
+			tableExists = existingTableExists
+			rqliteTableSchema = withGeneratedNames(renameTo, rqliteTableSchema)
+		}
+	}
+
 	if tableExists == 0 && rqliteTableSchema.IsDeleted {
 		return []string{}, nil
 	} else if tableExists > 0 && rqliteTableSchema.IsDeleted {
@@ -67,6 +92,10 @@ func PlanRqliteTable(url string, tableName string, rqliteTableSchema *schemasv1a
 	}
 	statements = append(statements, seedDataStatements...)
 
+	if renameTo != "" {
+		statements = append(statements, RenameTableStatement(tableName, renameTo))
+	}
+
 	return statements, nil
 }
 
@@ -132,13 +161,26 @@ AND m.name = ?`
 		existingColumns = append(existingColumns, existingColumn)
 	}
 
-	tableNeedsRecreate, err := checkTableNeedsRecreate(r, tableName, rqliteTableSchema, existingColumns)
+	// renamed columns are compared to the desired columns by their new name
+	existingColumnNames := []string{}
+	for _, existingColumn := range existingColumns {
+		existingColumnNames = append(existingColumnNames, existingColumn.Name)
+	}
+	renames := types.ColumnRenames(existingColumnNames, columnsRenamedFrom(rqliteTableSchema.Columns))
+	for i, existingColumn := range existingColumns {
+		if newName, ok := renames[existingColumn.Name]; ok {
+			statements = append(statements, RenameColumnStatement(tableName, existingColumn.Name, newName))
+			existingColumns[i].Name = newName
+		}
+	}
+
+	tableNeedsRecreate, err := checkTableNeedsRecreate(r, tableName, rqliteTableSchema, existingColumns, renames)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to check if table needs recreate")
 	}
 
 	if tableNeedsRecreate {
-		hardWayStatements, err := RecreateTableStatements(tableName, rqliteTableSchema)
+		hardWayStatements, err := RecreateTableStatements(tableName, rqliteTableSchema, existingColumns)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create recreate table statements")
 		}
@@ -165,7 +207,7 @@ AND m.name = ?`
 		}
 
 		// if there are unique indexes, they'll have to be removed before dropping their columns
-		indexStatements, err := BuildAlterIndexStatements(r, tableName, rqliteTableSchema)
+		indexStatements, err := BuildAlterIndexStatements(r, tableName, rqliteTableSchema, renames)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to build alter index statements")
 		}
@@ -194,12 +236,14 @@ AND m.name = ?`
 	return statements, nil
 }
 
-func checkTableNeedsRecreate(r *RqliteConnection, tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema, existingColumns []types.Column) (bool, error) {
+func checkTableNeedsRecreate(r *RqliteConnection, tableName string, rqliteTableSchema *schemasv1alpha4.RqliteTableSchema, existingColumns []types.Column, renames map[string]string) (bool, error) {
 	// check if primary keys match
 	existingPrimaryKey, err := r.GetTablePrimaryKeyColumns(tableName)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to get table primary key")
 	}
+	// renaming a column renames it in the primary key too
+	existingPrimaryKey = types.RenameColumnNames(existingPrimaryKey, renames)
 	if len(existingPrimaryKey) != len(rqliteTableSchema.PrimaryKey) {
 		return true, nil
 	}
@@ -218,6 +262,7 @@ nextPrimaryKeyColumn:
 	if err != nil {
 		return false, errors.Wrap(err, "failed to list table foreign keys")
 	}
+	types.RenameForeignKeyColumns(existingForeignKeys, renames)
 	if len(existingForeignKeys) != len(rqliteTableSchema.ForeignKeys) {
 		return true, nil
 	}
@@ -231,6 +276,16 @@ nextForeignKey:
 		return true, nil
 	}
 
+	// check if check constraints match, when the spec has checks
+	existingChecks, err := r.ListTableChecks(tableName)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list table checks")
+	}
+	types.RenameCheckColumns(existingChecks, renames)
+	if rqliteTableSchema.Checks != nil && !checksMatch(rqliteTableSchema.Checks, existingChecks) {
+		return true, nil
+	}
+
 	// check if columns were modified (ok if added or removed)
 	for _, existingColumn := range existingColumns {
 		for _, desiredColumn := range rqliteTableSchema.Columns {
@@ -251,6 +306,7 @@ nextForeignKey:
 	if err != nil {
 		return false, errors.Wrap(err, "failed to list table indexes")
 	}
+	types.RenameIndexColumns(currentIndexes, renames)
 
 desiredIndexesLoop:
 	for _, desiredIndex := range rqliteTableSchema.Indexes {
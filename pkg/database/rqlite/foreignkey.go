@@ -9,9 +9,15 @@ import (
 )
 
 func foreignKeyConstraintClause(tableName string, schemaForeignKey *schemasv1alpha4.RqliteTableForeignKey) string {
-	onDelete := ""
+	options := ""
+	if schemaForeignKey.Match != "" {
+		options = fmt.Sprintf("%s match %s", options, schemaForeignKey.Match)
+	}
 	if schemaForeignKey.OnDelete != "" {
-		onDelete = fmt.Sprintf(" on delete %s", schemaForeignKey.OnDelete)
+		options = fmt.Sprintf("%s on delete %s", options, schemaForeignKey.OnDelete)
+	}
+	if schemaForeignKey.OnUpdate != "" {
+		options = fmt.Sprintf("%s on update %s", options, schemaForeignKey.OnUpdate)
 	}
 
 	return fmt.Sprintf("constraint %s foreign key (%s) references %s (%s)%s",
@@ -19,5 +25,5 @@ func foreignKeyConstraintClause(tableName string, schemaForeignKey *schemasv1alp
 		strings.Join(schemaForeignKey.Columns, ", "),
 		schemaForeignKey.References.Table,
 		strings.Join(schemaForeignKey.References.Columns, ", "),
-		onDelete)
+		options)
 }
@@ -107,8 +107,28 @@ WHERE m.type='table' AND m.name=? AND il.name=? AND il.origin!='pk'
 	return isConstraint, nil
 }
 
+// ListTableChecks returns the named check constraints on the table, parsed from the statement that created it
+func (r *RqliteConnection) ListTableChecks(tableName string) ([]*types.Check, error) {
+	rows, err := r.db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     "select sql from sqlite_master where type=? and name=?",
+		Arguments: []interface{}{"table", tableName},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query table")
+	}
+
+	var createTableStatement string
+	for rows.Next() {
+		if err := rows.Scan(&createTableStatement); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+	}
+
+	return types.ParseSqliteChecks(createTableStatement), nil
+}
+
 func (r *RqliteConnection) ListTableForeignKeys(_ string, tableName string) ([]*types.ForeignKey, error) {
-	query := `SELECT id, 'from' as child_column, 'table' as parent_table, 'to' as parent_column, 'on_delete' FROM pragma_foreign_key_list(?)`
+	query := `SELECT id, "from" as child_column, "table" as parent_table, "to" as parent_column, on_delete, on_update, match FROM pragma_foreign_key_list(?)`
 	rows, err := r.db.QueryOneParameterized(gorqlite.ParameterizedStatement{
 		Query:     query,
 		Arguments: []interface{}{tableName},
@@ -121,9 +141,9 @@ func (r *RqliteConnection) ListTableForeignKeys(_ string, tableName string) ([]*
 
 	for rows.Next() {
 		var id int
-		var childColumn, parentColumn, parentTable, deleteRule string
+		var childColumn, parentColumn, parentTable, deleteRule, updateRule, matchOption string
 
-		if err := rows.Scan(&id, &childColumn, &parentTable, &parentColumn, &deleteRule); err != nil {
+		if err := rows.Scan(&id, &childColumn, &parentTable, &parentColumn, &deleteRule, &updateRule, &matchOption); err != nil {
 			return nil, err
 		}
 
@@ -135,6 +155,8 @@ func (r *RqliteConnection) ListTableForeignKeys(_ string, tableName string) ([]*
 				Name:        "", // TODO: find a way to get the name of the foreign key
 				ParentTable: parentTable,
 				OnDelete:    deleteRule,
+				OnUpdate:    updateRule,
+				Match:       matchOption,
 			}
 			foreignKeysMap[id] = foreignKey
 		}
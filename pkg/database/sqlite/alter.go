@@ -11,7 +11,10 @@ import (
 	"github.com/schemahero/schemahero/pkg/database/types"
 )
 
-func RecreateTableStatements(tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema) ([]string, error) {
+// RecreateTableStatements returns the statements that rebuild the table by renaming it, creating it again and
+// copying the rows back. The existing columns that aren't copied back unchanged are noted on the statement that
+// drops the renamed table, so that the rebuild is classified as data loss
+func RecreateTableStatements(tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, existingColumns []types.Column) ([]string, error) {
 	statements := []string{
 		"begin transaction",
 	}
@@ -33,27 +36,77 @@ func RecreateTableStatements(tableName string, sqliteTableSchema *schemasv1alpha
 	}
 	statements = append(statements, createTableStatement...)
 
+	// generated columns are computed, and can't be inserted into
 	columnNames := []string{}
 	for _, column := range sqliteTableSchema.Columns {
+		if column.Generated != nil {
+			continue
+		}
 		columnNames = append(columnNames, column.Name)
 	}
 	statements = append(statements,
 		fmt.Sprintf("insert into %s (%s) select %s from %s", tableName, strings.Join(columnNames, ", "), strings.Join(columnNames, ", "), tempTableName),
 	)
 
-	statements = append(statements, fmt.Sprintf("drop table %s", tempTableName))
+	lostColumns, err := uncopiedColumns(existingColumns, sqliteTableSchema.Columns)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compare columns")
+	}
+	if len(lostColumns) > 0 {
+		statements = append(statements, fmt.Sprintf("drop table %s -- columns %s aren't copied unchanged", tempTableName, strings.Join(lostColumns, ", ")))
+	} else {
+		statements = append(statements, fmt.Sprintf("drop table %s", tempTableName))
+	}
 	statements = append(statements, "commit")
 
 	return statements, nil
 }
 
-func BuildAlterIndexStatements(r *SqliteConnection, tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema) ([]string, error) {
-	indexStatements := []string{}
+// uncopiedColumns returns the existing columns that a rebuild of the table doesn't copy back, or copies into a
+// column with another type. Generated columns are computed, so they aren't copied
+func uncopiedColumns(existingColumns []types.Column, desiredColumns []*schemasv1alpha4.SqliteTableColumn) ([]string, error) {
+	lostColumns := []string{}
+NextExistingColumn:
+	for _, existingColumn := range existingColumns {
+		if existingColumn.Generated != nil {
+			continue
+		}
+
+		for _, desiredColumn := range desiredColumns {
+			if desiredColumn.Name != existingColumn.Name {
+				continue
+			}
+
+			column, err := schemaColumnToColumn(desiredColumn)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to convert desired column")
+			}
+			if column.Generated == nil && strings.EqualFold(column.DataType, existingColumn.DataType) {
+				continue NextExistingColumn
+			}
+			break
+		}
 
+		lostColumns = append(lostColumns, existingColumn.Name)
+	}
+
+	return lostColumns, nil
+}
+
+// BuildAlterIndexStatements returns the statements to make the indexes of the table match the spec. The current
+// indexes are compared by the new names of the columns in renames, which are renamed before the indexes are changed
+func BuildAlterIndexStatements(r *SqliteConnection, tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, renames map[string]string) ([]string, error) {
 	currentIndexes, err := r.ListTableIndexes("", tableName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list table indexes")
 	}
+	types.RenameIndexColumns(currentIndexes, renames)
+
+	return alterIndexStatements(tableName, sqliteTableSchema, currentIndexes), nil
+}
+
+func alterIndexStatements(tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, currentIndexes []*types.Index) []string {
+	indexStatements := []string{}
 
 desiredIndexesLoop:
 	for _, desiredIndex := range sqliteTableSchema.Indexes {
@@ -84,7 +137,7 @@ currentIndexesLoop:
 		indexStatements = append(indexStatements, RemoveIndexStatement(tableName, currentIndex))
 	}
 
-	return indexStatements, nil
+	return indexStatements
 }
 
 func columnsMatch(col1 types.Column, col2 types.Column) bool {
@@ -104,6 +157,10 @@ func columnsMatch(col1 types.Column, col2 types.Column) bool {
 		return false
 	}
 
+	if !types.GeneratedEqual(col1.Generated, col2.Generated) {
+		return false
+	}
+
 	col1Constraints, col2Constraints := col1.Constraints, col2.Constraints
 	if col1Constraints == nil {
 		col1Constraints = &types.ColumnConstraints{}
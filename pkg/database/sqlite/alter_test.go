@@ -3,9 +3,11 @@ package sqlite
 import (
 	"testing"
 
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 	"github.com/schemahero/schemahero/pkg/database/types"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_ColumnsMatch(t *testing.T) {
@@ -51,6 +53,20 @@ func Test_ColumnsMatch(t *testing.T) {
 			},
 			expect: false,
 		},
+		{
+			name: "generated expressions",
+			col1: types.Column{
+				Name:      "total",
+				DataType:  "real",
+				Generated: &types.ColumnGenerated{Expression: "price * quantity", Stored: true},
+			},
+			col2: types.Column{
+				Name:      "total",
+				DataType:  "real",
+				Generated: &types.ColumnGenerated{Expression: "price * quantity * 2", Stored: true},
+			},
+			expect: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -60,3 +76,81 @@ func Test_ColumnsMatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_RecreateTableStatementsRisk(t *testing.T) {
+	tableSchema := &schemasv1alpha4.SqliteTableSchema{
+		PrimaryKey: []string{"id"},
+		Columns: []*schemasv1alpha4.SqliteTableColumn{
+			{Name: "id", Type: "integer"},
+			{Name: "email", Type: "text"},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		existingColumns []types.Column
+		expect          types.StatementRisk
+	}{
+		{
+			name: "every column copied",
+			existingColumns: []types.Column{
+				{Name: "id", DataType: "integer"},
+				{Name: "email", DataType: "text"},
+			},
+			expect: types.StatementRiskSafe,
+		},
+		{
+			name: "column removed",
+			existingColumns: []types.Column{
+				{Name: "id", DataType: "integer"},
+				{Name: "email", DataType: "text"},
+				{Name: "name", DataType: "text"},
+			},
+			expect: types.StatementRiskDataLoss,
+		},
+		{
+			name: "column converted",
+			existingColumns: []types.Column{
+				{Name: "id", DataType: "integer"},
+				{Name: "email", DataType: "blob"},
+			},
+			expect: types.StatementRiskDataLoss,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statements, err := RecreateTableStatements("users", tableSchema, test.existingColumns)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expect, types.HighestRisk(types.ClassifyStatements(statements)))
+		})
+	}
+	assert.Equal(t, types.StatementRiskDataLoss, types.HighestRisk(types.ClassifyStatements([]string{"drop table users"})))
+}
+
+func Test_alterIndexStatementsRenamedColumn(t *testing.T) {
+	tableSchema := &schemasv1alpha4.SqliteTableSchema{
+		Columns: []*schemasv1alpha4.SqliteTableColumn{
+			{Name: "id", Type: "integer"},
+			{Name: "email", Type: "text", RenamedFrom: "email_address"},
+		},
+		Indexes: []*schemasv1alpha4.SqliteTableIndex{
+			{Name: "idx_users_email", Columns: []string{"email"}},
+		},
+	}
+	currentIndexes := []*types.Index{
+		{Name: "idx_users_email", Columns: []string{"email_address"}},
+	}
+
+	renames := types.ColumnRenames([]string{"id", "email_address"}, columnsRenamedFrom(tableSchema.Columns))
+	statements := []string{}
+	for existingName, newName := range renames {
+		statements = append(statements, RenameColumnStatement("users", existingName, newName))
+	}
+
+	types.RenameIndexColumns(currentIndexes, renames)
+	statements = append(statements, alterIndexStatements("users", tableSchema, currentIndexes)...)
+
+	assert.Equal(t, []string{`alter table "users" rename column "email_address" to "email"`}, statements)
+}
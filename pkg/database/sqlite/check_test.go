@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checksMatch(t *testing.T) {
+	createTableStatement := `create table "products" ("price" real, "name" text, constraint "price_positive" check (price > 0))`
+
+	tests := []struct {
+		name          string
+		desiredChecks []*schemasv1alpha4.SqliteTableCheck
+		want          bool
+	}{
+		{
+			name: "same check",
+			desiredChecks: []*schemasv1alpha4.SqliteTableCheck{
+				{Name: "price_positive", Expression: "price>0"},
+			},
+			want: true,
+		},
+		{
+			name: "changed check",
+			desiredChecks: []*schemasv1alpha4.SqliteTableCheck{
+				{Name: "price_positive", Expression: "price >= 0"},
+			},
+			want: false,
+		},
+		{
+			name: "added check",
+			desiredChecks: []*schemasv1alpha4.SqliteTableCheck{
+				{Name: "price_positive", Expression: "price > 0"},
+				{Name: "name_set", Expression: "length(name) > 0"},
+			},
+			want: false,
+		},
+		{
+			name:          "removed check",
+			desiredChecks: []*schemasv1alpha4.SqliteTableCheck{},
+			want:          false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, checksMatch(test.desiredChecks, types.ParseSqliteChecks(createTableStatement)))
+		})
+	}
+}
@@ -26,6 +26,13 @@ func schemaColumnToColumn(schemaColumn *schemasv1alpha4.SqliteTableColumn) (*typ
 		}
 	}
 
+	if schemaColumn.Generated != nil {
+		column.Generated = &types.ColumnGenerated{
+			Expression: schemaColumn.Generated.Expression,
+			Stored:     schemaColumn.Generated.Stored,
+		}
+	}
+
 	return column, nil
 }
 
@@ -41,6 +48,14 @@ func sqliteColumnAsInsert(column *schemasv1alpha4.SqliteTableColumn) (string, er
 		formatted = fmt.Sprintf("%s collate %s", formatted, sqliteColumn.Collation)
 	}
 
+	if sqliteColumn.Generated != nil {
+		if sqliteColumn.Generated.Stored {
+			formatted = fmt.Sprintf("%s generated always as (%s) stored", formatted, sqliteColumn.Generated.Expression)
+		} else {
+			formatted = fmt.Sprintf("%s generated always as (%s) virtual", formatted, sqliteColumn.Generated.Expression)
+		}
+	}
+
 	if sqliteColumn.Constraints != nil && sqliteColumn.Constraints.NotNull != nil {
 		if *sqliteColumn.Constraints.NotNull {
 			formatted = fmt.Sprintf("%s not null", formatted)
@@ -53,7 +68,7 @@ func sqliteColumnAsInsert(column *schemasv1alpha4.SqliteTableColumn) (string, er
 		formatted = fmt.Sprintf("%s autoincrement", formatted)
 	}
 
-	if sqliteColumn.ColumnDefault != nil {
+	if sqliteColumn.ColumnDefault != nil && sqliteColumn.Generated == nil {
 		formatted = fmt.Sprintf("%s default '%s'", formatted, *sqliteColumn.ColumnDefault)
 	}
 
@@ -71,6 +86,23 @@ func InsertColumnStatement(tableName string, desiredColumn *schemasv1alpha4.Sqli
 	return statement, nil
 }
 
+// RenameColumnStatement returns the statement to rename an existing column to its desired name
+func RenameColumnStatement(tableName string, existingName string, desiredName string) string {
+	return fmt.Sprintf(`alter table "%s" rename column "%s" to "%s"`, tableName, existingName, desiredName)
+}
+
+// columnsRenamedFrom returns the previous name of each desired column that was renamed, keyed by the desired name
+func columnsRenamedFrom(desiredColumns []*schemasv1alpha4.SqliteTableColumn) map[string]string {
+	renamedFrom := map[string]string{}
+	for _, desiredColumn := range desiredColumns {
+		if desiredColumn.RenamedFrom != "" {
+			renamedFrom[desiredColumn.Name] = desiredColumn.RenamedFrom
+		}
+	}
+
+	return renamedFrom
+}
+
 func DropColumnStatement(tableName string, existingColumn types.Column) (string, error) {
 	statement := fmt.Sprintf(`alter table "%s" drop column "%s"`, tableName, existingColumn.Name)
 	return statement, nil
@@ -53,6 +53,18 @@ func Test_columnAsInsert(t *testing.T) {
 			},
 			expectedStatement: `"c" integer default '11'`,
 		},
+		{
+			name: "stored generated",
+			column: &schemasv1alpha4.SqliteTableColumn{
+				Name: "total",
+				Type: "real",
+				Generated: &schemasv1alpha4.SqliteTableColumnGenerated{
+					Expression: "price * quantity",
+					Stored:     true,
+				},
+			},
+			expectedStatement: `"total" real generated always as (price * quantity) stored`,
+		},
 	}
 
 	for _, test := range tests {
@@ -34,6 +34,27 @@ func Test_CreateTableStatement(t *testing.T) {
 				`create table "simple" ("id" integer, primary key ("id"))`,
 			},
 		},
+		{
+			name: "table with a check",
+			tableSchema: &schemasv1alpha4.SqliteTableSchema{
+				Checks: []*schemasv1alpha4.SqliteTableCheck{
+					{
+						Name:       "price_positive",
+						Expression: "price > 0",
+					},
+				},
+				Columns: []*schemasv1alpha4.SqliteTableColumn{
+					{
+						Name: "price",
+						Type: "real",
+					},
+				},
+			},
+			tableName: "products",
+			expectedStatements: []string{
+				`create table "products" ("price" real, constraint "price_positive" check (price > 0))`,
+			},
+		},
 		{
 			name: "composite primary key",
 			tableSchema: &schemasv1alpha4.SqliteTableSchema{
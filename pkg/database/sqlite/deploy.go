@@ -15,7 +15,7 @@ func PlanSqliteView(dsn string, viewName string, sqliteViewSchema *schemasv1alph
 	return nil, errors.New("not implemented")
 }
 
-func PlanSqliteTable(dsn string, tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+func PlanSqliteTable(dsn string, tableName string, renamedFrom string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
 	s, err := Connect(dsn)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to sqlite")
@@ -28,6 +28,23 @@ func PlanSqliteTable(dsn string, tableName string, sqliteTableSchema *schemasv1a
 		return nil, errors.Wrap(err, "failed to scan")
 	}
 
+	// a renamed table is changed to match the spec under its existing name, and then renamed
+	renameTo := ""
+	if tableExists == 0 && renamedFrom != "" && renamedFrom != tableName && !sqliteTableSchema.IsDeleted {
+		existingTableExists := 0
+		row := s.db.QueryRow("select count(1) from sqlite_master where type=? and name=?", "table", renamedFrom)
+		if err := row.Scan(&existingTableExists); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		if existingTableExists > 0 {
+			renameTo = tableName
+			tableName = renamedFrom
+			tableExists = existingTableExists
+			sqliteTableSchema = withGeneratedNames(renameTo, sqliteTableSchema)
+		}
+	}
+
 	if tableExists == 0 && sqliteTableSchema.IsDeleted {
 		return []string{}, nil
 	} else if tableExists > 0 && sqliteTableSchema.IsDeleted {
@@ -60,6 +77,10 @@ func PlanSqliteTable(dsn string, tableName string, sqliteTableSchema *schemasv1a
 	}
 	statements = append(statements, seedDataStatements...)
 
+	if renameTo != "" {
+		statements = append(statements, RenameTableStatement(tableName, renameTo))
+	}
+
 	return statements, nil
 }
 
@@ -69,12 +90,18 @@ p.name AS col_name,
 p.type AS col_type,
 p.pk AS col_is_pk,
 p.dflt_value AS col_default_val,
-p.[notnull] AS col_is_not_null
+p.[notnull] AS col_is_not_null,
+p.hidden AS col_hidden
 FROM sqlite_master m
-LEFT OUTER JOIN pragma_table_info((m.name)) p
+LEFT OUTER JOIN pragma_table_xinfo((m.name)) p
 WHERE m.type = 'table'
 AND m.name = ?`
 
+	generatedExpressions, err := s.ListTableGeneratedColumns(tableName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list generated columns")
+	}
+
 	rows, err := s.db.Query(query, tableName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query from sqlite_master")
@@ -87,9 +114,9 @@ AND m.name = ?`
 	for rows.Next() {
 		var columnName, dataType string
 		var columnDefault sql.NullString
-		var primaryKey, notNull int
+		var primaryKey, notNull, hidden int
 
-		if err := rows.Scan(&columnName, &dataType, &primaryKey, &columnDefault, &notNull); err != nil {
+		if err := rows.Scan(&columnName, &dataType, &primaryKey, &columnDefault, &notNull, &hidden); err != nil {
 			return nil, errors.Wrap(err, "failed to scan")
 		}
 
@@ -120,16 +147,37 @@ AND m.name = ?`
 			existingColumn.ColumnDefault = &v
 		}
 
+		// hidden is 2 for virtual generated columns and 3 for stored generated columns
+		if hidden == 2 || hidden == 3 {
+			existingColumn.Generated = &types.ColumnGenerated{
+				Expression: generatedExpressions[columnName],
+				Stored:     hidden == 3,
+			}
+		}
+
 		existingColumns = append(existingColumns, existingColumn)
 	}
 
-	tableNeedsRecreate, err := checkTableNeedsRecreate(s, tableName, sqliteTableSchema, existingColumns)
+	// renamed columns are compared to the desired columns by their new name
+	existingColumnNames := []string{}
+	for _, existingColumn := range existingColumns {
+		existingColumnNames = append(existingColumnNames, existingColumn.Name)
+	}
+	renames := types.ColumnRenames(existingColumnNames, columnsRenamedFrom(sqliteTableSchema.Columns))
+	for i, existingColumn := range existingColumns {
+		if newName, ok := renames[existingColumn.Name]; ok {
+			statements = append(statements, RenameColumnStatement(tableName, existingColumn.Name, newName))
+			existingColumns[i].Name = newName
+		}
+	}
+
+	tableNeedsRecreate, err := checkTableNeedsRecreate(s, tableName, sqliteTableSchema, existingColumns, renames)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to check if table needs recreate")
 	}
 
 	if tableNeedsRecreate {
-		hardWayStatements, err := RecreateTableStatements(tableName, sqliteTableSchema)
+		hardWayStatements, err := RecreateTableStatements(tableName, sqliteTableSchema, existingColumns)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create recreate table statements")
 		}
@@ -156,7 +204,7 @@ AND m.name = ?`
 		}
 
 		// if there are unique indexes, they'll have to be removed before dropping their columns
-		indexStatements, err := BuildAlterIndexStatements(s, tableName, sqliteTableSchema)
+		indexStatements, err := BuildAlterIndexStatements(s, tableName, sqliteTableSchema, renames)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to build alter index statements")
 		}
@@ -185,12 +233,14 @@ AND m.name = ?`
 	return statements, nil
 }
 
-func checkTableNeedsRecreate(s *SqliteConnection, tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, existingColumns []types.Column) (bool, error) {
+func checkTableNeedsRecreate(s *SqliteConnection, tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema, existingColumns []types.Column, renames map[string]string) (bool, error) {
 	// check if primary keys match
 	existingPrimaryKey, err := s.GetTablePrimaryKeyColumns(tableName)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to get table primary key")
 	}
+	// renaming a column renames it in the primary key too
+	existingPrimaryKey = types.RenameColumnNames(existingPrimaryKey, renames)
 	if len(existingPrimaryKey) != len(sqliteTableSchema.PrimaryKey) {
 		return true, nil
 	}
@@ -209,6 +259,7 @@ nextPrimaryKeyColumn:
 	if err != nil {
 		return false, errors.Wrap(err, "failed to list table foreign keys")
 	}
+	types.RenameForeignKeyColumns(existingForeignKeys, renames)
 	if len(existingForeignKeys) != len(sqliteTableSchema.ForeignKeys) {
 		return true, nil
 	}
@@ -222,6 +273,34 @@ nextForeignKey:
 		return true, nil
 	}
 
+	// check if check constraints match, when the spec has checks
+	existingChecks, err := s.ListTableChecks(tableName)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list table checks")
+	}
+	types.RenameCheckColumns(existingChecks, renames)
+	if sqliteTableSchema.Checks != nil && !checksMatch(sqliteTableSchema.Checks, existingChecks) {
+		return true, nil
+	}
+
+	// stored generated columns can't be added to a table
+	for _, desiredColumn := range sqliteTableSchema.Columns {
+		if desiredColumn.Generated == nil || !desiredColumn.Generated.Stored {
+			continue
+		}
+
+		isColumnPresent := false
+		for _, existingColumn := range existingColumns {
+			if existingColumn.Name == desiredColumn.Name {
+				isColumnPresent = true
+				break
+			}
+		}
+		if !isColumnPresent {
+			return true, nil
+		}
+	}
+
 	// check if columns were modified (ok if added or removed)
 	for _, existingColumn := range existingColumns {
 		for _, desiredColumn := range sqliteTableSchema.Columns {
@@ -242,6 +321,7 @@ nextForeignKey:
 	if err != nil {
 		return false, errors.Wrap(err, "failed to list table indexes")
 	}
+	types.RenameIndexColumns(currentIndexes, renames)
 
 desiredIndexesLoop:
 	for _, desiredIndex := range sqliteTableSchema.Indexes {
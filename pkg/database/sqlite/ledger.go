@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// ledgerTimeFormat is fixed width so that applied_at sorts in time order
+const ledgerTimeFormat = "2006-01-02 15:04:05.000000"
+
+func createLedgerTableStatement() string {
+	return fmt.Sprintf(`create table if not exists "%s" (migration_id text, table_name text, spec_sha text, ddl_hash text not null, applied_at text not null, duration_ms integer not null)`, types.LedgerTableName)
+}
+
+// RecordLedgerEntry writes the entry to the migration ledger table, creating the table if it doesn't exist
+func RecordLedgerEntry(dsn string, entry types.LedgerEntry) error {
+	s, err := Connect(dsn)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect")
+	}
+	defer s.db.Close()
+
+	if _, err := s.db.Exec(createLedgerTableStatement()); err != nil {
+		return errors.Wrap(err, "failed to create ledger table")
+	}
+
+	query := fmt.Sprintf(`insert into "%s" (migration_id, table_name, spec_sha, ddl_hash, applied_at, duration_ms) values (?, ?, ?, ?, ?, ?)`, types.LedgerTableName)
+	if _, err := s.db.Exec(query, entry.MigrationID, entry.TableName, entry.SpecSHA, entry.DDLHash, entry.AppliedAt.UTC().Format(ledgerTimeFormat), entry.Duration.Milliseconds()); err != nil {
+		return errors.Wrap(err, "failed to insert ledger entry")
+	}
+
+	return nil
+}
+
+// LatestLedgerEntry returns the most recently applied ledger entry for the table, or nil if there is none
+func LatestLedgerEntry(dsn string, tableName string) (*types.LedgerEntry, error) {
+	s, err := Connect(dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect")
+	}
+	defer s.db.Close()
+
+	row := s.db.QueryRow("select count(1) from sqlite_master where type = ? and name = ?", "table", types.LedgerTableName)
+	ledgerExists := 0
+	if err := row.Scan(&ledgerExists); err != nil {
+		return nil, errors.Wrap(err, "failed to scan")
+	}
+	if ledgerExists == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf(`select coalesce(migration_id, ''), coalesce(table_name, ''), coalesce(spec_sha, ''), ddl_hash, applied_at, duration_ms from "%s" where table_name = ? order by applied_at desc limit 1`, types.LedgerTableName)
+	row = s.db.QueryRow(query, tableName)
+
+	entry := types.LedgerEntry{}
+	var appliedAt string
+	var durationMS int64
+	if err := row.Scan(&entry.MigrationID, &entry.TableName, &entry.SpecSHA, &entry.DDLHash, &appliedAt, &durationMS); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to scan ledger entry")
+	}
+
+	entry.AppliedAt, err = time.Parse(ledgerTimeFormat, appliedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse applied at")
+	}
+	entry.Duration = time.Duration(durationMS) * time.Millisecond
+
+	return &entry, nil
+}
@@ -0,0 +1,30 @@
+package sqlite
+
+import (
+	"fmt"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// RenameTableStatement returns the statement to rename an existing table to its desired name. Indexes and
+// triggers are kept, and foreign keys in other tables that reference it are updated to the new name
+func RenameTableStatement(existingName string, desiredName string) string {
+	return fmt.Sprintf(`alter table "%s" rename to "%s"`, existingName, desiredName)
+}
+
+// withGeneratedNames returns a copy of the schema with the index names that are generated from the table name
+// set explicitly. A renamed table is planned under its existing name, so this keeps the names of its indexes
+// the same as they will be when it's planned under the new name. Sqlite doesn't report the names of foreign keys,
+// so they are left as they are
+func withGeneratedNames(tableName string, sqliteTableSchema *schemasv1alpha4.SqliteTableSchema) *schemasv1alpha4.SqliteTableSchema {
+	named := sqliteTableSchema.DeepCopy()
+
+	for _, index := range named.Indexes {
+		if index.Name == "" {
+			index.Name = types.GenerateSqliteIndexName(tableName, index)
+		}
+	}
+
+	return named
+}
@@ -0,0 +1,26 @@
+package sqlite
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RenameTableStatement(t *testing.T) {
+	assert.Equal(t, `alter table "users" rename to "accounts"`, RenameTableStatement("users", "accounts"))
+}
+
+func Test_withGeneratedNames(t *testing.T) {
+	schema := &schemasv1alpha4.SqliteTableSchema{
+		Indexes: []*schemasv1alpha4.SqliteTableIndex{
+			{
+				Columns: []string{"email"},
+			},
+		},
+	}
+
+	named := withGeneratedNames("accounts", schema)
+	assert.Equal(t, "idx_accounts_email", named.Indexes[0].Name)
+	assert.Equal(t, "", schema.Indexes[0].Name)
+}
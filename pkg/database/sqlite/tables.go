@@ -97,8 +97,31 @@ WHERE m.type='table' AND m.name=? AND il.name=? AND il.origin!='pk'
 	return origin != "c", nil
 }
 
+// ListTableChecks returns the named check constraints on the table, parsed from the statement that created it
+func (s *SqliteConnection) ListTableChecks(tableName string) ([]*types.Check, error) {
+	var createTableStatement string
+	row := s.db.QueryRow("select sql from sqlite_master where type=? and name=?", "table", tableName)
+	if err := row.Scan(&createTableStatement); err != nil {
+		return nil, errors.Wrap(err, "failed to scan")
+	}
+
+	return types.ParseSqliteChecks(createTableStatement), nil
+}
+
+// ListTableGeneratedColumns returns the expression of each generated column in the table, keyed by the column
+// name, parsed from the statement that created it
+func (s *SqliteConnection) ListTableGeneratedColumns(tableName string) (map[string]string, error) {
+	var createTableStatement string
+	row := s.db.QueryRow("select sql from sqlite_master where type=? and name=?", "table", tableName)
+	if err := row.Scan(&createTableStatement); err != nil {
+		return nil, errors.Wrap(err, "failed to scan")
+	}
+
+	return types.ParseSqliteGeneratedColumns(createTableStatement), nil
+}
+
 func (s *SqliteConnection) ListTableForeignKeys(_ string, tableName string) ([]*types.ForeignKey, error) {
-	query := `SELECT id, 'from' as child_column, 'table' as parent_table, 'to' as parent_column, 'on_delete' FROM pragma_foreign_key_list(?)`
+	query := `SELECT id, "from" as child_column, "table" as parent_table, "to" as parent_column, on_delete, on_update, match FROM pragma_foreign_key_list(?)`
 	rows, err := s.db.Query(query, tableName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query foreign keys")
@@ -109,9 +132,9 @@ func (s *SqliteConnection) ListTableForeignKeys(_ string, tableName string) ([]*
 
 	for rows.Next() {
 		var id int
-		var childColumn, parentColumn, parentTable, deleteRule string
+		var childColumn, parentColumn, parentTable, deleteRule, updateRule, matchOption string
 
-		if err := rows.Scan(&id, &childColumn, &parentTable, &parentColumn, &deleteRule); err != nil {
+		if err := rows.Scan(&id, &childColumn, &parentTable, &parentColumn, &deleteRule, &updateRule, &matchOption); err != nil {
 			return nil, err
 		}
 
@@ -123,6 +146,8 @@ func (s *SqliteConnection) ListTableForeignKeys(_ string, tableName string) ([]*
 				Name:        "", // TODO: find a way to get the name of the foreign key
 				ParentTable: parentTable,
 				OnDelete:    deleteRule,
+				OnUpdate:    updateRule,
+				Match:       matchOption,
 			}
 			foreignKeysMap[id] = foreignKey
 		}
@@ -131,6 +131,7 @@ func toPostgresTableSchema(tableSchema *schemasv1alpha4.TimescaleDBTableSchema)
 		Columns:     tableSchema.Columns,
 		IsDeleted:   tableSchema.IsDeleted,
 		Triggers:    tableSchema.Triggers,
+		Comment:     tableSchema.Comment,
 	}
 }
 
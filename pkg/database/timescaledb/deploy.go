@@ -72,7 +72,7 @@ func PlanTimescaleDBView(uri string, viewName string, viewSchema *schemasv1alpha
 	return []string{}, nil
 }
 
-func PlanTimescaleDBTable(uri string, tableName string, tableSchema *schemasv1alpha4.TimescaleDBTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
+func PlanTimescaleDBTable(uri string, tableName string, renamedFrom string, tableSchema *schemasv1alpha4.TimescaleDBTableSchema, seedData *schemasv1alpha4.SeedData) ([]string, error) {
 	p, err := postgres.Connect(uri)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to timescaledb")
@@ -87,6 +87,23 @@ func PlanTimescaleDBTable(uri string, tableName string, tableSchema *schemasv1al
 		return nil, errors.Wrap(err, "failed to scan")
 	}
 
+	// a renamed table is changed to match the spec under its existing name, and then renamed
+	renameTo := ""
+	if tableExists == 0 && renamedFrom != "" && renamedFrom != tableName && !tableSchema.IsDeleted {
+		row := p.GetConnection().QueryRow(context.Background(), query, renamedFrom)
+		existingTableExists := 0
+		if err := row.Scan(&existingTableExists); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		if existingTableExists > 0 {
+			renameTo = tableName
+			tableName = renamedFrom
+			tableExists = existingTableExists
+			tableSchema = withGeneratedNames(renameTo, tableSchema)
+		}
+	}
+
 	if tableExists == 0 && tableSchema.IsDeleted {
 		return []string{}, nil
 	} else if tableExists > 0 && tableSchema.IsDeleted {
@@ -145,8 +162,19 @@ func PlanTimescaleDBTable(uri string, tableName string, tableSchema *schemasv1al
 	}
 	statements = append(statements, indexStatements...)
 
+	// comment changes
+	commentStatements, err := postgres.BuildCommentStatements(p, tableName, postgresTableSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build comment statements")
+	}
+	statements = append(statements, commentStatements...)
+
 	statements = append(statements, seedDataStatements...)
 
+	if renameTo != "" {
+		statements = append(statements, postgres.RenameTableStatement("", tableName, renameTo))
+	}
+
 	return statements, nil
 }
 
@@ -165,6 +193,13 @@ func BuildIndexStatements(p *postgres.PostgresConnection, tableName string, tabl
 		return nil, errors.Wrap(err, "failed to list table constraints")
 	}
 
+	// renaming a column renames it in the indexes too
+	renames, err := p.ListColumnRenames(tableName, postgresTableSchema.Columns)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list column renames")
+	}
+	types.RenameIndexColumns(currentIndexes, renames)
+
 DesiredIndexLoop:
 	for _, index := range postgresTableSchema.Indexes {
 		if index.Name == "" {
@@ -193,15 +228,15 @@ DesiredIndexLoop:
 			}
 
 			if isConstraint {
-				statement = postgres.RemoveConstraintStatement(tableName, matchedIndex)
+				statement = postgres.RemoveConstraintStatement("", tableName, matchedIndex)
 			} else {
-				statement = postgres.RemoveIndexStatement(tableName, matchedIndex)
+				statement = postgres.RemoveIndexStatement("", tableName, matchedIndex)
 			}
 			droppedIndexes = append(droppedIndexes, matchedIndex.Name)
 			indexStatements = append(indexStatements, statement)
 		}
 
-		statement = postgres.AddIndexStatement(tableName, index)
+		statement = postgres.AddIndexStatement("", tableName, index)
 		indexStatements = append(indexStatements, statement)
 	}
 
@@ -236,9 +271,9 @@ ExistingIndexLoop:
 		}
 
 		if isConstraint {
-			statement = postgres.RemoveConstraintStatement(tableName, currentIndex)
+			statement = postgres.RemoveConstraintStatement("", tableName, currentIndex)
 		} else {
-			statement = postgres.RemoveIndexStatement(tableName, currentIndex)
+			statement = postgres.RemoveIndexStatement("", tableName, currentIndex)
 		}
 
 		indexStatements = append(indexStatements, statement)
@@ -0,0 +1,27 @@
+package timescaledb
+
+import (
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/database/types"
+)
+
+// withGeneratedNames returns a copy of the schema with the index and foreign key names that are generated from
+// the table name set explicitly. A renamed table is planned under its existing name, so this keeps the names
+// of its indexes and foreign keys the same as they will be when it's planned under the new name
+func withGeneratedNames(tableName string, tableSchema *schemasv1alpha4.TimescaleDBTableSchema) *schemasv1alpha4.TimescaleDBTableSchema {
+	named := tableSchema.DeepCopy()
+
+	for _, index := range named.Indexes {
+		if index.Name == "" {
+			index.Name = types.GeneratePostgresqlIndexName(tableName, index)
+		}
+	}
+
+	for _, foreignKey := range named.ForeignKeys {
+		if foreignKey.Name == "" {
+			foreignKey.Name = types.GeneratePostgresqlFKName(tableName, foreignKey)
+		}
+	}
+
+	return named
+}
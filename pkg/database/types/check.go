@@ -0,0 +1,165 @@
+package types
+
+import (
+	"strings"
+	"unicode"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+type Check struct {
+	Name       string
+	Expression string
+}
+
+func (c *Check) Equals(other *Check) bool {
+	if c.Name != other.Name {
+		return false
+	}
+
+	return normalizeExpression(c.Expression) == normalizeExpression(other.Expression)
+}
+
+func PostgresqlSchemaCheckToCheck(schemaCheck *schemasv1alpha4.PostgresqlTableCheck) *Check {
+	return &Check{
+		Name:       schemaCheck.Name,
+		Expression: schemaCheck.Expression,
+	}
+}
+
+func MysqlSchemaCheckToCheck(schemaCheck *schemasv1alpha4.MysqlTableCheck) *Check {
+	return &Check{
+		Name:       schemaCheck.Name,
+		Expression: schemaCheck.Expression,
+	}
+}
+
+func SqliteSchemaCheckToCheck(schemaCheck *schemasv1alpha4.SqliteTableCheck) *Check {
+	return &Check{
+		Name:       schemaCheck.Name,
+		Expression: schemaCheck.Expression,
+	}
+}
+
+func RqliteSchemaCheckToCheck(schemaCheck *schemasv1alpha4.RqliteTableCheck) *Check {
+	return &Check{
+		Name:       schemaCheck.Name,
+		Expression: schemaCheck.Expression,
+	}
+}
+
+// ParseSqliteChecks returns the named check constraints in a create table statement. SQLite doesn't have a
+// catalog of check constraints, so they are read from the statement that's stored in sqlite_master
+func ParseSqliteChecks(createTableStatement string) []*Check {
+	checks := []*Check{}
+
+	// only ascii is lowered so that the offsets are the same in both strings
+	lowered := strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return r
+	}, createTableStatement)
+	for offset := 0; ; {
+		i := strings.Index(lowered[offset:], "constraint")
+		if i == -1 {
+			break
+		}
+		i += offset
+		offset = i + len("constraint")
+
+		// the keyword must not be part of another identifier
+		if i > 0 && isIdentifierRune(rune(lowered[i-1])) {
+			continue
+		}
+		if offset < len(lowered) && isIdentifierRune(rune(lowered[offset])) {
+			continue
+		}
+
+		name, rest := readSqliteIdentifier(createTableStatement[offset:])
+		if name == "" {
+			continue
+		}
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if len(rest) < len("check") || !strings.EqualFold(rest[:len("check")], "check") {
+			continue
+		}
+		rest = strings.TrimLeftFunc(rest[len("check"):], unicode.IsSpace)
+
+		expression, ok := readParenthesized(rest)
+		if !ok {
+			continue
+		}
+
+		checks = append(checks, &Check{
+			Name:       name,
+			Expression: expression,
+		})
+		offset = len(createTableStatement) - len(rest) + 1
+	}
+
+	return checks
+}
+
+// readSqliteIdentifier reads a quoted or bare identifier, and returns it with the rest of the string
+func readSqliteIdentifier(s string) (string, string) {
+	s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	if s == "" {
+		return "", s
+	}
+
+	closing := map[byte]byte{'"': '"', '`': '`', '[': ']'}
+	if end, ok := closing[s[0]]; ok {
+		i := strings.IndexByte(s[1:], end)
+		if i == -1 {
+			return "", s
+		}
+		return s[1 : i+1], s[i+2:]
+	}
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !isIdentifierRune(r)
+	})
+	if i == -1 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+// readParenthesized returns the contents of the parentheses that s starts with, ignoring parentheses that
+// are in string literals
+func readParenthesized(s string) (string, bool) {
+	if s == "" || s[0] != '(' {
+		return "", false
+	}
+
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(s[1:i]), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
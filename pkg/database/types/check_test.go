@@ -0,0 +1,78 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CheckEquals(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *Check
+		desired *Check
+		want    bool
+	}{
+		{
+			name:    "postgres check clause",
+			current: &Check{Name: "price_positive", Expression: "((price > (0)::numeric))"},
+			desired: &Check{Name: "price_positive", Expression: "price > 0"},
+			want:    true,
+		},
+		{
+			name:    "mysql check clause",
+			current: &Check{Name: "price_positive", Expression: "(`price` > 0)"},
+			desired: &Check{Name: "price_positive", Expression: "price > 0"},
+			want:    true,
+		},
+		{
+			name:    "different expression",
+			current: &Check{Name: "price_positive", Expression: "(price > 0)"},
+			desired: &Check{Name: "price_positive", Expression: "price >= 0"},
+			want:    false,
+		},
+		{
+			name:    "different name",
+			current: &Check{Name: "price_positive", Expression: "(price > 0)"},
+			desired: &Check{Name: "price_check", Expression: "price > 0"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.current.Equals(tt.desired))
+		})
+	}
+}
+
+func Test_ParseSqliteChecks(t *testing.T) {
+	tests := []struct {
+		name                 string
+		createTableStatement string
+		want                 []*Check
+	}{
+		{
+			name:                 "no checks",
+			createTableStatement: `CREATE TABLE "users" ("id" integer, primary key ("id"))`,
+			want:                 []*Check{},
+		},
+		{
+			name:                 "named checks",
+			createTableStatement: `create table "products" ("id" integer, "price" real, "name" text, constraint "price_positive" check (price > 0), CONSTRAINT name_set CHECK (length(name) > 0 and name != ')'))`,
+			want: []*Check{
+				{Name: "price_positive", Expression: "price > 0"},
+				{Name: "name_set", Expression: "length(name) > 0 and name != ')'"},
+			},
+		},
+		{
+			name:                 "named foreign key",
+			createTableStatement: `create table "orders" ("id" integer, "product_id" integer, constraint "fk_orders_products" foreign key ("product_id") references "products" ("id"))`,
+			want:                 []*Check{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseSqliteChecks(tt.createTableStatement))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+// StatementRisk describes what can go wrong when a planned statement is executed
+type StatementRisk string
+
+const (
+	// StatementRiskSafe statements don't remove data and don't hold long locks
+	StatementRiskSafe StatementRisk = "safe"
+
+	// StatementRiskLocking statements don't remove data, but can block reads or writes on the table while they run
+	StatementRiskLocking StatementRisk = "locking"
+
+	// StatementRiskDataLoss statements can remove or change existing data, and can't be undone
+	StatementRiskDataLoss StatementRisk = "data-loss"
+)
+
+// ClassifiedStatement is a planned statement and the risk of executing it
+type ClassifiedStatement struct {
+	Statement string
+	Risk      StatementRisk
+	Reason    string
+}
+
+type statementRule struct {
+	pattern *regexp.Regexp
+	risk    StatementRisk
+	reason  string
+}
+
+// statementRules are checked in order, and the first match classifies the statement. Data loss rules are first
+// so that a statement that is both locking and destructive is reported as destructive
+var statementRules = []statementRule{
+	{regexp.MustCompile(`^drop table \S+ -- columns .* aren't copied unchanged$`), StatementRiskDataLoss, "drops columns that the rebuilt table doesn't copy back unchanged"},
+	{regexp.MustCompile(`^drop table\b`), StatementRiskDataLoss, "drops the table and all of its data"},
+	{regexp.MustCompile(`^truncate\b`), StatementRiskDataLoss, "removes all rows from the table"},
+	{regexp.MustCompile(`^delete from\b`), StatementRiskDataLoss, "deletes rows from the table"},
+	{regexp.MustCompile(`\bdrop column\b`), StatementRiskDataLoss, "drops the column and all of its data"},
+	{regexp.MustCompile(`\balter column ("[^"]*"|\S+) type\b`), StatementRiskDataLoss, "changes the type of the column, which can truncate or fail to convert existing data"},
+	{regexp.MustCompile(`\b(modify|change) column\b`), StatementRiskDataLoss, "redefines the column, which can truncate or fail to convert existing data"},
+	{regexp.MustCompile(`\bdetach partition\b`), StatementRiskDataLoss, "removes the partition and its rows from the table"},
+	{regexp.MustCompile(`\bdrop partition\b`), StatementRiskDataLoss, "drops the partition and all of its data"},
+	{regexp.MustCompile("^alter table (`[^`]+`|\\S+) ([a-z_]+ [^,]+, )*engine (myisam|aria|memory|archive|csv|blackhole)\\b"), StatementRiskDataLoss, "moves the table off innodb, which can lose its rows, transactions and foreign keys"},
+
+	{regexp.MustCompile(`^create (unique )?index concurrently\b`), StatementRiskSafe, ""},
+	{regexp.MustCompile(`^create (unique )?index\b`), StatementRiskLocking, "builds the index while blocking writes to the table"},
+	{regexp.MustCompile(`\badd constraint\b.*\bnot valid$`), StatementRiskSafe, ""},
+	{regexp.MustCompile(`\badd (constraint|foreign key|primary key|unique)\b`), StatementRiskLocking, "validates existing rows while holding a lock on the table"},
+	{regexp.MustCompile(`\bset not null\b`), StatementRiskLocking, "scans existing rows while holding an exclusive lock on the table"},
+	{regexp.MustCompile(`\bconvert to character set\b`), StatementRiskLocking, "rewrites the table while blocking writes to it"},
+	{regexp.MustCompile("^alter table (`[^`]+`|\\S+) ([a-z_]+ [^,]+, )*(engine|row_format|key_block_size) "), StatementRiskLocking, "rebuilds the table while blocking writes to it"},
+	{regexp.MustCompile(`^alter table\b.*\b(partition by|remove partitioning)\b`), StatementRiskLocking, "rewrites the table while blocking writes to it"},
+	{regexp.MustCompile(`\b(reorganize|coalesce) partition\b|\badd partition partitions\b`), StatementRiskLocking, "moves rows between partitions while blocking writes to the table"},
+	{regexp.MustCompile(`\battach partition\b`), StatementRiskLocking, "scans the rows of the partition while holding a lock on the table"},
+	{regexp.MustCompile(`^update\b`), StatementRiskLocking, "rewrites existing rows"},
+}
+
+var (
+	whitespaceRegexp  = regexp.MustCompile(`\s+`)
+	renameTableRegexp = regexp.MustCompile(`^alter table ("[^"]+"|\S+) rename to ("[^"]+"|\S+)$`)
+	copyFromRegexp    = regexp.MustCompile(`^insert into \S+ \(.*\) select .* from ("[^"]+"|\S+)$`)
+	dropTableRegexp   = regexp.MustCompile(`^drop table ("[^"]+"|\S+)$`)
+)
+
+func normalizeStatement(statement string) string {
+	return strings.ToLower(strings.TrimSpace(whitespaceRegexp.ReplaceAllString(statement, " ")))
+}
+
+// ClassifyStatement returns the risk of executing a planned statement. Statements that don't match any known
+// risky operation are safe
+func ClassifyStatement(statement string) ClassifiedStatement {
+	normalized := normalizeStatement(statement)
+
+	for _, rule := range statementRules {
+		if rule.pattern.MatchString(normalized) {
+			return ClassifiedStatement{
+				Statement: statement,
+				Risk:      rule.risk,
+				Reason:    rule.reason,
+			}
+		}
+	}
+
+	return ClassifiedStatement{
+		Statement: statement,
+		Risk:      StatementRiskSafe,
+	}
+}
+
+// ClassifyStatements classifies each of the planned statements. Sqlite and rqlite rebuild a table by renaming it,
+// creating it again and copying the rows back, so dropping the renamed table after its rows are copied isn't
+// data loss. The planners note the columns that a rebuild doesn't copy back unchanged on the drop, and that drop
+// is data loss
+func ClassifyStatements(statements []string) []ClassifiedStatement {
+	renamedTo := map[string]bool{}
+	copiedFrom := map[string]bool{}
+
+	classified := []ClassifiedStatement{}
+	for _, statement := range statements {
+		normalized := normalizeStatement(statement)
+		if matches := renameTableRegexp.FindStringSubmatch(normalized); matches != nil {
+			renamedTo[strings.Trim(matches[2], `"`)] = true
+		}
+		if matches := copyFromRegexp.FindStringSubmatch(normalized); matches != nil {
+			copiedFrom[strings.Trim(matches[1], `"`)] = true
+		}
+
+		if matches := dropTableRegexp.FindStringSubmatch(normalized); matches != nil {
+			tableName := strings.Trim(matches[1], `"`)
+			if renamedTo[tableName] && copiedFrom[tableName] {
+				classified = append(classified, ClassifiedStatement{
+					Statement: statement,
+					Risk:      StatementRiskSafe,
+				})
+				continue
+			}
+		}
+
+		classified = append(classified, ClassifyStatement(statement))
+	}
+
+	return classified
+}
+
+// HighestRisk returns the most severe risk of the classified statements
+func HighestRisk(classified []ClassifiedStatement) StatementRisk {
+	highest := StatementRiskSafe
+	for _, c := range classified {
+		if riskSeverity(c.Risk) > riskSeverity(highest) {
+			highest = c.Risk
+		}
+	}
+
+	return highest
+}
+
+func riskSeverity(risk StatementRisk) int {
+	switch risk {
+	case StatementRiskDataLoss:
+		return 2
+	case StatementRiskLocking:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,201 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ClassifyStatement(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      StatementRisk
+	}{
+		{
+			name:      "create table",
+			statement: `create table "users" ("id" integer, primary key ("id"))`,
+			want:      StatementRiskSafe,
+		},
+		{
+			name:      "add nullable column",
+			statement: "alter table `users` add column `email` varchar (255)",
+			want:      StatementRiskSafe,
+		},
+		{
+			name:      "set default",
+			statement: `alter table "users" alter column "kind" set default 'type'`,
+			want:      StatementRiskSafe,
+		},
+		{
+			name:      "drop table",
+			statement: `drop table "users"`,
+			want:      StatementRiskDataLoss,
+		},
+		{
+			name:      "postgres drop column",
+			statement: `alter table "users" drop column "email"`,
+			want:      StatementRiskDataLoss,
+		},
+		{
+			name:      "postgres change column type",
+			statement: `alter table "users" alter column "email" type varchar (10)`,
+			want:      StatementRiskDataLoss,
+		},
+		{
+			name:      "mysql modify column",
+			statement: "alter table `users` modify column `email` varchar (10)",
+			want:      StatementRiskDataLoss,
+		},
+		{
+			name:      "create index",
+			statement: `create unique index "idx_users_email" on "users" ("email")`,
+			want:      StatementRiskLocking,
+		},
+		{
+			name:      "create index concurrently",
+			statement: `create index concurrently "idx_users_email" on "users" ("email")`,
+			want:      StatementRiskSafe,
+		},
+		{
+			name:      "add check constraint",
+			statement: `alter table "products" add constraint "price_positive" check (price > 0)`,
+			want:      StatementRiskLocking,
+		},
+		{
+			name:      "add check constraint not valid",
+			statement: `alter table "products" add constraint "price_positive" check (price > 0) not valid`,
+			want:      StatementRiskSafe,
+		},
+		{
+			name:      "set not null",
+			statement: `alter table "users" alter column "email" set not null`,
+			want:      StatementRiskLocking,
+		},
+		{
+			name:      "backfill nulls",
+			statement: `update "users" set "email"='' where "email" is null`,
+			want:      StatementRiskLocking,
+		},
+		{
+			name:      "create partitioned table",
+			statement: `create table "events" ("id" bigint, "created_at" date) partition by range (created_at)`,
+			want:      StatementRiskSafe,
+		},
+		{
+			name:      "detach partition",
+			statement: `alter table "events" detach partition "events_2023"`,
+			want:      StatementRiskDataLoss,
+		},
+		{
+			name:      "mysql drop partition",
+			statement: "alter table `events` drop partition `p2023`",
+			want:      StatementRiskDataLoss,
+		},
+		{
+			name:      "mysql partition existing table",
+			statement: "alter table `events` partition by hash (id) partitions 4",
+			want:      StatementRiskLocking,
+		},
+		{
+			name:      "mysql add range partition",
+			statement: "alter table `events` add partition (partition `p2025` values less than (2026))",
+			want:      StatementRiskSafe,
+		},
+		{
+			name:      "mysql online convert to character set",
+			statement: "alter table users convert to character set utf8mb4 collate utf8mb4_general_ci, algorithm=inplace, lock=none",
+			want:      StatementRiskLocking,
+		},
+		{
+			name:      "mysql online modify column",
+			statement: "alter table `users` modify column `total` decimal (12, 2), algorithm=inplace, lock=none",
+			want:      StatementRiskDataLoss,
+		},
+		{
+			name:      "mysql online drop column",
+			statement: "alter table `users` drop column `email`, algorithm=inplace, lock=none",
+			want:      StatementRiskDataLoss,
+		},
+		{
+			name:      "mysql change engine",
+			statement: "alter table `users` auto_increment 1000, engine InnoDB",
+			want:      StatementRiskLocking,
+		},
+		{
+			name:      "mysql change engine off innodb",
+			statement: "alter table `users` row_format dynamic, engine MyISAM",
+			want:      StatementRiskDataLoss,
+		},
+		{
+			name:      "mysql create table with memory engine",
+			statement: "create table `sessions` (`id` int (11), primary key (`id`)) engine MEMORY",
+			want:      StatementRiskSafe,
+		},
+		{
+			name:      "mysql add engine column",
+			statement: "alter table `cars` add column `engine` varchar (255)",
+			want:      StatementRiskSafe,
+		},
+		{
+			name:      "multi line and upper case",
+			statement: "ALTER TABLE users\n  DROP COLUMN email",
+			want:      StatementRiskDataLoss,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			classified := ClassifyStatement(test.statement)
+			assert.Equal(t, test.want, classified.Risk)
+			assert.Equal(t, test.statement, classified.Statement)
+			if test.want != StatementRiskSafe {
+				assert.NotEmpty(t, classified.Reason)
+			}
+		})
+	}
+}
+
+func Test_HighestRisk(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []string
+		want       StatementRisk
+	}{
+		{
+			name:       "no statements",
+			statements: []string{},
+			want:       StatementRiskSafe,
+		},
+		{
+			name: "locking",
+			statements: []string{
+				`alter table "users" add column "email" text`,
+				`create index "idx_users_email" on "users" ("email")`,
+			},
+			want: StatementRiskLocking,
+		},
+		{
+			name: "data loss",
+			statements: []string{
+				`alter table "users" drop column "name"`,
+				`create index "idx_users_email" on "users" ("email")`,
+			},
+			want: StatementRiskDataLoss,
+		},
+		{
+			name: "drop a table that rows were copied from",
+			statements: []string{
+				`insert into users_archive (id, name) select id, name from users`,
+				`drop table users`,
+			},
+			want: StatementRiskDataLoss,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, HighestRisk(ClassifyStatements(test.statements)))
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package types
 
 import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 )
 
@@ -12,6 +17,27 @@ type ColumnAttributes struct {
 	AutoIncrement *bool
 }
 
+// ColumnGenerated is the expression that a generated column is computed from, and whether the computed value
+// is stored or computed when it's read
+type ColumnGenerated struct {
+	Expression string
+	Stored     bool
+}
+
+// GeneratedEqual returns true when neither column is generated, or when both are generated from the same
+// expression and are stored the same way
+func GeneratedEqual(a, b *ColumnGenerated) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if a.Stored != b.Stored {
+		return false
+	}
+
+	return normalizeExpression(a.Expression) == normalizeExpression(b.Expression)
+}
+
 func BoolsEqual(a, b *bool) bool {
 	if a == nil || !*a {
 		return b == nil || !*b
@@ -29,6 +55,13 @@ type Column struct {
 	Charset       string
 	Collation     string
 	IsStatic      bool
+	Generated     *ColumnGenerated
+	Identity      *ColumnIdentity
+	Comment       string
+
+	// OwnedSequence is the qualified name of the sequence that's owned by the column, such as the sequence that
+	// the default of a serial column is taken from
+	OwnedSequence string
 }
 
 func ColumnToMysqlSchemaColumn(column *Column) (*schemasv1alpha4.MysqlTableColumn, error) {
@@ -54,6 +87,18 @@ func ColumnToMysqlSchemaColumn(column *Column) (*schemasv1alpha4.MysqlTableColum
 	schemaColumn.Charset = column.Charset
 	schemaColumn.Collation = column.Collation
 
+	if column.Generated != nil {
+		schemaColumn.Generated = &schemasv1alpha4.MysqlTableColumnGenerated{
+			Expression: column.Generated.Expression,
+			Stored:     column.Generated.Stored,
+		}
+	}
+
+	if column.Comment != "" {
+		comment := column.Comment
+		schemaColumn.Comment = &comment
+	}
+
 	return schemaColumn, nil
 }
 
@@ -77,6 +122,17 @@ func ColumnToPostgresqlSchemaColumn(column *Column) (*schemasv1alpha4.Postgresql
 
 	schemaColumn.Default = column.ColumnDefault
 
+	if column.Generated != nil {
+		schemaColumn.Generated = &schemasv1alpha4.PostgresqlTableColumnGenerated{
+			Expression: column.Generated.Expression,
+		}
+	}
+
+	if column.Comment != "" {
+		comment := column.Comment
+		schemaColumn.Comment = &comment
+	}
+
 	return schemaColumn, nil
 }
 
@@ -102,3 +158,210 @@ func ColumnToRqliteSchemaColumn(column *Column) (*schemasv1alpha4.RqliteTableCol
 
 	return schemaColumn, nil
 }
+
+// ColumnRenames returns the existing columns that should be renamed, keyed by their current name. The renamedFrom
+// map is the previous name of each desired column that declares one, keyed by the desired name. A rename is only
+// planned when the table has the column that it's renamed from and doesn't already have a column with the new
+// name, so it's planned once and then the column matches by its new name. When more than one column is renamed
+// from the same column, only the first of their new names in sorted order is renamed, so the plan is the same
+// every time
+func ColumnRenames(existingColumnNames []string, renamedFrom map[string]string) map[string]string {
+	existing := map[string]bool{}
+	for _, name := range existingColumnNames {
+		existing[name] = true
+	}
+
+	newNames := []string{}
+	for newName := range renamedFrom {
+		newNames = append(newNames, newName)
+	}
+	sort.Strings(newNames)
+
+	renames := map[string]string{}
+	for _, newName := range newNames {
+		oldName := renamedFrom[newName]
+		if oldName == "" || oldName == newName {
+			continue
+		}
+		if !existing[oldName] || existing[newName] {
+			continue
+		}
+		if _, ok := renames[oldName]; ok {
+			continue
+		}
+		renames[oldName] = newName
+	}
+
+	return renames
+}
+
+// RenameColumnNames returns the column names with any renamed columns replaced by their new name
+func RenameColumnNames(columnNames []string, renames map[string]string) []string {
+	renamed := []string{}
+	for _, name := range columnNames {
+		if newName, ok := renames[name]; ok {
+			renamed = append(renamed, newName)
+		} else {
+			renamed = append(renamed, name)
+		}
+	}
+
+	return renamed
+}
+
+// RenameIndexColumns renames the columns of the existing indexes, so that they're compared to the desired indexes
+// by the new names of the columns
+func RenameIndexColumns(indexes []*Index, renames map[string]string) {
+	for _, index := range indexes {
+		index.Columns = RenameColumnNames(index.Columns, renames)
+		index.Include = RenameColumnNames(index.Include, renames)
+	}
+}
+
+// RenameForeignKeyColumns renames the columns of the table in the existing foreign keys. The columns of the
+// referenced table aren't renamed
+func RenameForeignKeyColumns(foreignKeys []*ForeignKey, renames map[string]string) {
+	for _, foreignKey := range foreignKeys {
+		foreignKey.ChildColumns = RenameColumnNames(foreignKey.ChildColumns, renames)
+	}
+}
+
+// RenameCheckColumns renames the columns in the expressions of the existing checks. String literals in the
+// expressions aren't changed
+func RenameCheckColumns(checks []*Check, renames map[string]string) {
+	for _, check := range checks {
+		check.Expression = renameExpressionColumns(check.Expression, renames)
+	}
+}
+
+var expressionTokenRegexp = regexp.MustCompile("'(?:[^']|'')*'|\"(?:[^\"]|\"\")*\"|`[^`]*`|[A-Za-z_][A-Za-z0-9_$]*")
+
+func renameExpressionColumns(expression string, renames map[string]string) string {
+	if len(renames) == 0 {
+		return expression
+	}
+
+	return expressionTokenRegexp.ReplaceAllStringFunc(expression, func(token string) string {
+		switch token[0] {
+		case '\'':
+			return token
+		case '"', '`':
+			quote := token[:1]
+			if newName, ok := renames[strings.Trim(token, quote)]; ok {
+				return quote + newName + quote
+			}
+			return token
+		}
+
+		if newName, ok := renames[token]; ok {
+			return newName
+		}
+		return token
+	})
+}
+
+// ParseSqliteGeneratedColumns returns the expression of each generated column in a create table statement, keyed
+// by the column name. SQLite only reports which columns are generated, so the expressions are read from the
+// statement that's stored in sqlite_master
+func ParseSqliteGeneratedColumns(createTableStatement string) map[string]string {
+	expressions := map[string]string{}
+
+	start := strings.IndexByte(createTableStatement, '(')
+	if start == -1 {
+		return expressions
+	}
+	definitions, ok := readParenthesized(createTableStatement[start:])
+	if !ok {
+		return expressions
+	}
+
+	for _, definition := range splitSqliteDefinitions(definitions) {
+		name, rest := readSqliteIdentifier(definition)
+		if name == "" {
+			continue
+		}
+
+		if expression, ok := readGeneratedExpression(rest); ok {
+			expressions[name] = expression
+		}
+	}
+
+	return expressions
+}
+
+// splitSqliteDefinitions splits the column definitions and table constraints of a create table statement on the
+// commas that aren't in parentheses or string literals
+func splitSqliteDefinitions(s string) []string {
+	definitions := []string{}
+
+	depth := 0
+	start := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				definitions = append(definitions, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(definitions, strings.TrimSpace(s[start:]))
+}
+
+// readGeneratedExpression returns the expression after the "as" keyword of a generated column definition,
+// ignoring the keyword when it's in parentheses or string literals
+func readGeneratedExpression(definition string) (string, bool) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(definition); i++ {
+		c := definition[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+			continue
+		case '(':
+			depth++
+			continue
+		case ')':
+			depth--
+			continue
+		}
+
+		if depth != 0 || i+len("as") > len(definition) || !strings.EqualFold(definition[i:i+len("as")], "as") {
+			continue
+		}
+		if i > 0 && isIdentifierRune(rune(definition[i-1])) {
+			continue
+		}
+
+		rest := strings.TrimLeftFunc(definition[i+len("as"):], unicode.IsSpace)
+		if expression, ok := readParenthesized(rest); ok {
+			return expression, true
+		}
+	}
+
+	return "", false
+}
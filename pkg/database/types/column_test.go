@@ -1,6 +1,9 @@
 package types
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestBoolsEqual(t *testing.T) {
 	falseValue := false
@@ -74,3 +77,160 @@ func TestBoolsEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestGeneratedEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *ColumnGenerated
+		b    *ColumnGenerated
+		want bool
+	}{
+		{
+			name: "nil nil",
+			want: true,
+		},
+		{
+			name: "nil generated",
+			b:    &ColumnGenerated{Expression: "price * quantity"},
+			want: false,
+		},
+		{
+			name: "postgres generation expression",
+			a:    &ColumnGenerated{Expression: "(price * (quantity)::numeric)", Stored: true},
+			b:    &ColumnGenerated{Expression: "price * quantity", Stored: true},
+			want: true,
+		},
+		{
+			name: "mysql generation expression",
+			a:    &ColumnGenerated{Expression: "(`price` * `quantity`)"},
+			b:    &ColumnGenerated{Expression: "price * quantity"},
+			want: true,
+		},
+		{
+			name: "different expression",
+			a:    &ColumnGenerated{Expression: "price * quantity"},
+			b:    &ColumnGenerated{Expression: "price + quantity"},
+			want: false,
+		},
+		{
+			name: "stored and virtual",
+			a:    &ColumnGenerated{Expression: "price * quantity", Stored: true},
+			b:    &ColumnGenerated{Expression: "price * quantity"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GeneratedEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("GeneratedEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnRenames(t *testing.T) {
+	tests := []struct {
+		name                string
+		existingColumnNames []string
+		renamedFrom         map[string]string
+		want                map[string]string
+	}{
+		{
+			name:                "rename",
+			existingColumnNames: []string{"id", "email_address"},
+			renamedFrom:         map[string]string{"email": "email_address"},
+			want:                map[string]string{"email_address": "email"},
+		},
+		{
+			name:                "already renamed",
+			existingColumnNames: []string{"id", "email"},
+			renamedFrom:         map[string]string{"email": "email_address"},
+			want:                map[string]string{},
+		},
+		{
+			name:                "both columns exist",
+			existingColumnNames: []string{"id", "email", "email_address"},
+			renamedFrom:         map[string]string{"email": "email_address"},
+			want:                map[string]string{},
+		},
+		{
+			name:                "renamed from itself",
+			existingColumnNames: []string{"id", "email"},
+			renamedFrom:         map[string]string{"email": "email"},
+			want:                map[string]string{},
+		},
+		{
+			name:                "two columns renamed from the same column",
+			existingColumnNames: []string{"id", "email_address"},
+			renamedFrom:         map[string]string{"work_email": "email_address", "email": "email_address"},
+			want:                map[string]string{"email_address": "email"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ColumnRenames(tt.existingColumnNames, tt.renamedFrom); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ColumnRenames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSqliteGeneratedColumns(t *testing.T) {
+	tests := []struct {
+		name                 string
+		createTableStatement string
+		want                 map[string]string
+	}{
+		{
+			name:                 "no generated columns",
+			createTableStatement: `CREATE TABLE "orders" ("id" integer, "status" text default 'as (x)', primary key ("id"))`,
+			want:                 map[string]string{},
+		},
+		{
+			name:                 "virtual and stored columns",
+			createTableStatement: `create table "orders" ("id" integer, "price" real, "quantity" integer, "total" real generated always as (price * quantity) stored, "label" text as (upper(name)), primary key ("id"))`,
+			want: map[string]string{
+				"total": "price * quantity",
+				"label": "upper(name)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSqliteGeneratedColumns(tt.createTableStatement); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSqliteGeneratedColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenameConstraintColumns(t *testing.T) {
+	renames := map[string]string{"email_address": "email"}
+
+	indexes := []*Index{{Name: "idx_users_email", Columns: []string{"email_address", "id"}}}
+	RenameIndexColumns(indexes, renames)
+	if want := []string{"email", "id"}; !reflect.DeepEqual(indexes[0].Columns, want) {
+		t.Errorf("RenameIndexColumns() = %v, want %v", indexes[0].Columns, want)
+	}
+
+	foreignKeys := []*ForeignKey{{ChildColumns: []string{"email_address"}, ParentTable: "accounts", ParentColumns: []string{"email_address"}}}
+	RenameForeignKeyColumns(foreignKeys, renames)
+	if want := []string{"email"}; !reflect.DeepEqual(foreignKeys[0].ChildColumns, want) {
+		t.Errorf("RenameForeignKeyColumns() = %v, want %v", foreignKeys[0].ChildColumns, want)
+	}
+	if want := []string{"email_address"}; !reflect.DeepEqual(foreignKeys[0].ParentColumns, want) {
+		t.Errorf("RenameForeignKeyColumns() renamed the parent columns to %v", foreignKeys[0].ParentColumns)
+	}
+
+	checks := []*Check{
+		{Name: "email_has_at", Expression: "(email_address like '%@%' and email_address <> 'email_address')"},
+		{Name: "email_quoted", Expression: `("email_address" is not null)`},
+	}
+	RenameCheckColumns(checks, renames)
+	if want := "(email like '%@%' and email <> 'email_address')"; checks[0].Expression != want {
+		t.Errorf("RenameCheckColumns() = %q, want %q", checks[0].Expression, want)
+	}
+	if want := `("email" is not null)`; checks[1].Expression != want {
+		t.Errorf("RenameCheckColumns() = %q, want %q", checks[1].Expression, want)
+	}
+}
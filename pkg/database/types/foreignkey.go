@@ -8,11 +8,15 @@ import (
 )
 
 type ForeignKey struct {
-	ChildColumns  []string
-	ParentTable   string
-	ParentColumns []string
-	Name          string
-	OnDelete      string
+	ChildColumns      []string
+	ParentTable       string
+	ParentColumns     []string
+	Name              string
+	OnDelete          string
+	OnUpdate          string
+	Match             string
+	Deferrable        bool
+	InitiallyDeferred bool
 }
 
 func (fk *ForeignKey) Equals(other *ForeignKey) bool {
@@ -24,7 +28,19 @@ func (fk *ForeignKey) Equals(other *ForeignKey) bool {
 		return false
 	}
 
-	if fk.OnDelete != other.OnDelete {
+	if !referentialActionsEqual(fk.OnDelete, other.OnDelete) {
+		return false
+	}
+
+	if !referentialActionsEqual(fk.OnUpdate, other.OnUpdate) {
+		return false
+	}
+
+	if !matchTypesEqual(fk.Match, other.Match) {
+		return false
+	}
+
+	if fk.Deferrable != other.Deferrable || fk.InitiallyDeferred != other.InitiallyDeferred {
 		return false
 	}
 
@@ -53,11 +69,37 @@ nextParentColumn:
 				continue nextParentColumn
 			}
 		}
+		return false
 	}
 
 	return true
 }
 
+// referentialActionsEqual compares on delete and on update actions. Databases report the default action as
+// NO ACTION, which is the same as not setting one
+func referentialActionsEqual(action string, otherAction string) bool {
+	normalize := func(a string) string {
+		if a == "" {
+			return "no action"
+		}
+		return strings.ToLower(a)
+	}
+
+	return normalize(action) == normalize(otherAction)
+}
+
+// matchTypesEqual compares match types. Databases report the default, simple, match type as NONE
+func matchTypesEqual(match string, otherMatch string) bool {
+	normalize := func(m string) string {
+		if m == "" || strings.EqualFold(m, "none") {
+			return "simple"
+		}
+		return strings.ToLower(m)
+	}
+
+	return normalize(match) == normalize(otherMatch)
+}
+
 func ForeignKeyToMysqlSchemaForeignKey(foreignKey *ForeignKey) *schemasv1alpha4.MysqlTableForeignKey {
 	schemaForeignKey := schemasv1alpha4.MysqlTableForeignKey{
 		Columns: foreignKey.ChildColumns,
@@ -67,6 +109,11 @@ func ForeignKeyToMysqlSchemaForeignKey(foreignKey *ForeignKey) *schemasv1alpha4.
 		},
 		Name:     foreignKey.Name,
 		OnDelete: foreignKey.OnDelete,
+		OnUpdate: foreignKey.OnUpdate,
+	}
+
+	if !matchTypesEqual(foreignKey.Match, "") {
+		schemaForeignKey.Match = strings.ToLower(foreignKey.Match)
 	}
 
 	return &schemaForeignKey
@@ -79,8 +126,15 @@ func ForeignKeyToPostgresqlSchemaForeignKey(foreignKey *ForeignKey) *schemasv1al
 			Table:   foreignKey.ParentTable,
 			Columns: foreignKey.ParentColumns,
 		},
-		Name:     foreignKey.Name,
-		OnDelete: foreignKey.OnDelete,
+		Name:              foreignKey.Name,
+		OnDelete:          foreignKey.OnDelete,
+		OnUpdate:          foreignKey.OnUpdate,
+		Deferrable:        foreignKey.Deferrable,
+		InitiallyDeferred: foreignKey.InitiallyDeferred,
+	}
+
+	if !matchTypesEqual(foreignKey.Match, "") {
+		schemaForeignKey.Match = strings.ToLower(foreignKey.Match)
 	}
 
 	return &schemaForeignKey
@@ -95,11 +149,18 @@ func ForeignKeyToRqliteSchemaForeignKey(foreignKey *ForeignKey) *schemasv1alpha4
 		},
 		Name:     foreignKey.Name,
 		OnDelete: foreignKey.OnDelete,
+		OnUpdate: foreignKey.OnUpdate,
+	}
+
+	if !matchTypesEqual(foreignKey.Match, "") {
+		schemaForeignKey.Match = strings.ToLower(foreignKey.Match)
 	}
 
 	return &schemaForeignKey
 }
 
+// MysqlSchemaForeignKeyToForeignKey leaves out the match type, which innodb parses but doesn't keep, and
+// always reports as NONE
 func MysqlSchemaForeignKeyToForeignKey(schemaForeignKey *schemasv1alpha4.MysqlTableForeignKey) *ForeignKey {
 	foreignKey := ForeignKey{
 		ChildColumns:  schemaForeignKey.Columns,
@@ -107,6 +168,7 @@ func MysqlSchemaForeignKeyToForeignKey(schemaForeignKey *schemasv1alpha4.MysqlTa
 		ParentColumns: schemaForeignKey.References.Columns,
 		Name:          schemaForeignKey.Name,
 		OnDelete:      schemaForeignKey.OnDelete,
+		OnUpdate:      schemaForeignKey.OnUpdate,
 	}
 
 	return &foreignKey
@@ -119,11 +181,18 @@ func PostgresqlSchemaForeignKeyToForeignKey(schemaForeignKey *schemasv1alpha4.Po
 		ParentColumns: schemaForeignKey.References.Columns,
 		Name:          schemaForeignKey.Name,
 		OnDelete:      schemaForeignKey.OnDelete,
+		OnUpdate:      schemaForeignKey.OnUpdate,
+		Match:         schemaForeignKey.Match,
+		// initially deferred constraints are always deferrable
+		Deferrable:        schemaForeignKey.Deferrable || schemaForeignKey.InitiallyDeferred,
+		InitiallyDeferred: schemaForeignKey.InitiallyDeferred,
 	}
 
 	return &foreignKey
 }
 
+// SqliteSchemaForeignKeyToForeignKey leaves out the match type, which sqlite parses but doesn't keep, and
+// always reports as NONE
 func SqliteSchemaForeignKeyToForeignKey(schemaForeignKey *schemasv1alpha4.SqliteTableForeignKey) *ForeignKey {
 	foreignKey := ForeignKey{
 		ChildColumns:  schemaForeignKey.Columns,
@@ -131,11 +200,14 @@ func SqliteSchemaForeignKeyToForeignKey(schemaForeignKey *schemasv1alpha4.Sqlite
 		ParentColumns: schemaForeignKey.References.Columns,
 		Name:          schemaForeignKey.Name,
 		OnDelete:      schemaForeignKey.OnDelete,
+		OnUpdate:      schemaForeignKey.OnUpdate,
 	}
 
 	return &foreignKey
 }
 
+// RqliteSchemaForeignKeyToForeignKey leaves out the match type, which rqlite parses but doesn't keep, and
+// always reports as NONE
 func RqliteSchemaForeignKeyToForeignKey(schemaForeignKey *schemasv1alpha4.RqliteTableForeignKey) *ForeignKey {
 	foreignKey := ForeignKey{
 		ChildColumns:  schemaForeignKey.Columns,
@@ -143,6 +215,7 @@ func RqliteSchemaForeignKeyToForeignKey(schemaForeignKey *schemasv1alpha4.Rqlite
 		ParentColumns: schemaForeignKey.References.Columns,
 		Name:          schemaForeignKey.Name,
 		OnDelete:      schemaForeignKey.OnDelete,
+		OnUpdate:      schemaForeignKey.OnUpdate,
 	}
 
 	return &foreignKey
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"testing"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ForeignKeyEquals(t *testing.T) {
+	current := func() *ForeignKey {
+		return &ForeignKey{
+			Name:          "orders_product_id_fkey",
+			ChildColumns:  []string{"product_id"},
+			ParentTable:   "products",
+			ParentColumns: []string{"id"},
+			OnDelete:      "NO ACTION",
+			OnUpdate:      "NO ACTION",
+			Match:         "NONE",
+		}
+	}
+	desired := func() *schemasv1alpha4.PostgresqlTableForeignKey {
+		return &schemasv1alpha4.PostgresqlTableForeignKey{
+			Name:    "orders_product_id_fkey",
+			Columns: []string{"product_id"},
+			References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{
+				Table:   "products",
+				Columns: []string{"id"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		current func() *ForeignKey
+		desired func() *schemasv1alpha4.PostgresqlTableForeignKey
+		want    bool
+	}{
+		{
+			name:    "defaults",
+			current: current,
+			desired: desired,
+			want:    true,
+		},
+		{
+			name:    "on update changed",
+			current: current,
+			desired: func() *schemasv1alpha4.PostgresqlTableForeignKey {
+				fk := desired()
+				fk.OnUpdate = "cascade"
+				return fk
+			},
+			want: false,
+		},
+		{
+			name: "same on update",
+			current: func() *ForeignKey {
+				fk := current()
+				fk.OnUpdate = "CASCADE"
+				return fk
+			},
+			desired: func() *schemasv1alpha4.PostgresqlTableForeignKey {
+				fk := desired()
+				fk.OnUpdate = "cascade"
+				return fk
+			},
+			want: true,
+		},
+		{
+			name:    "match changed",
+			current: current,
+			desired: func() *schemasv1alpha4.PostgresqlTableForeignKey {
+				fk := desired()
+				fk.Match = "full"
+				return fk
+			},
+			want: false,
+		},
+		{
+			name: "initially deferred",
+			current: func() *ForeignKey {
+				fk := current()
+				fk.Deferrable = true
+				fk.InitiallyDeferred = true
+				return fk
+			},
+			desired: func() *schemasv1alpha4.PostgresqlTableForeignKey {
+				fk := desired()
+				fk.InitiallyDeferred = true
+				return fk
+			},
+			want: true,
+		},
+		{
+			name:    "deferrable changed",
+			current: current,
+			desired: func() *schemasv1alpha4.PostgresqlTableForeignKey {
+				fk := desired()
+				fk.Deferrable = true
+				return fk
+			},
+			want: false,
+		},
+		{
+			name:    "parent column changed",
+			current: current,
+			desired: func() *schemasv1alpha4.PostgresqlTableForeignKey {
+				fk := desired()
+				fk.References.Columns = []string{"uuid"}
+				return fk
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.current().Equals(PostgresqlSchemaForeignKeyToForeignKey(tt.desired())))
+		})
+	}
+}
+
+func Test_ForeignKeyEqualsIgnoresUnkeptMatch(t *testing.T) {
+	current := &ForeignKey{
+		ChildColumns:  []string{"account_id"},
+		ParentTable:   "accounts",
+		ParentColumns: []string{"id"},
+		Name:          "users_account_id_fkey",
+		Match:         "NONE",
+	}
+
+	mysqlForeignKey := &schemasv1alpha4.MysqlTableForeignKey{
+		Columns: []string{"account_id"},
+		References: schemasv1alpha4.MysqlTableForeignKeyReferences{
+			Table:   "accounts",
+			Columns: []string{"id"},
+		},
+		Name:  "users_account_id_fkey",
+		Match: "full",
+	}
+	assert.True(t, current.Equals(MysqlSchemaForeignKeyToForeignKey(mysqlForeignKey)))
+
+	sqliteForeignKey := &schemasv1alpha4.SqliteTableForeignKey{
+		Columns: []string{"account_id"},
+		References: schemasv1alpha4.SqliteTableForeignKeyReferences{
+			Table:   "accounts",
+			Columns: []string{"id"},
+		},
+		Name:  "users_account_id_fkey",
+		Match: "full",
+	}
+	assert.True(t, current.Equals(SqliteSchemaForeignKeyToForeignKey(sqliteForeignKey)))
+
+	rqliteForeignKey := &schemasv1alpha4.RqliteTableForeignKey{
+		Columns: []string{"account_id"},
+		References: schemasv1alpha4.RqliteTableForeignKeyReferences{
+			Table:   "accounts",
+			Columns: []string{"id"},
+		},
+		Name:  "users_account_id_fkey",
+		Match: "full",
+	}
+	assert.True(t, current.Equals(RqliteSchemaForeignKeyToForeignKey(rqliteForeignKey)))
+}
@@ -2,7 +2,9 @@ package types
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode"
 
 	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
 )
@@ -11,6 +13,11 @@ type Index struct {
 	Columns  []string
 	Name     string
 	IsUnique bool
+	// KeyOptions are the operator class and ordering of each of the columns, when they aren't the defaults
+	KeyOptions []string
+	Method     string
+	Where      string
+	Include    []string
 }
 
 func (idx *Index) Equals(other *Index) bool {
@@ -22,25 +29,93 @@ func (idx *Index) Equals(other *Index) bool {
 		return false
 	}
 
-	if len(idx.Columns) != len(other.Columns) {
+	// the method is only known when it's read from the database or set in the spec
+	if idx.Method != "" && other.Method != "" && idx.Method != other.Method {
 		return false
 	}
 
-	for _, otherColumn := range other.Columns {
-		for _, col := range idx.Columns {
-			if col == otherColumn {
-				goto NextColumn
+	if normalizeExpression(idx.Where) != normalizeExpression(other.Where) {
+		return false
+	}
+
+	if !sameDefinitions(idx.Include, other.Include) {
+		return false
+	}
+
+	return sameDefinitions(idx.keyDefinitions(), other.keyDefinitions())
+}
+
+// keyDefinitions returns each of the columns with its options
+func (idx *Index) keyDefinitions() []string {
+	definitions := []string{}
+	for i, column := range idx.Columns {
+		if i < len(idx.KeyOptions) && idx.KeyOptions[i] != "" {
+			column = fmt.Sprintf("%s %s", column, idx.KeyOptions[i])
+		}
+		definitions = append(definitions, column)
+	}
+
+	return definitions
+}
+
+// sameDefinitions returns true if the two lists have the same definitions, in any order
+func sameDefinitions(definitions []string, otherDefinitions []string) bool {
+	if len(definitions) != len(otherDefinitions) {
+		return false
+	}
+
+	for _, otherDefinition := range otherDefinitions {
+		for _, definition := range definitions {
+			if normalizeExpression(definition) == normalizeExpression(otherDefinition) {
+				goto NextDefinition
 			}
 		}
 
 		return false
 
-	NextColumn:
+	NextDefinition:
 	}
 
 	return true
 }
 
+var castPattern = regexp.MustCompile(`::\s*[a-z_][a-z0-9_]*(\s+(varying|precision|with time zone|without time zone))?(\[\])?`)
+
+// normalizeExpression removes the type casts, parentheses, quotes and whitespace that the database adds
+// when it prints a column or expression, so they can be compared to the spec
+func normalizeExpression(definition string) string {
+	normalized := castPattern.ReplaceAllString(strings.ToLower(definition), "")
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' || r == '`' {
+			return -1
+		}
+		return r
+	}, normalized)
+}
+
+// PostgresqlIndexKeyOptions returns the operator class and ordering of the key, leaving out the defaults
+func PostgresqlIndexKeyOptions(key schemasv1alpha4.PostgresqlTableIndexKey) string {
+	options := []string{}
+	if key.OpClass != "" {
+		options = append(options, key.OpClass)
+	}
+
+	isDescending := strings.EqualFold(key.Order, "desc")
+	if isDescending {
+		options = append(options, "desc")
+	}
+
+	// nulls sort last in ascending order and first in descending order unless they're set otherwise
+	if isDescending && strings.EqualFold(key.Nulls, "last") {
+		options = append(options, "nulls last")
+	} else if !isDescending && strings.EqualFold(key.Nulls, "first") {
+		options = append(options, "nulls first")
+	}
+
+	return strings.Join(options, " ")
+}
+
 func IndexToMysqlSchemaIndex(index *Index) *schemasv1alpha4.MysqlTableIndex {
 	schemaIndex := schemasv1alpha4.MysqlTableIndex{
 		Columns:  index.Columns,
@@ -53,9 +128,15 @@ func IndexToMysqlSchemaIndex(index *Index) *schemasv1alpha4.MysqlTableIndex {
 
 func IndexToPostgresqlSchemaIndex(index *Index) *schemasv1alpha4.PostgresqlTableIndex {
 	schemaIndex := schemasv1alpha4.PostgresqlTableIndex{
-		Columns:  index.Columns,
+		Columns:  index.keyDefinitions(),
 		Name:     index.Name,
 		IsUnique: index.IsUnique,
+		Where:    index.Where,
+		Include:  index.Include,
+	}
+
+	if index.Method != "" && index.Method != "btree" {
+		schemaIndex.Type = index.Method
 	}
 
 	return &schemaIndex
@@ -86,6 +167,21 @@ func PostgresqlSchemaIndexToIndex(schemaIndex *schemasv1alpha4.PostgresqlTableIn
 		Columns:  schemaIndex.Columns,
 		Name:     schemaIndex.Name,
 		IsUnique: schemaIndex.IsUnique,
+		Method:   strings.ToLower(schemaIndex.Type),
+		Where:    schemaIndex.Where,
+		Include:  schemaIndex.Include,
+	}
+
+	if len(schemaIndex.Keys) > 0 {
+		index.Columns = []string{}
+		for _, key := range schemaIndex.Keys {
+			if key.Column != "" {
+				index.Columns = append(index.Columns, key.Column)
+			} else {
+				index.Columns = append(index.Columns, key.Expression)
+			}
+			index.KeyOptions = append(index.KeyOptions, PostgresqlIndexKeyOptions(key))
+		}
 	}
 
 	return &index
@@ -119,8 +215,25 @@ func GenerateMysqlIndexName(tableName string, schemaIndex *schemasv1alpha4.Mysql
 	return indexName
 }
 
+var nonIdentifierPattern = regexp.MustCompile(`[^a-z0-9_]+`)
+
 func GeneratePostgresqlIndexName(tableName string, schemaIndex *schemasv1alpha4.PostgresqlTableIndex) string {
-	return fmt.Sprintf("idx_%s_%s", tableName, strings.Join(schemaIndex.Columns, "_"))
+	if len(schemaIndex.Keys) == 0 {
+		return fmt.Sprintf("idx_%s_%s", tableName, strings.Join(schemaIndex.Columns, "_"))
+	}
+
+	// expressions are named by the identifiers in them, so that lower(email) is named lower_email
+	keyNames := []string{}
+	for _, key := range schemaIndex.Keys {
+		if key.Column != "" {
+			keyNames = append(keyNames, key.Column)
+			continue
+		}
+		keyName := nonIdentifierPattern.ReplaceAllString(strings.ToLower(key.Expression), "_")
+		keyNames = append(keyNames, strings.Trim(keyName, "_"))
+	}
+
+	return fmt.Sprintf("idx_%s_%s", tableName, strings.Join(keyNames, "_"))
 }
 
 func GenerateSqliteIndexName(tableName string, schemaIndex *schemasv1alpha4.SqliteTableIndex) string {
@@ -49,3 +49,145 @@ func Test_GenerateMysqlIndexName(t *testing.T) {
 		})
 	}
 }
+
+func Test_GeneratePostgresqlIndexName(t *testing.T) {
+	tests := []struct {
+		name        string
+		tableName   string
+		schemaIndex *schemasv1alpha4.PostgresqlTableIndex
+		want        string
+	}{
+		{
+			name:      "columns",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Columns: []string{"first_name", "last_name"},
+			},
+			want: "idx_users_first_name_last_name",
+		},
+		{
+			name:      "keys with an expression",
+			tableName: "users",
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Keys: []schemasv1alpha4.PostgresqlTableIndexKey{
+					{Expression: "lower(email)"},
+					{Column: "created_at", Order: "desc"},
+				},
+			},
+			want: "idx_users_lower_email_created_at",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GeneratePostgresqlIndexName(tt.tableName, tt.schemaIndex); got != tt.want {
+				t.Errorf("GeneratePostgresqlIndexName() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_IndexEquals(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *Index
+		schemaIndex *schemasv1alpha4.PostgresqlTableIndex
+		want        bool
+	}{
+		{
+			name: "same columns in another order",
+			current: &Index{
+				Name:    "idx_users_a_b",
+				Columns: []string{"b", "a"},
+				Method:  "btree",
+			},
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Name:    "idx_users_a_b",
+				Columns: []string{"a", "b"},
+			},
+			want: true,
+		},
+		{
+			name: "different method",
+			current: &Index{
+				Name:    "idx_users_tags",
+				Columns: []string{"tags"},
+				Method:  "btree",
+			},
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Name:    "idx_users_tags",
+				Columns: []string{"tags"},
+				Type:    "gin",
+			},
+			want: false,
+		},
+		{
+			name: "expression and predicate as printed by postgres",
+			current: &Index{
+				Name:       "idx_users_lower_email",
+				Columns:    []string{"lower((email)::text)"},
+				KeyOptions: []string{"text_pattern_ops desc"},
+				Method:     "btree",
+				Where:      "(deleted_at IS NULL)",
+				Include:    []string{"id"},
+			},
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Name: "idx_users_lower_email",
+				Keys: []schemasv1alpha4.PostgresqlTableIndexKey{
+					{Expression: "lower(email)", OpClass: "text_pattern_ops", Order: "desc"},
+				},
+				Where:   "deleted_at is null",
+				Include: []string{"id"},
+			},
+			want: true,
+		},
+		{
+			name: "different predicate",
+			current: &Index{
+				Name:    "idx_users_email",
+				Columns: []string{"email"},
+				Where:   "(deleted_at IS NULL)",
+			},
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Name:    "idx_users_email",
+				Columns: []string{"email"},
+			},
+			want: false,
+		},
+		{
+			name: "different ordering",
+			current: &Index{
+				Name:    "idx_users_created_at",
+				Columns: []string{"created_at"},
+			},
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Name: "idx_users_created_at",
+				Keys: []schemasv1alpha4.PostgresqlTableIndexKey{
+					{Column: "created_at", Order: "desc"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "default nulls ordering",
+			current: &Index{
+				Name:       "idx_users_created_at",
+				Columns:    []string{"created_at"},
+				KeyOptions: []string{"desc"},
+			},
+			schemaIndex: &schemasv1alpha4.PostgresqlTableIndex{
+				Name: "idx_users_created_at",
+				Keys: []schemasv1alpha4.PostgresqlTableIndexKey{
+					{Column: "created_at", Order: "desc", Nulls: "first"},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.current.Equals(PostgresqlSchemaIndexToIndex(tt.schemaIndex)); got != tt.want {
+				t.Errorf("Equals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
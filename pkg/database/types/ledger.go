@@ -0,0 +1,37 @@
+package types
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LedgerTableName is the table, created in the target database, that applied migrations are recorded in
+const LedgerTableName = "schemahero_migrations"
+
+// LedgerEntry is a row in the migration ledger table. TableName is empty for migrations that were planned for a
+// database, such as the extensions
+type LedgerEntry struct {
+	MigrationID string
+	TableName   string
+	SpecSHA     string
+	DDLHash     string
+	AppliedAt   time.Time
+	Duration    time.Duration
+}
+
+// DDLHash returns the sha256 of the statements that were applied, ignoring empty statements
+func DDLHash(statements []string) string {
+	filtered := []string{}
+	for _, statement := range statements {
+		statement = strings.TrimSpace(statement)
+		if statement == "" {
+			continue
+		}
+		filtered = append(filtered, statement)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(filtered, ";\n")))
+	return fmt.Sprintf("%x", sum)
+}
@@ -0,0 +1,14 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DDLHash(t *testing.T) {
+	statements := []string{"create table a (id int)", "create index a_idx on a (id)"}
+
+	assert.Equal(t, DDLHash(statements), DDLHash([]string{"create table a (id int)", "", " create index a_idx on a (id) "}))
+	assert.NotEqual(t, DDLHash(statements), DDLHash([]string{"create index a_idx on a (id)", "create table a (id int)"}))
+}
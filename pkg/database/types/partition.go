@@ -0,0 +1,48 @@
+package types
+
+// Partition is a partition of a table. Bound is the clause that the rows of the partition match, such as
+// "for values in (1, 2)", and is empty for the partitions of a hash partitioned mysql table
+type Partition struct {
+	Name  string
+	Bound string
+}
+
+func (p *Partition) Equals(other *Partition) bool {
+	if p.Name != other.Name {
+		return false
+	}
+
+	return normalizeExpression(p.Bound) == normalizeExpression(other.Bound)
+}
+
+// Partitioning is how the rows of a table are split into partitions
+type Partitioning struct {
+	Strategy   string
+	Key        string
+	Partitions []*Partition
+}
+
+// KeyEquals returns true when both tables are partitioned the same way by the same key. The partitions
+// aren't compared
+func (p *Partitioning) KeyEquals(other *Partitioning) bool {
+	if p == nil || other == nil {
+		return p == nil && other == nil
+	}
+
+	if normalizeExpression(p.Strategy) != normalizeExpression(other.Strategy) {
+		return false
+	}
+
+	return normalizeExpression(p.Key) == normalizeExpression(other.Key)
+}
+
+// FindPartition returns the partition with the name, or nil when there isn't one
+func (p *Partitioning) FindPartition(name string) *Partition {
+	for _, partition := range p.Partitions {
+		if partition.Name == name {
+			return partition
+		}
+	}
+
+	return nil
+}
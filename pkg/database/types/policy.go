@@ -0,0 +1,66 @@
+package types
+
+import (
+	"sort"
+	"strings"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+// Policy is a row level security policy. Command is in lower case, and Roles are sorted
+type Policy struct {
+	Name      string
+	Command   string
+	Roles     []string
+	Using     string
+	WithCheck string
+}
+
+func (p *Policy) Equals(other *Policy) bool {
+	if p.Name != other.Name {
+		return false
+	}
+
+	if !strings.EqualFold(p.Command, other.Command) {
+		return false
+	}
+
+	if strings.Join(p.Roles, ",") != strings.Join(other.Roles, ",") {
+		return false
+	}
+
+	if normalizeExpression(p.Using) != normalizeExpression(other.Using) {
+		return false
+	}
+
+	return normalizeExpression(p.WithCheck) == normalizeExpression(other.WithCheck)
+}
+
+// PostgresqlSchemaPolicyToPolicy returns the policy with the defaults that postgres uses filled in, so that it
+// can be compared to the policies in pg_policies
+func PostgresqlSchemaPolicyToPolicy(schemaPolicy *schemasv1alpha4.PostgresqlTablePolicy) *Policy {
+	policy := Policy{
+		Name:      schemaPolicy.Name,
+		Command:   strings.ToLower(strings.TrimSpace(schemaPolicy.Command)),
+		Roles:     []string{},
+		Using:     schemaPolicy.Using,
+		WithCheck: schemaPolicy.WithCheck,
+	}
+
+	if policy.Command == "" {
+		policy.Command = "all"
+	}
+
+	for _, role := range schemaPolicy.Roles {
+		if strings.EqualFold(role, "public") {
+			role = "public"
+		}
+		policy.Roles = append(policy.Roles, role)
+	}
+	if len(policy.Roles) == 0 {
+		policy.Roles = append(policy.Roles, "public")
+	}
+	sort.Strings(policy.Roles)
+
+	return &policy
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"strings"
+
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+)
+
+type SequenceOptions struct {
+	Start     *int64
+	Increment *int64
+	MinValue  *int64
+	MaxValue  *int64
+	Cycle     bool
+}
+
+// Matches returns true when the options that are set in desired are the same as these. The options that
+// aren't set are left to the database, so they match anything
+func (o SequenceOptions) Matches(desired SequenceOptions) bool {
+	if !int64Matches(o.Start, desired.Start) {
+		return false
+	}
+	if !int64Matches(o.Increment, desired.Increment) {
+		return false
+	}
+	if !int64Matches(o.MinValue, desired.MinValue) {
+		return false
+	}
+	if !int64Matches(o.MaxValue, desired.MaxValue) {
+		return false
+	}
+
+	return o.Cycle == desired.Cycle
+}
+
+func int64Matches(current *int64, desired *int64) bool {
+	if desired == nil {
+		return true
+	}
+
+	return current != nil && *current == *desired
+}
+
+type Sequence struct {
+	Name    string
+	Options SequenceOptions
+	OwnedBy string
+}
+
+// ColumnIdentity is how the values of an identity column are generated. Generation is "always" or "by default"
+type ColumnIdentity struct {
+	Generation string
+	Options    SequenceOptions
+}
+
+// IdentitiesMatch returns true when neither column is an identity, or when both are generated the same way
+// and the options that are set in desired match
+func IdentitiesMatch(current *ColumnIdentity, desired *ColumnIdentity) bool {
+	if current == nil || desired == nil {
+		return current == nil && desired == nil
+	}
+
+	if !strings.EqualFold(current.Generation, desired.Generation) {
+		return false
+	}
+
+	return current.Options.Matches(desired.Options)
+}
+
+func PostgresqlSchemaSequenceOptionsToSequenceOptions(schemaOptions *schemasv1alpha4.PostgresqlSequenceOptions) SequenceOptions {
+	if schemaOptions == nil {
+		return SequenceOptions{}
+	}
+
+	return SequenceOptions{
+		Start:     schemaOptions.Start,
+		Increment: schemaOptions.Increment,
+		MinValue:  schemaOptions.MinValue,
+		MaxValue:  schemaOptions.MaxValue,
+		Cycle:     schemaOptions.Cycle,
+	}
+}
+
+func PostgresqlSchemaSequenceToSequence(schemaSequence *schemasv1alpha4.PostgresqlSequence) *Sequence {
+	return &Sequence{
+		Name:    schemaSequence.Name,
+		Options: PostgresqlSchemaSequenceOptionsToSequenceOptions(schemaSequence.Options),
+		OwnedBy: schemaSequence.OwnedBy,
+	}
+}
@@ -4,4 +4,5 @@ type Table struct {
 	Name      string
 	Charset   string
 	Collation string
+	Comment   string
 }
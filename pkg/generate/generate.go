@@ -170,6 +170,7 @@ func generateMysqlTableYAML(dbName string, table *types.Table, primaryKey []stri
 		Indexes:        schemaIndexes,
 		DefaultCharset: table.Charset,
 		Collation:      table.Collation,
+		Comment:        tableComment(table),
 	}
 
 	schema := &schemasv1alpha4.TableSchema{}
@@ -233,6 +234,7 @@ func generatePostgresqlTableYAML(driver string, dbName string, table *types.Tabl
 		Columns:     schemaTableColumns,
 		ForeignKeys: schemaForeignKeys,
 		Indexes:     schemaIndexes,
+		Comment:     tableComment(table),
 	}
 
 	schema := &schemasv1alpha4.TableSchema{}
@@ -333,6 +335,16 @@ metadata:
 	return tableDoc, nil
 }
 
+// tableComment returns the comment on the table for the spec, leaving it out when the table doesn't have one
+func tableComment(table *types.Table) *string {
+	if table.Comment == "" {
+		return nil
+	}
+
+	comment := table.Comment
+	return &comment
+}
+
 func sanitizeName(name string) string {
 	return strings.Replace(name, "_", "-", -1)
 }
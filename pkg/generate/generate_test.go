@@ -209,6 +209,46 @@ spec:
         type: integer
         attributes:
           autoIncrement: true
+`,
+		},
+		{
+			name:   "postgres -- comments",
+			driver: "postgres",
+			dbName: "db",
+			table: types.Table{
+				Name:    "orders",
+				Comment: "Customer orders",
+			},
+			primaryKey: []string{"id"},
+			columns: []*types.Column{
+				{
+					Name:     "id",
+					DataType: "integer",
+					Comment:  "Unique order number",
+				},
+				{
+					Name:     "total",
+					DataType: "integer",
+				},
+			},
+			expectedYAML: `apiVersion: schemas.schemahero.io/v1alpha4
+kind: Table
+metadata:
+  name: orders
+spec:
+  database: db
+  name: orders
+  schema:
+    postgres:
+      primaryKey:
+      - id
+      columns:
+      - name: id
+        type: integer
+        comment: Unique order number
+      - name: total
+        type: integer
+      comment: Customer orders
 `,
 		},
 		{
@@ -22,6 +22,13 @@ import (
 var tenSeconds = int64(10)
 var defaultMode = int32(420)
 
+// webhookLabels select the manager pod that serves the webhooks. The managers of the databases also have the
+// control-plane label, but they don't serve the webhooks
+var webhookLabels = map[string]string{
+	"control-plane": "schemahero",
+	"webhook":       "schemahero",
+}
+
 func namespaceYAML(name string) ([]byte, error) {
 	s := json.NewYAMLSerializer(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme)
 	var result bytes.Buffer
@@ -71,7 +78,7 @@ func serviceYAML(namespace string) ([]byte, error) {
 }
 
 func ensureService(ctx context.Context, clientset *kubernetes.Clientset, namespace string) error {
-	_, err := clientset.CoreV1().Services(namespace).Get(ctx, "controller-manager-service", metav1.GetOptions{})
+	existingService, err := clientset.CoreV1().Services(namespace).Get(ctx, "controller-manager-service", metav1.GetOptions{})
 	if err != nil {
 		if !kuberneteserrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get service")
@@ -81,6 +88,14 @@ func ensureService(ctx context.Context, clientset *kubernetes.Clientset, namespa
 		if err != nil {
 			return errors.Wrap(err, "failed to create service")
 		}
+
+		return nil
+	}
+
+	// services created by earlier versions select the managers of the databases too
+	existingService.Spec.Selector = service(namespace).Spec.Selector
+	if _, err := clientset.CoreV1().Services(namespace).Update(ctx, existingService, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to update service")
 	}
 
 	return nil
@@ -97,9 +112,7 @@ func service(namespace string) *corev1.Service {
 			Namespace: namespace,
 		},
 		Spec: corev1.ServiceSpec{
-			Selector: map[string]string{
-				"control-plane": "schemahero",
-			},
+			Selector: webhookLabels,
 			Ports: []corev1.ServicePort{
 				{
 					Port:       443,
@@ -229,9 +242,7 @@ func manager(namespace string) *appsv1.StatefulSet {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"control-plane": "schemahero",
-					},
+					Labels: webhookLabels,
 				},
 				Spec: corev1.PodSpec{
 					Affinity: &corev1.Affinity{
@@ -277,7 +288,7 @@ func manager(namespace string) *appsv1.StatefulSet {
 							Image:           schemaHeroManagerImage,
 							ImagePullPolicy: corev1.PullAlways,
 							Name:            "manager",
-							Command:         []string{"/manager", "run", "--enable-database-controller"},
+							Command:         []string{"/manager", "run", "--enable-database-controller", "--enable-webhooks"},
 							Env:             env,
 							Resources: corev1.ResourceRequirements{
 								Limits: corev1.ResourceList{
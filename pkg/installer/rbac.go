@@ -129,6 +129,11 @@ func clusterRole() *rbacv1.ClusterRole {
 				Resources: []string{"views/status"},
 				Verbs:     metav1.Verbs{"get", "update", "patch"},
 			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"events"},
+				Verbs:     metav1.Verbs{"create", "patch"},
+			},
 		},
 	}
 
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "schemahero"
+
+var (
+	plansTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "plans_total",
+		Help:      "Number of plans computed for tables and views.",
+	}, []string{"kind", "database", "driver", "result"})
+
+	plannedStatements = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "planned_statements",
+		Help:      "Number of statements generated by each successful plan.",
+		Buckets:   []float64{0, 1, 2, 5, 10, 25, 50, 100},
+	}, []string{"kind", "database", "driver"})
+
+	migrationExecutionSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "migration_execution_duration_seconds",
+		Help:      "Time taken to execute approved migrations against the database.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+	}, []string{"database", "driver", "result"})
+
+	connectionFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "database_connection_failures_total",
+		Help:      "Number of failed connection checks to databases.",
+	}, []string{"database", "driver"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		plansTotal,
+		plannedStatements,
+		migrationExecutionSeconds,
+		connectionFailuresTotal,
+	)
+}
+
+// ObservePlan records a plan computed for a table or view, and the number of statements it generated
+func ObservePlan(kind string, database string, driver string, statements int, err error) {
+	if err != nil {
+		plansTotal.WithLabelValues(kind, database, driver, "error").Inc()
+		return
+	}
+
+	plansTotal.WithLabelValues(kind, database, driver, "success").Inc()
+	plannedStatements.WithLabelValues(kind, database, driver).Observe(float64(statements))
+}
+
+// ObserveMigrationExecution records the time taken to execute a migration
+func ObserveMigrationExecution(database string, driver string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+
+	migrationExecutionSeconds.WithLabelValues(database, driver, result).Observe(duration.Seconds())
+}
+
+// IncConnectionFailures records a failed connection check to a database
+func IncConnectionFailures(database string, driver string) {
+	connectionFailuresTotal.WithLabelValues(database, driver).Inc()
+}
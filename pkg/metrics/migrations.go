@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/schemahero/schemahero/pkg/logger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	migrationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "migrations"),
+		"Number of migrations in each phase.",
+		[]string{"namespace", "database", "phase"}, nil,
+	)
+
+	oldestPendingApprovalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "oldest_pending_approval_age_seconds"),
+		"Age of the oldest migration that is waiting to be approved.",
+		[]string{"namespace", "database"}, nil,
+	)
+)
+
+// migrationCollector reports the state of the migrations for the databases that the manager is running for.
+// Migrations are read from the manager's cache each time metrics are collected
+type migrationCollector struct {
+	reader        client.Reader
+	databaseNames []string
+	now           func() time.Time
+}
+
+type migrationKey struct {
+	namespace string
+	database  string
+}
+
+// RegisterMigrationCollector adds the migrations for the databases to the metrics that are served by the manager
+func RegisterMigrationCollector(reader client.Reader, databaseNames []string) error {
+	collector := &migrationCollector{
+		reader:        reader,
+		databaseNames: databaseNames,
+		now:           time.Now,
+	}
+
+	if err := metrics.Registry.Register(collector); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return nil
+		}
+		return errors.Wrap(err, "failed to register migration collector")
+	}
+
+	return nil
+}
+
+func (c *migrationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- migrationsDesc
+	ch <- oldestPendingApprovalDesc
+}
+
+func (c *migrationCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	migrationList := schemasv1alpha4.MigrationList{}
+	if err := c.reader.List(ctx, &migrationList); err != nil {
+		logger.Error(errors.Wrap(err, "failed to list migrations for metrics"))
+		return
+	}
+
+	now := c.now()
+	counts := map[migrationKey]map[string]int{}
+	oldestPendingApproval := map[migrationKey]time.Duration{}
+
+	for _, migration := range migrationList.Items {
+		if !c.isManagedDatabase(migration.Spec.DatabaseName) {
+			continue
+		}
+
+		key := migrationKey{
+			namespace: migration.Namespace,
+			database:  migration.Spec.DatabaseName,
+		}
+
+		phase := migrationPhase(&migration)
+		if counts[key] == nil {
+			counts[key] = map[string]int{}
+		}
+		counts[key][phase]++
+
+		if phase == "planned" && migration.Status.PlannedAt > 0 {
+			age := now.Sub(time.Unix(migration.Status.PlannedAt, 0))
+			if age > oldestPendingApproval[key] {
+				oldestPendingApproval[key] = age
+			}
+		}
+	}
+
+	for key, phases := range counts {
+		for _, phase := range []string{"planned", "approved", "executed", "rejected"} {
+			ch <- prometheus.MustNewConstMetric(migrationsDesc, prometheus.GaugeValue, float64(phases[phase]), key.namespace, key.database, phase)
+		}
+
+		ch <- prometheus.MustNewConstMetric(oldestPendingApprovalDesc, prometheus.GaugeValue, oldestPendingApproval[key].Seconds(), key.namespace, key.database)
+	}
+}
+
+func (c *migrationCollector) isManagedDatabase(databaseName string) bool {
+	for _, managedDatabaseName := range c.databaseNames {
+		if managedDatabaseName == databaseName || managedDatabaseName == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// migrationPhase returns the phase of the migration from the times it was approved, rejected and executed,
+// because the phase in the status is not updated when a migration is rejected
+func migrationPhase(migration *schemasv1alpha4.Migration) string {
+	switch {
+	case migration.Status.ExecutedAt > 0:
+		return "executed"
+	case migration.Status.RejectedAt > 0:
+		return "rejected"
+	case migration.Status.ApprovedAt > 0:
+		return "approved"
+	default:
+		return "planned"
+	}
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_migrationPhase(t *testing.T) {
+	tests := []struct {
+		name   string
+		status schemasv1alpha4.MigrationStatus
+		want   string
+	}{
+		{
+			name:   "planned",
+			status: schemasv1alpha4.MigrationStatus{PlannedAt: 1},
+			want:   "planned",
+		},
+		{
+			name:   "approved",
+			status: schemasv1alpha4.MigrationStatus{PlannedAt: 1, ApprovedAt: 2},
+			want:   "approved",
+		},
+		{
+			name:   "rejected",
+			status: schemasv1alpha4.MigrationStatus{PlannedAt: 1, RejectedAt: 2, Phase: schemasv1alpha4.Planned},
+			want:   "rejected",
+		},
+		{
+			name:   "executed",
+			status: schemasv1alpha4.MigrationStatus{PlannedAt: 1, ApprovedAt: 2, ExecutedAt: 3},
+			want:   "executed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migration := &schemasv1alpha4.Migration{Status: tt.status}
+			assert.Equal(t, tt.want, migrationPhase(migration))
+		})
+	}
+}
+
+func Test_migrationCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, schemasv1alpha4.AddToScheme(scheme))
+
+	now := time.Unix(10000, 0)
+	migration := func(name string, databaseName string, status schemasv1alpha4.MigrationStatus) *schemasv1alpha4.Migration {
+		return &schemasv1alpha4.Migration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: schemasv1alpha4.MigrationSpec{
+				DatabaseName: databaseName,
+			},
+			Status: status,
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		migration("a", "db", schemasv1alpha4.MigrationStatus{PlannedAt: 9000}),
+		migration("b", "db", schemasv1alpha4.MigrationStatus{PlannedAt: 9900}),
+		migration("c", "db", schemasv1alpha4.MigrationStatus{PlannedAt: 100, ApprovedAt: 200, ExecutedAt: 300}),
+		migration("d", "other", schemasv1alpha4.MigrationStatus{PlannedAt: 100}),
+	).Build()
+
+	collector := &migrationCollector{
+		reader:        c,
+		databaseNames: []string{"db"},
+		now:           func() time.Time { return now },
+	}
+
+	expected := `
+# HELP schemahero_migrations Number of migrations in each phase.
+# TYPE schemahero_migrations gauge
+schemahero_migrations{database="db",namespace="default",phase="approved"} 0
+schemahero_migrations{database="db",namespace="default",phase="executed"} 1
+schemahero_migrations{database="db",namespace="default",phase="planned"} 2
+schemahero_migrations{database="db",namespace="default",phase="rejected"} 0
+# HELP schemahero_oldest_pending_approval_age_seconds Age of the oldest migration that is waiting to be approved.
+# TYPE schemahero_oldest_pending_approval_age_seconds gauge
+schemahero_oldest_pending_approval_age_seconds{database="db",namespace="default"} 1000
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	certificateValidity = 10 * 365 * 24 * time.Hour
+	renewBefore         = 30 * 24 * time.Hour
+)
+
+// certificates are the CA and serving certificate of the webhook server, PEM encoded
+type certificates struct {
+	caCert  []byte
+	tlsCert []byte
+	tlsKey  []byte
+}
+
+// ensureCertificates reads the serving certificate for the webhook server from the secret, generating
+// and saving a new one when the secret is empty or the certificate is about to expire
+func ensureCertificates(ctx context.Context, clientset kubernetes.Interface, namespace string, secretName string, serviceName string) (*certificates, error) {
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if kuberneteserrors.IsNotFound(err) {
+		secret = nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get secret")
+	}
+
+	if secret != nil {
+		existing := &certificates{
+			caCert:  secret.Data["ca.crt"],
+			tlsCert: secret.Data[corev1.TLSCertKey],
+			tlsKey:  secret.Data[corev1.TLSPrivateKeyKey],
+		}
+		if isCertificateValid(existing, time.Now()) {
+			return existing, nil
+		}
+	}
+
+	certs, err := generateCertificates(serviceName, namespace, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate certificates")
+	}
+
+	data := map[string][]byte{
+		"ca.crt":                certs.caCert,
+		corev1.TLSCertKey:       certs.tlsCert,
+		corev1.TLSPrivateKeyKey: certs.tlsKey,
+	}
+
+	if secret == nil {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: namespace,
+			},
+			Data: data,
+		}
+		if _, err := clientset.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return nil, errors.Wrap(err, "failed to create secret")
+		}
+		return certs, nil
+	}
+
+	secret.Data = data
+	if _, err := clientset.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return nil, errors.Wrap(err, "failed to update secret")
+	}
+
+	return certs, nil
+}
+
+// isCertificateValid returns true when the serving certificate is signed by the CA and is not about to expire
+func isCertificateValid(certs *certificates, now time.Time) bool {
+	if len(certs.caCert) == 0 || len(certs.tlsCert) == 0 || len(certs.tlsKey) == 0 {
+		return false
+	}
+
+	block, _ := pem.Decode(certs.tlsCert)
+	if block == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(certs.caCert) {
+		return false
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, CurrentTime: now}); err != nil {
+		return false
+	}
+
+	return now.Add(renewBefore).Before(cert.NotAfter)
+}
+
+// generateCertificates creates a self signed CA, and a serving certificate signed by it for the service
+func generateCertificates(serviceName string, namespace string, now time.Time) (*certificates, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate ca key")
+	}
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "schemahero-webhook-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certificateValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create ca certificate")
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse ca certificate")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: fmt.Sprintf("%s.%s.svc", serviceName, namespace)},
+		DNSNames: []string{
+			serviceName,
+			fmt.Sprintf("%s.%s", serviceName, namespace),
+			fmt.Sprintf("%s.%s.svc", serviceName, namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, namespace),
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(certificateValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create certificate")
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal key")
+	}
+
+	return &certificates{
+		caCert:  pemEncode("CERTIFICATE", caDER),
+		tlsCert: pemEncode("CERTIFICATE", der),
+		tlsKey:  pemEncode("EC PRIVATE KEY", keyDER),
+	}, nil
+}
+
+// writeCertificates writes the serving certificate to the directory that the webhook server loads it from
+func writeCertificates(certs *certificates, certDir string) error {
+	if err := os.MkdirAll(certDir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create cert dir")
+	}
+
+	if err := os.WriteFile(filepath.Join(certDir, corev1.TLSCertKey), certs.tlsCert, 0600); err != nil {
+		return errors.Wrap(err, "failed to write certificate")
+	}
+
+	if err := os.WriteFile(filepath.Join(certDir, corev1.TLSPrivateKeyKey), certs.tlsKey, 0600); err != nil {
+		return errors.Wrap(err, "failed to write key")
+	}
+
+	return nil
+}
+
+func pemEncode(blockType string, der []byte) []byte {
+	var buf bytes.Buffer
+	pem.Encode(&buf, &pem.Block{Type: blockType, Bytes: der})
+	return buf.Bytes()
+}
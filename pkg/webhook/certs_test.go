@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_generateCertificates(t *testing.T) {
+	now := time.Now()
+	certs, err := generateCertificates("controller-manager-service", "schemahero-system", now)
+	require.NoError(t, err)
+
+	_, err = tls.X509KeyPair(certs.tlsCert, certs.tlsKey)
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(certs.tlsCert)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(certs.caCert))
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:   roots,
+		DNSName: "controller-manager-service.schemahero-system.svc",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, isCertificateValid(certs, now))
+	assert.False(t, isCertificateValid(certs, now.Add(certificateValidity-renewBefore)))
+	assert.False(t, isCertificateValid(&certificates{}, now))
+
+	other, err := generateCertificates("controller-manager-service", "schemahero-system", now)
+	require.NoError(t, err)
+	assert.False(t, isCertificateValid(&certificates{caCert: other.caCert, tlsCert: certs.tlsCert, tlsKey: certs.tlsKey}, now))
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const validatingWebhookConfigurationName = "schemahero-validating-webhook-configuration"
+
+// ensureValidatingWebhookConfiguration registers the webhooks that validate tables, views and databases with the
+// api server, trusting the CA that signed the serving certificate
+func ensureValidatingWebhookConfiguration(ctx context.Context, clientset kubernetes.Interface, namespace string, caBundle []byte) error {
+	desired := validatingWebhookConfiguration(namespace, caBundle)
+
+	existing, err := clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(ctx, validatingWebhookConfigurationName, metav1.GetOptions{})
+	if kuberneteserrors.IsNotFound(err) {
+		if _, err := clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().Create(ctx, desired, metav1.CreateOptions{}); err != nil {
+			return errors.Wrap(err, "failed to create validating webhook configuration")
+		}
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to get validating webhook configuration")
+	}
+
+	existing.Webhooks = desired.Webhooks
+	if _, err := clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to update validating webhook configuration")
+	}
+
+	return nil
+}
+
+func validatingWebhookConfiguration(namespace string, caBundle []byte) *admissionregistrationv1.ValidatingWebhookConfiguration {
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "admissionregistration.k8s.io/v1",
+			Kind:       "ValidatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: validatingWebhookConfigurationName,
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			validatingWebhook("tables.schemas.schemahero.io", validateTablePath, "schemas.schemahero.io", "tables", namespace, caBundle),
+			validatingWebhook("views.schemas.schemahero.io", validateViewPath, "schemas.schemahero.io", "views", namespace, caBundle),
+			validatingWebhook("databases.databases.schemahero.io", validateDatabasePath, "databases.schemahero.io", "databases", namespace, caBundle),
+		},
+	}
+}
+
+func validatingWebhook(name string, path string, group string, resource string, namespace string, caBundle []byte) admissionregistrationv1.ValidatingWebhook {
+	port := int32(443)
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	timeoutSeconds := int32(10)
+
+	return admissionregistrationv1.ValidatingWebhook{
+		Name: name,
+		ClientConfig: admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{
+				Namespace: namespace,
+				Name:      serviceName,
+				Path:      &path,
+				Port:      &port,
+			},
+			CABundle: caBundle,
+		},
+		Rules: []admissionregistrationv1.RuleWithOperations{
+			{
+				Operations: []admissionregistrationv1.OperationType{
+					admissionregistrationv1.Create,
+					admissionregistrationv1.Update,
+				},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{group},
+					APIVersions: []string{"v1alpha4"},
+					Resources:   []string{resource},
+				},
+			},
+		},
+		FailurePolicy:           &failurePolicy,
+		SideEffects:             &sideEffects,
+		TimeoutSeconds:          &timeoutSeconds,
+		AdmissionReviewVersions: []string{"v1"},
+	}
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/schemahero/schemahero/pkg/logger"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	// serviceName is the service that the installer creates in front of the webhook server
+	serviceName = "controller-manager-service"
+
+	validateTablePath    = "/validate-table"
+	validateViewPath     = "/validate-view"
+	validateDatabasePath = "/validate-database"
+)
+
+func init() {
+	AddToManagerFuncs = append(AddToManagerFuncs, addValidatingWebhooks)
+}
+
+// addValidatingWebhooks serves the validating webhooks from the manager, and registers them with the api server.
+// The manager must be running in the namespace that the installer deployed it to, with the POD_NAMESPACE and
+// SECRET_NAME environment variables set
+func addValidatingWebhooks(mgr manager.Manager) error {
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		return errors.New("POD_NAMESPACE is required to run the webhook server")
+	}
+	secretName := os.Getenv("SECRET_NAME")
+	if secretName == "" {
+		return errors.New("SECRET_NAME is required to run the webhook server")
+	}
+
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return errors.Wrap(err, "failed to create clientset")
+	}
+
+	ctx := context.Background()
+	certs, err := ensureCertificates(ctx, clientset, namespace, secretName, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure webhook certificates")
+	}
+
+	server := mgr.GetWebhookServer()
+	if err := writeCertificates(certs, server.CertDir); err != nil {
+		return errors.Wrap(err, "failed to write webhook certificates")
+	}
+
+	if err := ensureValidatingWebhookConfiguration(ctx, clientset, namespace, certs.caCert); err != nil {
+		return errors.Wrap(err, "failed to ensure validating webhook configuration")
+	}
+
+	logger.Infof("Starting validating webhooks on port %d", server.Port)
+
+	server.Register(validateTablePath, &webhook.Admission{Handler: &tableValidator{reader: mgr.GetAPIReader()}})
+	server.Register(validateViewPath, &webhook.Admission{Handler: &viewValidator{reader: mgr.GetAPIReader()}})
+	server.Register(validateDatabasePath, &webhook.Admission{Handler: &databaseValidator{}})
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// databaseValidator rejects databases without exactly one connection, with maintenance windows that
+// can't be parsed, or with extensions that are missing a name or listed twice
+type databaseValidator struct{}
+
+func (v *databaseValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	database := databasesv1alpha4.Database{}
+	if err := json.Unmarshal(req.Object.Raw, &database); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "failed to decode database"))
+	}
+
+	return validationResponse(validateDatabase(&database))
+}
+
+func validateDatabase(database *databasesv1alpha4.Database) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
+	connection := database.Spec.Connection
+	connections := 0
+	for _, isSet := range []bool{
+		connection.Postgres != nil,
+		connection.Mysql != nil,
+		connection.CockroachDB != nil,
+		connection.Cassandra != nil,
+		connection.SQLite != nil,
+		connection.RQLite != nil,
+		connection.TimescaleDB != nil,
+	} {
+		if isSet {
+			connections++
+		}
+	}
+	if connections == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("connection"), "a connection is required"))
+	} else if connections > 1 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("connection"), connectionType(&connection), "only one connection can be set"))
+	}
+
+	if connection.Postgres != nil {
+		allErrs = append(allErrs, validateExtensions(specPath.Child("connection", "postgres", "extensions"), connection.Postgres.Extensions)...)
+	}
+	if connection.TimescaleDB != nil {
+		allErrs = append(allErrs, validateExtensions(specPath.Child("connection", "timescaledb", "extensions"), connection.TimescaleDB.Extensions)...)
+	}
+
+	windowsPath := specPath.Child("maintenanceWindows")
+	for i, window := range database.Spec.MaintenanceWindows {
+		windowOnly := databasesv1alpha4.Database{
+			Spec: databasesv1alpha4.DatabaseSpec{
+				MaintenanceWindows: []databasesv1alpha4.MaintenanceWindow{window},
+			},
+		}
+		if _, err := windowOnly.NextMaintenanceWindow(time.Now()); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowsPath.Index(i), window, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateExtensions checks that every extension has a name, and that no extension is listed twice
+func validateExtensions(path *field.Path, extensions []databasesv1alpha4.PostgresExtension) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := map[string]bool{}
+	for i, extension := range extensions {
+		namePath := path.Index(i).Child("name")
+		if extension.Name == "" {
+			allErrs = append(allErrs, field.Required(namePath, "an extension name is required"))
+			continue
+		}
+
+		if names[extension.Name] {
+			allErrs = append(allErrs, field.Duplicate(namePath, extension.Name))
+		}
+		names[extension.Name] = true
+	}
+
+	return allErrs
+}
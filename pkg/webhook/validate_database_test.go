@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"testing"
+
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateDatabase(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   databasesv1alpha4.DatabaseSpec
+		expect []string
+	}{
+		{
+			name: "valid database",
+			spec: databasesv1alpha4.DatabaseSpec{
+				Connection: databasesv1alpha4.DatabaseConnection{
+					Postgres: &databasesv1alpha4.PostgresConnection{},
+				},
+				MaintenanceWindows: []databasesv1alpha4.MaintenanceWindow{
+					{Days: []string{"sat", "sun"}, Start: "01:00", End: "05:00", Timezone: "UTC"},
+				},
+			},
+			expect: []string{},
+		},
+		{
+			name:   "no connection",
+			spec:   databasesv1alpha4.DatabaseSpec{},
+			expect: []string{"spec.connection"},
+		},
+		{
+			name: "two connections",
+			spec: databasesv1alpha4.DatabaseSpec{
+				Connection: databasesv1alpha4.DatabaseConnection{
+					Postgres: &databasesv1alpha4.PostgresConnection{},
+					Mysql:    &databasesv1alpha4.MysqlConnection{},
+				},
+			},
+			expect: []string{"spec.connection"},
+		},
+		{
+			name: "invalid maintenance window",
+			spec: databasesv1alpha4.DatabaseSpec{
+				Connection: databasesv1alpha4.DatabaseConnection{
+					Postgres: &databasesv1alpha4.PostgresConnection{},
+				},
+				MaintenanceWindows: []databasesv1alpha4.MaintenanceWindow{
+					{Start: "01:00", End: "05:00"},
+					{Days: []string{"someday"}, Start: "01:00", End: "05:00"},
+				},
+			},
+			expect: []string{"spec.maintenanceWindows[1]"},
+		},
+		{
+			name: "invalid extensions",
+			spec: databasesv1alpha4.DatabaseSpec{
+				Connection: databasesv1alpha4.DatabaseConnection{
+					TimescaleDB: &databasesv1alpha4.PostgresConnection{
+						Extensions: []databasesv1alpha4.PostgresExtension{
+							{Name: "timescaledb"},
+							{Version: "1.3"},
+							{Name: "timescaledb", Version: "2.5.0"},
+						},
+					},
+				},
+			},
+			expect: []string{
+				"spec.connection.timescaledb.extensions[1].name",
+				"spec.connection.timescaledb.extensions[2].name",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &databasesv1alpha4.Database{Spec: tt.spec}
+
+			fields := []string{}
+			for _, e := range validateDatabase(database) {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tt.expect, fields)
+		})
+	}
+}
@@ -0,0 +1,498 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	tablecontroller "github.com/schemahero/schemahero/pkg/controller/table"
+	"github.com/schemahero/schemahero/pkg/database/mysql"
+	"github.com/schemahero/schemahero/pkg/database/postgres"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// tableValidator rejects tables that can't be deployed to their database
+type tableValidator struct {
+	reader client.Reader
+}
+
+// tableShape is the part of a table schema that is validated the same way for every engine
+type tableShape struct {
+	path        *field.Path
+	isDeleted   bool
+	columns     []string
+	renamedFrom []string
+	primaryKey  []string
+	indexes     [][]string
+	foreignKeys []foreignKeyShape
+}
+
+type foreignKeyShape struct {
+	columns []string
+	table   string
+}
+
+func (v *tableValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	table := schemasv1alpha4.Table{}
+	if err := json.Unmarshal(req.Object.Raw, &table); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "failed to decode table"))
+	}
+
+	// an update only has to reference tables that exist in the foreign keys that it adds or changes
+	var oldTable *schemasv1alpha4.Table
+	if len(req.OldObject.Raw) > 0 {
+		oldTable = &schemasv1alpha4.Table{}
+		if err := json.Unmarshal(req.OldObject.Raw, oldTable); err != nil {
+			return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "failed to decode old table"))
+		}
+	}
+
+	allErrs, err := validateTable(ctx, v.reader, &table, oldTable)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return validationResponse(allErrs)
+}
+
+// validateTable returns the problems in the spec of the table. The database is only checked when it exists,
+// because tables can be deployed before their database. oldTable is the table before an update, and is nil
+// when the table is created
+func validateTable(ctx context.Context, reader client.Reader, table *schemasv1alpha4.Table, oldTable *schemasv1alpha4.Table) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	schemaPath := field.NewPath("spec", "schema")
+
+	schema := table.Spec.Schema
+	if schema == nil {
+		return append(allErrs, field.Required(schemaPath, "a schema is required")), nil
+	}
+
+	database := databasesv1alpha4.Database{}
+	err := reader.Get(ctx, types.NamespacedName{Namespace: table.Namespace, Name: table.Spec.Database}, &database)
+	if err != nil && !kuberneteserrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "failed to get database %s", table.Spec.Database)
+	}
+	databaseType := connectionType(&database.Spec.Connection)
+	if err == nil && databaseType != "" && !tablecontroller.CheckDatabaseTypeMatches(&database.Spec.Connection, schema) {
+		allErrs = append(allErrs, field.Required(schemaPath.Child(databaseType),
+			fmt.Sprintf("database %s is a %s database", database.Name, databaseType)))
+	}
+
+	if schema.Postgres != nil {
+		path := schemaPath.Child("postgres")
+		allErrs = append(allErrs, validatePostgresColumns(path, schema.Postgres.Columns)...)
+		allErrs = append(allErrs, validatePostgresIndexes(path, schema.Postgres.Indexes)...)
+	}
+	if schema.CockroachDB != nil {
+		path := schemaPath.Child("cockroachdb")
+		allErrs = append(allErrs, validatePostgresColumns(path, schema.CockroachDB.Columns)...)
+		allErrs = append(allErrs, validatePostgresIndexes(path, schema.CockroachDB.Indexes)...)
+	}
+	if schema.TimescaleDB != nil {
+		path := schemaPath.Child("timescaledb")
+		allErrs = append(allErrs, validatePostgresColumns(path, schema.TimescaleDB.Columns)...)
+		allErrs = append(allErrs, validatePostgresIndexes(path, schema.TimescaleDB.Indexes)...)
+	}
+	if schema.Mysql != nil {
+		path := schemaPath.Child("mysql")
+		allErrs = append(allErrs, validateMysqlColumns(path, schema.Mysql.Columns)...)
+		allErrs = append(allErrs, validateMysqlTableOptions(path, schema.Mysql)...)
+	}
+	if schema.Cassandra != nil && table.Spec.RenamedFrom != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "renamedFrom"), "cassandra does not support renaming tables"))
+	}
+
+	shapes := tableShapes(schemaPath, schema)
+	for _, shape := range shapes {
+		allErrs = append(allErrs, validateTableShape(shape)...)
+	}
+
+	oldShapes := []tableShape{}
+	if oldTable != nil && oldTable.Spec.Schema != nil && oldTable.Spec.Database == table.Spec.Database {
+		oldShapes = tableShapes(schemaPath, oldTable.Spec.Schema)
+	}
+
+	foreignKeyErrs, err := validateForeignKeyTables(ctx, reader, table, shapes, oldShapes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to validate foreign keys")
+	}
+	allErrs = append(allErrs, foreignKeyErrs...)
+
+	return allErrs, nil
+}
+
+// tableShapes returns the shapes of the schemas of every engine in the table schema
+func tableShapes(schemaPath *field.Path, schema *schemasv1alpha4.TableSchema) []tableShape {
+	shapes := []tableShape{}
+	if schema.Postgres != nil {
+		shapes = append(shapes, postgresTableShape(schemaPath.Child("postgres"), schema.Postgres))
+	}
+	if schema.CockroachDB != nil {
+		shapes = append(shapes, postgresTableShape(schemaPath.Child("cockroachdb"), schema.CockroachDB))
+	}
+	if schema.TimescaleDB != nil {
+		shapes = append(shapes, timescaleTableShape(schemaPath.Child("timescaledb"), schema.TimescaleDB))
+	}
+	if schema.Mysql != nil {
+		shapes = append(shapes, mysqlTableShape(schemaPath.Child("mysql"), schema.Mysql))
+	}
+	if schema.SQLite != nil {
+		shapes = append(shapes, sqliteTableShape(schemaPath.Child("sqlite"), schema.SQLite))
+	}
+	if schema.RQLite != nil {
+		shapes = append(shapes, rqliteTableShape(schemaPath.Child("rqlite"), schema.RQLite))
+	}
+	if schema.Cassandra != nil {
+		shapes = append(shapes, cassandraTableShape(schemaPath.Child("cassandra"), schema.Cassandra))
+	}
+
+	return shapes
+}
+
+// validateTableShape checks that the primary key, indexes and foreign keys only use columns in the table, and that
+// renamed columns aren't renamed from a column that is still in the table
+func validateTableShape(shape tableShape) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if shape.isDeleted {
+		return allErrs
+	}
+
+	columns := map[string]bool{}
+	for i, column := range shape.columns {
+		if columns[column] {
+			allErrs = append(allErrs, field.Duplicate(shape.path.Child("columns").Index(i).Child("name"), column))
+		}
+		columns[column] = true
+	}
+
+	// a column can't be renamed from a column that is still in the table, or that another column is renamed from
+	renamedFromColumns := map[string]bool{}
+	for i, renamedFrom := range shape.renamedFrom {
+		if renamedFrom == "" {
+			continue
+		}
+		if columns[renamedFrom] {
+			allErrs = append(allErrs, field.Invalid(shape.path.Child("columns").Index(i).Child("renamedFrom"), renamedFrom, "column is still defined in the table"))
+		}
+		if renamedFromColumns[renamedFrom] {
+			allErrs = append(allErrs, field.Duplicate(shape.path.Child("columns").Index(i).Child("renamedFrom"), renamedFrom))
+		}
+		renamedFromColumns[renamedFrom] = true
+	}
+
+	allErrs = append(allErrs, validateColumnReferences(shape.path.Child("primaryKey"), shape.primaryKey, columns)...)
+
+	for i, index := range shape.indexes {
+		allErrs = append(allErrs, validateColumnReferences(shape.path.Child("indexes").Index(i).Child("columns"), index, columns)...)
+	}
+
+	for i, foreignKey := range shape.foreignKeys {
+		allErrs = append(allErrs, validateColumnReferences(shape.path.Child("foreignKeys").Index(i).Child("columns"), foreignKey.columns, columns)...)
+	}
+
+	return allErrs
+}
+
+func validateColumnReferences(path *field.Path, references []string, columns map[string]bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, reference := range references {
+		if !columns[reference] {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), reference, "column is not defined in the table"))
+		}
+	}
+
+	return allErrs
+}
+
+// foreignKeyKey identifies a foreign key of a shape, so that it can be found in the shape before an update
+func foreignKeyKey(shape tableShape, foreignKey foreignKeyShape) string {
+	return fmt.Sprintf("%s|%s|%s", shape.path.String(), strings.Join(foreignKey.columns, ","), foreignKey.table)
+}
+
+// validateForeignKeyTables checks that every table referenced by a foreign key is defined by a Table in the
+// same namespace and database. Foreign keys that are unchanged from oldShapes aren't checked, so that an update
+// isn't rejected because a table that it already referenced was removed
+func validateForeignKeyTables(ctx context.Context, reader client.Reader, table *schemasv1alpha4.Table, shapes []tableShape, oldShapes []tableShape) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+
+	existingForeignKeys := map[string]bool{}
+	for _, shape := range oldShapes {
+		if shape.isDeleted {
+			continue
+		}
+		for _, foreignKey := range shape.foreignKeys {
+			existingForeignKeys[foreignKeyKey(shape, foreignKey)] = true
+		}
+	}
+
+	hasForeignKeys := false
+	for _, shape := range shapes {
+		if shape.isDeleted {
+			continue
+		}
+		for _, foreignKey := range shape.foreignKeys {
+			if !existingForeignKeys[foreignKeyKey(shape, foreignKey)] {
+				hasForeignKeys = true
+			}
+		}
+	}
+	if !hasForeignKeys {
+		return allErrs, nil
+	}
+
+	tableList := schemasv1alpha4.TableList{}
+	if err := reader.List(ctx, &tableList, client.InNamespace(table.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list tables")
+	}
+
+	tableNames := map[string]bool{
+		table.Spec.Name: true,
+	}
+	for _, existingTable := range tableList.Items {
+		if existingTable.Spec.Database == table.Spec.Database {
+			tableNames[existingTable.Spec.Name] = true
+		}
+	}
+
+	for _, shape := range shapes {
+		if shape.isDeleted {
+			continue
+		}
+
+		for i, foreignKey := range shape.foreignKeys {
+			if existingForeignKeys[foreignKeyKey(shape, foreignKey)] {
+				continue
+			}
+			if !tableNames[foreignKey.table] {
+				allErrs = append(allErrs, field.Invalid(shape.path.Child("foreignKeys").Index(i).Child("references", "table"), foreignKey.table,
+					fmt.Sprintf("no table in database %s is named %s", table.Spec.Database, foreignKey.table)))
+			}
+		}
+	}
+
+	return allErrs, nil
+}
+
+func validatePostgresColumns(path *field.Path, columns []*schemasv1alpha4.PostgresqlTableColumn) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, column := range columns {
+		if err := postgres.ValidateColumn(column); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("columns").Index(i).Child("type"), column.Type, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePostgresIndexes checks that each index is either a list of columns or a list of keys, and that each
+// key is either a column or an expression
+func validatePostgresIndexes(path *field.Path, indexes []*schemasv1alpha4.PostgresqlTableIndex) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, index := range indexes {
+		indexPath := path.Child("indexes").Index(i)
+		if len(index.Columns) > 0 && len(index.Keys) > 0 {
+			allErrs = append(allErrs, field.Invalid(indexPath.Child("keys"), index.Keys, "columns and keys can't both be set"))
+		} else if len(index.Columns) == 0 && len(index.Keys) == 0 {
+			allErrs = append(allErrs, field.Required(indexPath.Child("columns"), "columns or keys are required"))
+		}
+
+		for j, key := range index.Keys {
+			if (key.Column == "") == (key.Expression == "") {
+				allErrs = append(allErrs, field.Invalid(indexPath.Child("keys").Index(j), key, "exactly one of column and expression must be set"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateMysqlColumns(path *field.Path, columns []*schemasv1alpha4.MysqlTableColumn) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, column := range columns {
+		if err := mysql.ValidateColumn(column); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("columns").Index(i).Child("type"), column.Type, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateMysqlTableOptions(path *field.Path, schema *schemasv1alpha4.MysqlTableSchema) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if err := mysql.ValidateEngine(schema.Engine); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("engine"), schema.Engine, err.Error()))
+	}
+	if err := mysql.ValidateRowFormat(schema.RowFormat); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("rowFormat"), schema.RowFormat, err.Error()))
+	}
+
+	return allErrs
+}
+
+func postgresTableShape(path *field.Path, schema *schemasv1alpha4.PostgresqlTableSchema) tableShape {
+	shape := tableShape{
+		path:       path,
+		isDeleted:  schema.IsDeleted,
+		primaryKey: schema.PrimaryKey,
+	}
+	for _, column := range schema.Columns {
+		shape.columns = append(shape.columns, column.Name)
+		shape.renamedFrom = append(shape.renamedFrom, column.RenamedFrom)
+	}
+	for _, index := range schema.Indexes {
+		shape.indexes = append(shape.indexes, postgresIndexColumns(index))
+	}
+	for _, foreignKey := range schema.ForeignKeys {
+		shape.foreignKeys = append(shape.foreignKeys, foreignKeyShape{columns: foreignKey.Columns, table: foreignKey.References.Table})
+	}
+
+	return shape
+}
+
+func timescaleTableShape(path *field.Path, schema *schemasv1alpha4.TimescaleDBTableSchema) tableShape {
+	shape := tableShape{
+		path:       path,
+		isDeleted:  schema.IsDeleted,
+		primaryKey: schema.PrimaryKey,
+	}
+	for _, column := range schema.Columns {
+		shape.columns = append(shape.columns, column.Name)
+		shape.renamedFrom = append(shape.renamedFrom, column.RenamedFrom)
+	}
+	for _, index := range schema.Indexes {
+		shape.indexes = append(shape.indexes, postgresIndexColumns(index))
+	}
+	for _, foreignKey := range schema.ForeignKeys {
+		shape.foreignKeys = append(shape.foreignKeys, foreignKeyShape{columns: foreignKey.Columns, table: foreignKey.References.Table})
+	}
+
+	return shape
+}
+
+// postgresIndexColumns returns the columns that the index uses. Expressions aren't checked because they can
+// reference columns in any way
+func postgresIndexColumns(index *schemasv1alpha4.PostgresqlTableIndex) []string {
+	columns := append([]string{}, index.Columns...)
+	for _, key := range index.Keys {
+		if key.Column != "" {
+			columns = append(columns, key.Column)
+		}
+	}
+
+	return append(columns, index.Include...)
+}
+
+func mysqlTableShape(path *field.Path, schema *schemasv1alpha4.MysqlTableSchema) tableShape {
+	shape := tableShape{
+		path:       path,
+		isDeleted:  schema.IsDeleted,
+		primaryKey: schema.PrimaryKey,
+	}
+	for _, column := range schema.Columns {
+		shape.columns = append(shape.columns, column.Name)
+		shape.renamedFrom = append(shape.renamedFrom, column.RenamedFrom)
+	}
+	for _, index := range schema.Indexes {
+		shape.indexes = append(shape.indexes, index.Columns)
+	}
+	for _, foreignKey := range schema.ForeignKeys {
+		shape.foreignKeys = append(shape.foreignKeys, foreignKeyShape{columns: foreignKey.Columns, table: foreignKey.References.Table})
+	}
+
+	return shape
+}
+
+func sqliteTableShape(path *field.Path, schema *schemasv1alpha4.SqliteTableSchema) tableShape {
+	shape := tableShape{
+		path:       path,
+		isDeleted:  schema.IsDeleted,
+		primaryKey: schema.PrimaryKey,
+	}
+	for _, column := range schema.Columns {
+		shape.columns = append(shape.columns, column.Name)
+		shape.renamedFrom = append(shape.renamedFrom, column.RenamedFrom)
+	}
+	for _, index := range schema.Indexes {
+		shape.indexes = append(shape.indexes, index.Columns)
+	}
+	for _, foreignKey := range schema.ForeignKeys {
+		shape.foreignKeys = append(shape.foreignKeys, foreignKeyShape{columns: foreignKey.Columns, table: foreignKey.References.Table})
+	}
+
+	return shape
+}
+
+func rqliteTableShape(path *field.Path, schema *schemasv1alpha4.RqliteTableSchema) tableShape {
+	shape := tableShape{
+		path:       path,
+		isDeleted:  schema.IsDeleted,
+		primaryKey: schema.PrimaryKey,
+	}
+	for _, column := range schema.Columns {
+		shape.columns = append(shape.columns, column.Name)
+		shape.renamedFrom = append(shape.renamedFrom, column.RenamedFrom)
+	}
+	for _, index := range schema.Indexes {
+		shape.indexes = append(shape.indexes, index.Columns)
+	}
+	for _, foreignKey := range schema.ForeignKeys {
+		shape.foreignKeys = append(shape.foreignKeys, foreignKeyShape{columns: foreignKey.Columns, table: foreignKey.References.Table})
+	}
+
+	return shape
+}
+
+func cassandraTableShape(path *field.Path, schema *schemasv1alpha4.CassandraTableSchema) tableShape {
+	shape := tableShape{
+		path:      path,
+		isDeleted: schema.IsDeleted,
+	}
+	for _, column := range schema.Columns {
+		shape.columns = append(shape.columns, column.Name)
+		shape.renamedFrom = append(shape.renamedFrom, column.RenamedFrom)
+	}
+	for _, keyColumns := range schema.PrimaryKey {
+		shape.primaryKey = append(shape.primaryKey, keyColumns...)
+	}
+
+	return shape
+}
+
+// connectionType returns the name of the schema block that matches the type of the database connection
+func connectionType(connection *databasesv1alpha4.DatabaseConnection) string {
+	switch {
+	case connection.Postgres != nil:
+		return "postgres"
+	case connection.Mysql != nil:
+		return "mysql"
+	case connection.CockroachDB != nil:
+		return "cockroachdb"
+	case connection.Cassandra != nil:
+		return "cassandra"
+	case connection.SQLite != nil:
+		return "sqlite"
+	case connection.RQLite != nil:
+		return "rqlite"
+	case connection.TimescaleDB != nil:
+		return "timescaledb"
+	}
+
+	return ""
+}
+
+func validationResponse(allErrs field.ErrorList) admission.Response {
+	if len(allErrs) == 0 {
+		return admission.Allowed("")
+	}
+
+	return admission.Denied(allErrs.ToAggregate().Error())
+}
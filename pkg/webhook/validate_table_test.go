@@ -0,0 +1,309 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_validateTable(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, schemasv1alpha4.AddToScheme(scheme))
+	require.NoError(t, databasesv1alpha4.AddToScheme(scheme))
+
+	postgresDatabase := &databasesv1alpha4.Database{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+		},
+		Spec: databasesv1alpha4.DatabaseSpec{
+			Connection: databasesv1alpha4.DatabaseConnection{
+				Postgres: &databasesv1alpha4.PostgresConnection{},
+			},
+		},
+	}
+
+	usersTable := &schemasv1alpha4.Table{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "users",
+			Namespace: "default",
+		},
+		Spec: schemasv1alpha4.TableSpec{
+			Database: "db",
+			Name:     "users",
+		},
+	}
+
+	postgresTable := func(schema *schemasv1alpha4.PostgresqlTableSchema) *schemasv1alpha4.Table {
+		return &schemasv1alpha4.Table{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "orders",
+				Namespace: "default",
+			},
+			Spec: schemasv1alpha4.TableSpec{
+				Database: "db",
+				Name:     "orders",
+				Schema: &schemasv1alpha4.TableSchema{
+					Postgres: schema,
+				},
+			},
+		}
+	}
+
+	columns := []*schemasv1alpha4.PostgresqlTableColumn{
+		{Name: "id", Type: "integer"},
+		{Name: "user_id", Type: "integer"},
+	}
+
+	tests := []struct {
+		name     string
+		objects  []client.Object
+		table    *schemasv1alpha4.Table
+		oldTable *schemasv1alpha4.Table
+		expect   []string
+	}{
+		{
+			name:    "valid table",
+			objects: []client.Object{postgresDatabase, usersTable},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				PrimaryKey: []string{"id"},
+				Columns:    columns,
+				Indexes: []*schemasv1alpha4.PostgresqlTableIndex{
+					{Columns: []string{"user_id"}},
+				},
+				ForeignKeys: []*schemasv1alpha4.PostgresqlTableForeignKey{
+					{
+						Columns:    []string{"user_id"},
+						References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{Table: "users", Columns: []string{"id"}},
+					},
+				},
+			}),
+			expect: []string{},
+		},
+		{
+			name:    "missing schema",
+			objects: []client.Object{postgresDatabase},
+			table: &schemasv1alpha4.Table{
+				Spec: schemasv1alpha4.TableSpec{Database: "db"},
+			},
+			expect: []string{"spec.schema"},
+		},
+		{
+			name:    "schema does not match database",
+			objects: []client.Object{postgresDatabase},
+			table: &schemasv1alpha4.Table{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: schemasv1alpha4.TableSpec{
+					Database: "db",
+					Schema: &schemasv1alpha4.TableSchema{
+						Mysql: &schemasv1alpha4.MysqlTableSchema{},
+					},
+				},
+			},
+			expect: []string{"spec.schema.postgres"},
+		},
+		{
+			name:    "database does not exist yet",
+			objects: []client.Object{},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: columns,
+			}),
+			expect: []string{},
+		},
+		{
+			name:    "unknown column type",
+			objects: []client.Object{postgresDatabase},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{Name: "id", Type: "integer"},
+					{Name: "name", Type: "strnig"},
+				},
+			}),
+			expect: []string{"spec.schema.postgres.columns[1].type"},
+		},
+		{
+			name:    "primary key and index reference missing columns",
+			objects: []client.Object{postgresDatabase},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				PrimaryKey: []string{"uuid"},
+				Columns:    columns,
+				Indexes: []*schemasv1alpha4.PostgresqlTableIndex{
+					{Columns: []string{"user_id", "created_at"}},
+				},
+			}),
+			expect: []string{
+				"spec.schema.postgres.primaryKey[0]",
+				"spec.schema.postgres.indexes[0].columns[1]",
+			},
+		},
+		{
+			name:    "index keys without a column or expression",
+			objects: []client.Object{postgresDatabase},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: columns,
+				Indexes: []*schemasv1alpha4.PostgresqlTableIndex{
+					{
+						Keys: []schemasv1alpha4.PostgresqlTableIndexKey{
+							{Expression: "lower(name)"},
+							{Order: "desc"},
+						},
+					},
+					{},
+				},
+			}),
+			expect: []string{
+				"spec.schema.postgres.indexes[0].keys[1]",
+				"spec.schema.postgres.indexes[1].columns",
+			},
+		},
+		{
+			name:    "column renamed from a column that is still defined",
+			objects: []client.Object{postgresDatabase},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{Name: "id", Type: "integer"},
+					{Name: "customer_id", Type: "integer", RenamedFrom: "user_id"},
+					{Name: "user_id", Type: "integer"},
+				},
+			}),
+			expect: []string{"spec.schema.postgres.columns[1].renamedFrom"},
+		},
+		{
+			name:    "two columns renamed from the same column",
+			objects: []client.Object{postgresDatabase},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: []*schemasv1alpha4.PostgresqlTableColumn{
+					{Name: "id", Type: "integer"},
+					{Name: "customer_id", Type: "integer", RenamedFrom: "user_id"},
+					{Name: "account_id", Type: "integer", RenamedFrom: "user_id"},
+				},
+			}),
+			expect: []string{"spec.schema.postgres.columns[2].renamedFrom"},
+		},
+		{
+			name:    "cassandra table renamed",
+			objects: []client.Object{},
+			table: &schemasv1alpha4.Table{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: schemasv1alpha4.TableSpec{
+					Database:    "db",
+					Name:        "accounts",
+					RenamedFrom: "users",
+					Schema: &schemasv1alpha4.TableSchema{
+						Cassandra: &schemasv1alpha4.CassandraTableSchema{},
+					},
+				},
+			},
+			expect: []string{"spec.renamedFrom"},
+		},
+		{
+			name:    "foreign key references a table that does not exist",
+			objects: []client.Object{postgresDatabase},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: columns,
+				ForeignKeys: []*schemasv1alpha4.PostgresqlTableForeignKey{
+					{
+						Columns:    []string{"user_id"},
+						References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{Table: "users", Columns: []string{"id"}},
+					},
+				},
+			}),
+			expect: []string{"spec.schema.postgres.foreignKeys[0].references.table"},
+		},
+		{
+			name:    "update that keeps a foreign key to a table that does not exist",
+			objects: []client.Object{postgresDatabase},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: append(columns, &schemasv1alpha4.PostgresqlTableColumn{Name: "total", Type: "integer"}),
+				ForeignKeys: []*schemasv1alpha4.PostgresqlTableForeignKey{
+					{
+						Columns:    []string{"user_id"},
+						References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{Table: "users", Columns: []string{"id"}},
+					},
+				},
+			}),
+			oldTable: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: columns,
+				ForeignKeys: []*schemasv1alpha4.PostgresqlTableForeignKey{
+					{
+						Columns:    []string{"user_id"},
+						References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{Table: "users", Columns: []string{"id"}},
+					},
+				},
+			}),
+			expect: []string{},
+		},
+		{
+			name:    "update that changes a foreign key to a table that does not exist",
+			objects: []client.Object{postgresDatabase},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: columns,
+				ForeignKeys: []*schemasv1alpha4.PostgresqlTableForeignKey{
+					{
+						Columns:    []string{"user_id"},
+						References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{Table: "accounts", Columns: []string{"id"}},
+					},
+				},
+			}),
+			oldTable: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				Columns: columns,
+				ForeignKeys: []*schemasv1alpha4.PostgresqlTableForeignKey{
+					{
+						Columns:    []string{"user_id"},
+						References: schemasv1alpha4.PostgresqlTableForeignKeyReferences{Table: "users", Columns: []string{"id"}},
+					},
+				},
+			}),
+			expect: []string{"spec.schema.postgres.foreignKeys[0].references.table"},
+		},
+		{
+			name:    "unknown mysql engine and row format",
+			objects: []client.Object{},
+			table: &schemasv1alpha4.Table{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: schemasv1alpha4.TableSpec{
+					Database: "db",
+					Name:     "orders",
+					Schema: &schemasv1alpha4.TableSchema{
+						Mysql: &schemasv1alpha4.MysqlTableSchema{
+							Engine:    "innodb row_format=compact",
+							RowFormat: "tiny",
+						},
+					},
+				},
+			},
+			expect: []string{"spec.schema.mysql.engine", "spec.schema.mysql.rowFormat"},
+		},
+		{
+			name:    "deleted tables are not validated",
+			objects: []client.Object{postgresDatabase},
+			table: postgresTable(&schemasv1alpha4.PostgresqlTableSchema{
+				IsDeleted:  true,
+				PrimaryKey: []string{"uuid"},
+			}),
+			expect: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			allErrs, err := validateTable(context.Background(), reader, tt.table, tt.oldTable)
+			require.NoError(t, err)
+
+			fields := []string{}
+			for _, e := range allErrs {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tt.expect, fields)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	databasesv1alpha4 "github.com/schemahero/schemahero/pkg/apis/databases/v1alpha4"
+	schemasv1alpha4 "github.com/schemahero/schemahero/pkg/apis/schemas/v1alpha4"
+	viewcontroller "github.com/schemahero/schemahero/pkg/controller/view"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// viewValidator rejects views that can't be deployed to their database
+type viewValidator struct {
+	reader client.Reader
+}
+
+func (v *viewValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	view := schemasv1alpha4.View{}
+	if err := json.Unmarshal(req.Object.Raw, &view); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "failed to decode view"))
+	}
+
+	allErrs, err := validateView(ctx, v.reader, &view)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return validationResponse(allErrs)
+}
+
+// validateView returns the problems in the spec of the view. The database is only checked when it exists,
+// because views can be deployed before their database
+func validateView(ctx context.Context, reader client.Reader, view *schemasv1alpha4.View) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	schemaPath := field.NewPath("spec", "schema")
+
+	if view.Spec.Schema == nil {
+		return append(allErrs, field.Required(schemaPath, "a schema is required")), nil
+	}
+
+	database := databasesv1alpha4.Database{}
+	err := reader.Get(ctx, types.NamespacedName{Namespace: view.Namespace, Name: view.Spec.Database}, &database)
+	if err != nil && !kuberneteserrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "failed to get database %s", view.Spec.Database)
+	}
+	databaseType := connectionType(&database.Spec.Connection)
+	if err == nil && databaseType != "" && !viewcontroller.CheckDatabaseTypeMatches(&database.Spec.Connection, view.Spec.Schema) {
+		allErrs = append(allErrs, field.Required(schemaPath.Child(databaseType),
+			fmt.Sprintf("database %s is a %s database", database.Name, databaseType)))
+	}
+
+	return allErrs, nil
+}